@@ -143,7 +143,12 @@ type Request struct {
 	//	*Request_Headers
 	//	*Request_Message
 	//	*Request_RstStream
+	//	*Request_WindowUpdate
+	//	*Request_Ping
 	Type isRequest_Type `protobuf_oneof:"type"`
+	// If rst_stream is set, why the client reset the stream: CANCELLED if
+	// it was cancelled and DEADLINE_EXCEEDED if its deadline passed.
+	RstStreamStatus *status.Status `protobuf:"bytes,7,opt,name=rst_stream_status,json=rstStreamStatus,proto3" json:"rst_stream_status,omitempty"`
 }
 
 func (x *Request) Reset() {
@@ -213,6 +218,27 @@ func (x *Request) GetRstStream() bool {
 	return false
 }
 
+func (x *Request) GetWindowUpdate() *WindowUpdate {
+	if x, ok := x.GetType().(*Request_WindowUpdate); ok {
+		return x.WindowUpdate
+	}
+	return nil
+}
+
+func (x *Request) GetPing() *Ping {
+	if x, ok := x.GetType().(*Request_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *Request) GetRstStreamStatus() *status.Status {
+	if x != nil {
+		return x.RstStreamStatus
+	}
+	return nil
+}
+
 type isRequest_Type interface {
 	isRequest_Type()
 }
@@ -229,12 +255,24 @@ type Request_RstStream struct {
 	RstStream bool `protobuf:"varint,4,opt,name=rst_stream,json=rstStream,proto3,oneof"`
 }
 
+type Request_WindowUpdate struct {
+	WindowUpdate *WindowUpdate `protobuf:"bytes,5,opt,name=window_update,json=windowUpdate,proto3,oneof"`
+}
+
+type Request_Ping struct {
+	Ping *Ping `protobuf:"bytes,6,opt,name=ping,proto3,oneof"`
+}
+
 func (*Request_Headers) isRequest_Type() {}
 
 func (*Request_Message) isRequest_Type() {}
 
 func (*Request_RstStream) isRequest_Type() {}
 
+func (*Request_WindowUpdate) isRequest_Type() {}
+
+func (*Request_Ping) isRequest_Type() {}
+
 // RequestHeaders describe the unary or streaming call to make.
 type RequestHeaders struct {
 	state         protoimpl.MessageState
@@ -244,6 +282,20 @@ type RequestHeaders struct {
 	Method   string               `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
 	Metadata *Metadata            `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Timeout  *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// If set, the client supports per stream flow control and the server
+	// may send at most this many bytes of messages before being granted more
+	// by a WindowUpdate. A server supporting flow control replies with a
+	// WindowUpdate granting the client its own window.
+	WindowSize uint32 `protobuf:"varint,4,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	// If set, the server may send response messages that fit in a single
+	// packet over the unordered, unreliable data channel with this label
+	// instead of the one the request came in on. Such messages may be lost
+	// or arrive out of order and do not count against flow control.
+	ResponseDataChannel string `protobuf:"bytes,5,opt,name=response_data_channel,json=responseDataChannel,proto3" json:"response_data_channel,omitempty"`
+	// If set, the largest response message in bytes the client will accept.
+	// A server sending a larger one fails the call with RESOURCE_EXHAUSTED
+	// instead.
+	MaxResponseMessageSize uint32 `protobuf:"varint,6,opt,name=max_response_message_size,json=maxResponseMessageSize,proto3" json:"max_response_message_size,omitempty"`
 }
 
 func (x *RequestHeaders) Reset() {
@@ -299,6 +351,27 @@ func (x *RequestHeaders) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *RequestHeaders) GetWindowSize() uint32 {
+	if x != nil {
+		return x.WindowSize
+	}
+	return 0
+}
+
+func (x *RequestHeaders) GetResponseDataChannel() string {
+	if x != nil {
+		return x.ResponseDataChannel
+	}
+	return ""
+}
+
+func (x *RequestHeaders) GetMaxResponseMessageSize() uint32 {
+	if x != nil {
+		return x.MaxResponseMessageSize
+	}
+	return 0
+}
+
 // A RequestMessage contains individual gRPC messages and a potential
 // end-of-stream (EOS) marker.
 type RequestMessage struct {
@@ -379,6 +452,8 @@ type Response struct {
 	//	*Response_Headers
 	//	*Response_Message
 	//	*Response_Trailers
+	//	*Response_WindowUpdate
+	//	*Response_Ping
 	Type isResponse_Type `protobuf_oneof:"type"`
 }
 
@@ -449,6 +524,20 @@ func (x *Response) GetTrailers() *ResponseTrailers {
 	return nil
 }
 
+func (x *Response) GetWindowUpdate() *WindowUpdate {
+	if x, ok := x.GetType().(*Response_WindowUpdate); ok {
+		return x.WindowUpdate
+	}
+	return nil
+}
+
+func (x *Response) GetPing() *Ping {
+	if x, ok := x.GetType().(*Response_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
 type isResponse_Type interface {
 	isResponse_Type()
 }
@@ -465,12 +554,24 @@ type Response_Trailers struct {
 	Trailers *ResponseTrailers `protobuf:"bytes,4,opt,name=trailers,proto3,oneof"`
 }
 
+type Response_WindowUpdate struct {
+	WindowUpdate *WindowUpdate `protobuf:"bytes,5,opt,name=window_update,json=windowUpdate,proto3,oneof"`
+}
+
+type Response_Ping struct {
+	Ping *Ping `protobuf:"bytes,6,opt,name=ping,proto3,oneof"`
+}
+
 func (*Response_Headers) isResponse_Type() {}
 
 func (*Response_Message) isResponse_Type() {}
 
 func (*Response_Trailers) isResponse_Type() {}
 
+func (*Response_WindowUpdate) isResponse_Type() {}
+
+func (*Response_Ping) isResponse_Type() {}
+
 // ResponseHeaders contain custom metadata that are sent to the client
 // before any message or trailers (unless only trailers are sent).
 type ResponseHeaders struct {
@@ -624,6 +725,126 @@ func (x *ResponseTrailers) GetMetadata() *Metadata {
 	return nil
 }
 
+// A WindowUpdate grants the other end of a stream permission to send
+// increment more bytes of messages. It is only sent on streams where
+// flow control was negotiated (see RequestHeaders.window_size).
+type WindowUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Increment uint32 `protobuf:"varint,1,opt,name=increment,proto3" json:"increment,omitempty"`
+}
+
+func (x *WindowUpdate) Reset() {
+	*x = WindowUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WindowUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowUpdate) ProtoMessage() {}
+
+func (x *WindowUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowUpdate.ProtoReflect.Descriptor instead.
+func (*WindowUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WindowUpdate) GetIncrement() uint32 {
+	if x != nil {
+		return x.Increment
+	}
+	return 0
+}
+
+// A Ping checks that the other end of a data channel is still there. It
+// belongs to no stream. A Ping without ack set is answered with a Ping
+// carrying the same data with ack set. A server pings once as soon as the
+// data channel opens so that clients know it answers pings.
+type Ping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data uint64 `protobuf:"varint,1,opt,name=data,proto3" json:"data,omitempty"`
+	Ack  bool   `protobuf:"varint,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	// ice_restart is set by a client that restarts ICE when its connection
+	// drops so that the server knows to hold on to the connection while it
+	// does so.
+	IceRestart bool `protobuf:"varint,3,opt,name=ice_restart,json=iceRestart,proto3" json:"ice_restart,omitempty"`
+}
+
+func (x *Ping) Reset() {
+	*x = Ping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ping) ProtoMessage() {}
+
+func (x *Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ping.ProtoReflect.Descriptor instead.
+func (*Ping) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Ping) GetData() uint64 {
+	if x != nil {
+		return x.Data
+	}
+	return 0
+}
+
+func (x *Ping) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *Ping) GetIceRestart() bool {
+	if x != nil {
+		return x.IceRestart
+	}
+	return false
+}
+
 // Strings are a series of values.
 type Strings struct {
 	state         protoimpl.MessageState
@@ -636,7 +857,7 @@ type Strings struct {
 func (x *Strings) Reset() {
 	*x = Strings{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[9]
+		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -649,7 +870,7 @@ func (x *Strings) String() string {
 func (*Strings) ProtoMessage() {}
 
 func (x *Strings) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[9]
+	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +883,7 @@ func (x *Strings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Strings.ProtoReflect.Descriptor instead.
 func (*Strings) Descriptor() ([]byte, []int) {
-	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{9}
+	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Strings) GetValues() []string {
@@ -685,7 +906,7 @@ type Metadata struct {
 func (x *Metadata) Reset() {
 	*x = Metadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[10]
+		mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -698,7 +919,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[10]
+	mi := &file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +932,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{10}
+	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Metadata) GetMd() map[string]*Strings {
@@ -736,7 +957,7 @@ var file_proto_rpc_webrtc_v1_grpc_proto_rawDesc = []byte{
 	0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
 	0x52, 0x03, 0x65, 0x6f, 0x6d, 0x22, 0x18, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
 	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0xe9, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x73,
+	0xa4, 0x03, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x73,
 	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76,
 	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
@@ -750,76 +971,113 @@ var file_proto_rpc_webrtc_v1_grpc_proto_rawDesc = []byte{
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x72, 0x73, 0x74, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x0e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x8e, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x61, 0x73,
-	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
-	0x68, 0x61, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x70, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77,
-	0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x03, 0x65, 0x6f, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x40, 0x0a, 0x07, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f,
+	0x65, 0x61, 0x6d, 0x12, 0x48, 0x0a, 0x0d, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
-	0x48, 0x00, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x40, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x43, 0x0a,
-	0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72,
-	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65,
-	0x72, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x4c, 0x0a, 0x0f, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5c, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x70,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x79, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x21, 0x0a, 0x07, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x35, 0x0a, 0x02, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a,
+	0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x3e,
+	0x0a, 0x11, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0f, 0x72,
+	0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x06,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xa8, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x33, 0x0a, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x39, 0x0a, 0x19, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x22, 0x8e, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
-	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4d, 0x64, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6d, 0x64, 0x1a, 0x53, 0x0a, 0x07, 0x4d, 0x64, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x27, 0x5a,
-	0x25, 0x67, 0x6f, 0x2e, 0x76, 0x69, 0x61, 0x6d, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x74, 0x69,
-	0x6c, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x77, 0x65, 0x62,
-	0x72, 0x74, 0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x65,
+	0x6f, 0x73, 0x22, 0x8b, 0x03, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x33, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x40, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x40, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x69,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
+	0x73, 0x48, 0x00, 0x52, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x48, 0x0a,
+	0x0d, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x4c, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5c,
+	0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x49, 0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x79, 0x0a, 0x10,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73,
+	0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2c, 0x0a, 0x0c, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x4d, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x61, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x22, 0x21, 0x0a, 0x07, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x35, 0x0a, 0x02, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x4d, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6d, 0x64, 0x1a, 0x53, 0x0a, 0x07, 0x4d,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x42, 0x27, 0x5a, 0x25, 0x67, 0x6f, 0x2e, 0x76, 0x69, 0x61, 0x6d, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x75, 0x74, 0x69, 0x6c, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63, 0x2f,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -834,7 +1092,7 @@ func file_proto_rpc_webrtc_v1_grpc_proto_rawDescGZIP() []byte {
 	return file_proto_rpc_webrtc_v1_grpc_proto_rawDescData
 }
 
-var file_proto_rpc_webrtc_v1_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_rpc_webrtc_v1_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_rpc_webrtc_v1_grpc_proto_goTypes = []interface{}{
 	(*PacketMessage)(nil),       // 0: proto.rpc.webrtc.v1.PacketMessage
 	(*Stream)(nil),              // 1: proto.rpc.webrtc.v1.Stream
@@ -845,34 +1103,41 @@ var file_proto_rpc_webrtc_v1_grpc_proto_goTypes = []interface{}{
 	(*ResponseHeaders)(nil),     // 6: proto.rpc.webrtc.v1.ResponseHeaders
 	(*ResponseMessage)(nil),     // 7: proto.rpc.webrtc.v1.ResponseMessage
 	(*ResponseTrailers)(nil),    // 8: proto.rpc.webrtc.v1.ResponseTrailers
-	(*Strings)(nil),             // 9: proto.rpc.webrtc.v1.Strings
-	(*Metadata)(nil),            // 10: proto.rpc.webrtc.v1.Metadata
-	nil,                         // 11: proto.rpc.webrtc.v1.Metadata.MdEntry
-	(*durationpb.Duration)(nil), // 12: google.protobuf.Duration
-	(*status.Status)(nil),       // 13: google.rpc.Status
+	(*WindowUpdate)(nil),        // 9: proto.rpc.webrtc.v1.WindowUpdate
+	(*Ping)(nil),                // 10: proto.rpc.webrtc.v1.Ping
+	(*Strings)(nil),             // 11: proto.rpc.webrtc.v1.Strings
+	(*Metadata)(nil),            // 12: proto.rpc.webrtc.v1.Metadata
+	nil,                         // 13: proto.rpc.webrtc.v1.Metadata.MdEntry
+	(*status.Status)(nil),       // 14: google.rpc.Status
+	(*durationpb.Duration)(nil), // 15: google.protobuf.Duration
 }
 var file_proto_rpc_webrtc_v1_grpc_proto_depIdxs = []int32{
 	1,  // 0: proto.rpc.webrtc.v1.Request.stream:type_name -> proto.rpc.webrtc.v1.Stream
 	3,  // 1: proto.rpc.webrtc.v1.Request.headers:type_name -> proto.rpc.webrtc.v1.RequestHeaders
 	4,  // 2: proto.rpc.webrtc.v1.Request.message:type_name -> proto.rpc.webrtc.v1.RequestMessage
-	10, // 3: proto.rpc.webrtc.v1.RequestHeaders.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
-	12, // 4: proto.rpc.webrtc.v1.RequestHeaders.timeout:type_name -> google.protobuf.Duration
-	0,  // 5: proto.rpc.webrtc.v1.RequestMessage.packet_message:type_name -> proto.rpc.webrtc.v1.PacketMessage
-	1,  // 6: proto.rpc.webrtc.v1.Response.stream:type_name -> proto.rpc.webrtc.v1.Stream
-	6,  // 7: proto.rpc.webrtc.v1.Response.headers:type_name -> proto.rpc.webrtc.v1.ResponseHeaders
-	7,  // 8: proto.rpc.webrtc.v1.Response.message:type_name -> proto.rpc.webrtc.v1.ResponseMessage
-	8,  // 9: proto.rpc.webrtc.v1.Response.trailers:type_name -> proto.rpc.webrtc.v1.ResponseTrailers
-	10, // 10: proto.rpc.webrtc.v1.ResponseHeaders.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
-	0,  // 11: proto.rpc.webrtc.v1.ResponseMessage.packet_message:type_name -> proto.rpc.webrtc.v1.PacketMessage
-	13, // 12: proto.rpc.webrtc.v1.ResponseTrailers.status:type_name -> google.rpc.Status
-	10, // 13: proto.rpc.webrtc.v1.ResponseTrailers.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
-	11, // 14: proto.rpc.webrtc.v1.Metadata.md:type_name -> proto.rpc.webrtc.v1.Metadata.MdEntry
-	9,  // 15: proto.rpc.webrtc.v1.Metadata.MdEntry.value:type_name -> proto.rpc.webrtc.v1.Strings
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	9,  // 3: proto.rpc.webrtc.v1.Request.window_update:type_name -> proto.rpc.webrtc.v1.WindowUpdate
+	10, // 4: proto.rpc.webrtc.v1.Request.ping:type_name -> proto.rpc.webrtc.v1.Ping
+	14, // 5: proto.rpc.webrtc.v1.Request.rst_stream_status:type_name -> google.rpc.Status
+	12, // 6: proto.rpc.webrtc.v1.RequestHeaders.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
+	15, // 7: proto.rpc.webrtc.v1.RequestHeaders.timeout:type_name -> google.protobuf.Duration
+	0,  // 8: proto.rpc.webrtc.v1.RequestMessage.packet_message:type_name -> proto.rpc.webrtc.v1.PacketMessage
+	1,  // 9: proto.rpc.webrtc.v1.Response.stream:type_name -> proto.rpc.webrtc.v1.Stream
+	6,  // 10: proto.rpc.webrtc.v1.Response.headers:type_name -> proto.rpc.webrtc.v1.ResponseHeaders
+	7,  // 11: proto.rpc.webrtc.v1.Response.message:type_name -> proto.rpc.webrtc.v1.ResponseMessage
+	8,  // 12: proto.rpc.webrtc.v1.Response.trailers:type_name -> proto.rpc.webrtc.v1.ResponseTrailers
+	9,  // 13: proto.rpc.webrtc.v1.Response.window_update:type_name -> proto.rpc.webrtc.v1.WindowUpdate
+	10, // 14: proto.rpc.webrtc.v1.Response.ping:type_name -> proto.rpc.webrtc.v1.Ping
+	12, // 15: proto.rpc.webrtc.v1.ResponseHeaders.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
+	0,  // 16: proto.rpc.webrtc.v1.ResponseMessage.packet_message:type_name -> proto.rpc.webrtc.v1.PacketMessage
+	14, // 17: proto.rpc.webrtc.v1.ResponseTrailers.status:type_name -> google.rpc.Status
+	12, // 18: proto.rpc.webrtc.v1.ResponseTrailers.metadata:type_name -> proto.rpc.webrtc.v1.Metadata
+	13, // 19: proto.rpc.webrtc.v1.Metadata.md:type_name -> proto.rpc.webrtc.v1.Metadata.MdEntry
+	11, // 20: proto.rpc.webrtc.v1.Metadata.MdEntry.value:type_name -> proto.rpc.webrtc.v1.Strings
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_proto_rpc_webrtc_v1_grpc_proto_init() }
@@ -990,7 +1255,7 @@ func file_proto_rpc_webrtc_v1_grpc_proto_init() {
 			}
 		}
 		file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Strings); i {
+			switch v := v.(*WindowUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1002,6 +1267,30 @@ func file_proto_rpc_webrtc_v1_grpc_proto_init() {
 			}
 		}
 		file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Strings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Metadata); i {
 			case 0:
 				return &v.state
@@ -1018,11 +1307,15 @@ func file_proto_rpc_webrtc_v1_grpc_proto_init() {
 		(*Request_Headers)(nil),
 		(*Request_Message)(nil),
 		(*Request_RstStream)(nil),
+		(*Request_WindowUpdate)(nil),
+		(*Request_Ping)(nil),
 	}
 	file_proto_rpc_webrtc_v1_grpc_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*Response_Headers)(nil),
 		(*Response_Message)(nil),
 		(*Response_Trailers)(nil),
+		(*Response_WindowUpdate)(nil),
+		(*Response_Ping)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1030,7 +1323,7 @@ func file_proto_rpc_webrtc_v1_grpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_rpc_webrtc_v1_grpc_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
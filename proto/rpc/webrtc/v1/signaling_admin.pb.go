@@ -0,0 +1,655 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: proto/rpc/webrtc/v1/signaling_admin.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CallQueueStatsRequest asks for what a call queue knows of.
+type CallQueueStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// when hosts is set, only the hosts and calls for these hosts are returned.
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *CallQueueStatsRequest) Reset() {
+	*x = CallQueueStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueStatsRequest) ProtoMessage() {}
+
+func (x *CallQueueStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueStatsRequest.ProtoReflect.Descriptor instead.
+func (*CallQueueStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CallQueueStatsRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+// CallQueueStatsResponse is what a call queue knows of.
+type CallQueueStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []*CallQueueHostStats `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	// operators is empty for call queues that are not distributed.
+	Operators []*CallQueueOperatorStats `protobuf:"bytes,2,rep,name=operators,proto3" json:"operators,omitempty"`
+	Calls     []*CallQueueCallStats     `protobuf:"bytes,3,rep,name=calls,proto3" json:"calls,omitempty"`
+}
+
+func (x *CallQueueStatsResponse) Reset() {
+	*x = CallQueueStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueStatsResponse) ProtoMessage() {}
+
+func (x *CallQueueStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueStatsResponse.ProtoReflect.Descriptor instead.
+func (*CallQueueStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CallQueueStatsResponse) GetHosts() []*CallQueueHostStats {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *CallQueueStatsResponse) GetOperators() []*CallQueueOperatorStats {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *CallQueueStatsResponse) GetCalls() []*CallQueueCallStats {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+// CallQueueHostStats is a host that has offers pending or answerers connected.
+type CallQueueHostStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// pending_offers is how many offers to the host have yet to be picked up
+	// by an answerer.
+	PendingOffers uint64 `protobuf:"varint,2,opt,name=pending_offers,json=pendingOffers,proto3" json:"pending_offers,omitempty"`
+	// connected_answerers is how many answerers are waiting for calls to the
+	// host.
+	ConnectedAnswerers uint64 `protobuf:"varint,3,opt,name=connected_answerers,json=connectedAnswerers,proto3" json:"connected_answerers,omitempty"`
+}
+
+func (x *CallQueueHostStats) Reset() {
+	*x = CallQueueHostStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueHostStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueHostStats) ProtoMessage() {}
+
+func (x *CallQueueHostStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueHostStats.ProtoReflect.Descriptor instead.
+func (*CallQueueHostStats) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CallQueueHostStats) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *CallQueueHostStats) GetPendingOffers() uint64 {
+	if x != nil {
+		return x.PendingOffers
+	}
+	return 0
+}
+
+func (x *CallQueueHostStats) GetConnectedAnswerers() uint64 {
+	if x != nil {
+		return x.ConnectedAnswerers
+	}
+	return 0
+}
+
+// CallQueueOperatorStats is an operator sharing a distributed call queue.
+type CallQueueOperatorStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// expires_at is when the operator is considered gone unless it checks in
+	// again.
+	ExpiresAt *timestamppb.Timestamp        `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Hosts     []*CallQueueOperatorHostStats `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *CallQueueOperatorStats) Reset() {
+	*x = CallQueueOperatorStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueOperatorStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueOperatorStats) ProtoMessage() {}
+
+func (x *CallQueueOperatorStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueOperatorStats.ProtoReflect.Descriptor instead.
+func (*CallQueueOperatorStats) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CallQueueOperatorStats) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CallQueueOperatorStats) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CallQueueOperatorStats) GetHosts() []*CallQueueOperatorHostStats {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+// CallQueueOperatorHostStats is how many callers and answerers for a host
+// are connected to an operator.
+type CallQueueOperatorHostStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host      string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Callers   uint64 `protobuf:"varint,2,opt,name=callers,proto3" json:"callers,omitempty"`
+	Answerers uint64 `protobuf:"varint,3,opt,name=answerers,proto3" json:"answerers,omitempty"`
+}
+
+func (x *CallQueueOperatorHostStats) Reset() {
+	*x = CallQueueOperatorHostStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueOperatorHostStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueOperatorHostStats) ProtoMessage() {}
+
+func (x *CallQueueOperatorHostStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueOperatorHostStats.ProtoReflect.Descriptor instead.
+func (*CallQueueOperatorHostStats) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CallQueueOperatorHostStats) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *CallQueueOperatorHostStats) GetCallers() uint64 {
+	if x != nil {
+		return x.Callers
+	}
+	return 0
+}
+
+func (x *CallQueueOperatorHostStats) GetAnswerers() uint64 {
+	if x != nil {
+		return x.Answerers
+	}
+	return 0
+}
+
+// CallQueueCallStats is a call that is in flight.
+type CallQueueCallStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid      string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Host      string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Deadline  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// answered is whether an answerer has picked up the offer.
+	Answered           bool   `protobuf:"varint,5,opt,name=answered,proto3" json:"answered,omitempty"`
+	CallerOperatorId   string `protobuf:"bytes,6,opt,name=caller_operator_id,json=callerOperatorId,proto3" json:"caller_operator_id,omitempty"`
+	AnswererOperatorId string `protobuf:"bytes,7,opt,name=answerer_operator_id,json=answererOperatorId,proto3" json:"answerer_operator_id,omitempty"`
+}
+
+func (x *CallQueueCallStats) Reset() {
+	*x = CallQueueCallStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallQueueCallStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallQueueCallStats) ProtoMessage() {}
+
+func (x *CallQueueCallStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallQueueCallStats.ProtoReflect.Descriptor instead.
+func (*CallQueueCallStats) Descriptor() ([]byte, []int) {
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CallQueueCallStats) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CallQueueCallStats) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *CallQueueCallStats) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *CallQueueCallStats) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *CallQueueCallStats) GetAnswered() bool {
+	if x != nil {
+		return x.Answered
+	}
+	return false
+}
+
+func (x *CallQueueCallStats) GetCallerOperatorId() string {
+	if x != nil {
+		return x.CallerOperatorId
+	}
+	return ""
+}
+
+func (x *CallQueueCallStats) GetAnswererOperatorId() string {
+	if x != nil {
+		return x.AnswererOperatorId
+	}
+	return ""
+}
+
+var File_proto_rpc_webrtc_v1_signaling_admin_proto protoreflect.FileDescriptor
+
+var file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x5f,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73,
+	0x22, 0xe1, 0x01, 0x0a, 0x16, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x3d, 0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x63,
+	0x61, 0x6c, 0x6c, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x12, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x73, 0x22, 0xaa, 0x01, 0x0a, 0x16, 0x43, 0x61, 0x6c, 0x6c,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x45, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x22, 0x68, 0x0a, 0x1a, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x73, 0x22, 0xab,
+	0x02, 0x0a, 0x12, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x43, 0x61, 0x6c, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x39, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x65, 0x72, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x65, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x32, 0x82, 0x01, 0x0a,
+	0x15, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x69, 0x0a, 0x0e, 0x43, 0x61, 0x6c, 0x6c, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x6f, 0x2e, 0x76, 0x69, 0x61, 0x6d, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x75, 0x74, 0x69, 0x6c, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x70, 0x63,
+	0x2f, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescOnce sync.Once
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescData = file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDesc
+)
+
+func file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescGZIP() []byte {
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescOnce.Do(func() {
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescData)
+	})
+	return file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDescData
+}
+
+var file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_rpc_webrtc_v1_signaling_admin_proto_goTypes = []interface{}{
+	(*CallQueueStatsRequest)(nil),      // 0: proto.rpc.webrtc.v1.CallQueueStatsRequest
+	(*CallQueueStatsResponse)(nil),     // 1: proto.rpc.webrtc.v1.CallQueueStatsResponse
+	(*CallQueueHostStats)(nil),         // 2: proto.rpc.webrtc.v1.CallQueueHostStats
+	(*CallQueueOperatorStats)(nil),     // 3: proto.rpc.webrtc.v1.CallQueueOperatorStats
+	(*CallQueueOperatorHostStats)(nil), // 4: proto.rpc.webrtc.v1.CallQueueOperatorHostStats
+	(*CallQueueCallStats)(nil),         // 5: proto.rpc.webrtc.v1.CallQueueCallStats
+	(*timestamppb.Timestamp)(nil),      // 6: google.protobuf.Timestamp
+}
+var file_proto_rpc_webrtc_v1_signaling_admin_proto_depIdxs = []int32{
+	2, // 0: proto.rpc.webrtc.v1.CallQueueStatsResponse.hosts:type_name -> proto.rpc.webrtc.v1.CallQueueHostStats
+	3, // 1: proto.rpc.webrtc.v1.CallQueueStatsResponse.operators:type_name -> proto.rpc.webrtc.v1.CallQueueOperatorStats
+	5, // 2: proto.rpc.webrtc.v1.CallQueueStatsResponse.calls:type_name -> proto.rpc.webrtc.v1.CallQueueCallStats
+	6, // 3: proto.rpc.webrtc.v1.CallQueueOperatorStats.expires_at:type_name -> google.protobuf.Timestamp
+	4, // 4: proto.rpc.webrtc.v1.CallQueueOperatorStats.hosts:type_name -> proto.rpc.webrtc.v1.CallQueueOperatorHostStats
+	6, // 5: proto.rpc.webrtc.v1.CallQueueCallStats.started_at:type_name -> google.protobuf.Timestamp
+	6, // 6: proto.rpc.webrtc.v1.CallQueueCallStats.deadline:type_name -> google.protobuf.Timestamp
+	0, // 7: proto.rpc.webrtc.v1.SignalingAdminService.CallQueueStats:input_type -> proto.rpc.webrtc.v1.CallQueueStatsRequest
+	1, // 8: proto.rpc.webrtc.v1.SignalingAdminService.CallQueueStats:output_type -> proto.rpc.webrtc.v1.CallQueueStatsResponse
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_rpc_webrtc_v1_signaling_admin_proto_init() }
+func file_proto_rpc_webrtc_v1_signaling_admin_proto_init() {
+	if File_proto_rpc_webrtc_v1_signaling_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueHostStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueOperatorStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueOperatorHostStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallQueueCallStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_rpc_webrtc_v1_signaling_admin_proto_goTypes,
+		DependencyIndexes: file_proto_rpc_webrtc_v1_signaling_admin_proto_depIdxs,
+		MessageInfos:      file_proto_rpc_webrtc_v1_signaling_admin_proto_msgTypes,
+	}.Build()
+	File_proto_rpc_webrtc_v1_signaling_admin_proto = out.File
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_rawDesc = nil
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_goTypes = nil
+	file_proto_rpc_webrtc_v1_signaling_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"errors"
+	"io"
+)
+
+// DefaultChunkSize is how many bytes a ChunkWriter sends per message by default. A message
+// this size still fits in a single WebRTC packet, so each one is handed to the receiver as
+// soon as it arrives rather than after the rest of a larger message is reassembled.
+const DefaultChunkSize = 1 << 15
+
+// A ChunkWriter is an io.Writer for byte oriented streaming calls, like uploads, that sends
+// what is written to it as a series of messages of at most a chunk each. How a chunk becomes
+// a message, and how it is sent, is up to the function it is made with, which is typically
+// a wrapper around the Send method of a generated stream. Nothing is buffered across writes,
+// so there is nothing to flush; the stream is closed as usual once done.
+type ChunkWriter struct {
+	send      func(chunk []byte) error
+	chunkSize int
+}
+
+// NewChunkWriter returns a ChunkWriter that sends chunks of at most chunkSize bytes with
+// send, which owns each chunk it is given. A chunkSize of zero means DefaultChunkSize.
+func NewChunkWriter(send func(chunk []byte) error, chunkSize int) *ChunkWriter {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &ChunkWriter{send: send, chunkSize: chunkSize}
+}
+
+// Write sends p as one or more chunks. Since messages may be used after being sent, such as
+// by stats handlers, and writers must not hold on to what they are given, each chunk is a
+// copy; ReadFrom avoids that.
+func (w *ChunkWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) != 0 {
+		n := w.chunkSize
+		if len(p) < n {
+			n = len(p)
+		}
+		chunk := make([]byte, n)
+		copy(chunk, p)
+		if err := w.send(chunk); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// ReadFrom sends everything read from r until io.EOF, reading each chunk straight into the
+// buffer that is sent. io.Copy uses it when copying into a ChunkWriter.
+func (w *ChunkWriter) ReadFrom(r io.Reader) (int64, error) {
+	var total int64
+	for {
+		chunk := make([]byte, w.chunkSize)
+		n, err := io.ReadFull(r, chunk)
+		if n != 0 {
+			if sendErr := w.send(chunk[:n]); sendErr != nil {
+				return total, sendErr
+			}
+			total += int64(n)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return total, nil
+			}
+			return total, err
+		}
+	}
+}
+
+// A ChunkReader is an io.Reader for byte oriented streaming calls that reads the chunks of
+// a series of messages, like those sent by a ChunkWriter, as they are received. At most one
+// message is held at a time, so no matter how much is streamed, memory use is bound by the
+// size of a message.
+type ChunkReader struct {
+	recv  func() ([]byte, error)
+	chunk []byte
+	err   error
+}
+
+// NewChunkReader returns a ChunkReader that gets each chunk from recv, which is typically a
+// wrapper around the Recv method of a generated stream. recv returns io.EOF once the stream
+// is done.
+func NewChunkReader(recv func() ([]byte, error)) *ChunkReader {
+	return &ChunkReader{recv: recv}
+}
+
+// next makes sure there is a chunk to read from unless the stream is done.
+func (r *ChunkReader) next() error {
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		r.chunk, r.err = r.recv()
+	}
+	return nil
+}
+
+// Read reads from the current chunk, receiving the next one once it is used up.
+func (r *ChunkReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := r.next(); err != nil {
+		return 0, err
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// WriteTo writes every chunk to w as it is received, without copying it first, until the
+// stream is done. io.Copy uses it when copying from a ChunkReader.
+func (r *ChunkReader) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for {
+		if err := r.next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return total, nil
+			}
+			return total, err
+		}
+		n, err := w.Write(r.chunk)
+		total += int64(n)
+		r.chunk = r.chunk[n:]
+		if err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestChunkWriter(t *testing.T) {
+	data := []byte("the quick brown fox jumps over the lazy dog")
+	var chunks [][]byte
+	w := NewChunkWriter(func(chunk []byte) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}, 10)
+
+	n, err := w.Write(data)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, n, test.ShouldEqual, len(data))
+	test.That(t, chunks, test.ShouldHaveLength, 5)
+	for _, chunk := range chunks {
+		test.That(t, len(chunk), test.ShouldBeLessThanOrEqualTo, 10)
+	}
+	test.That(t, bytes.Join(chunks, nil), test.ShouldResemble, data)
+
+	// chunks do not share memory with what was written
+	data[0] = 'T'
+	test.That(t, chunks[0][0], test.ShouldEqual, 't')
+
+	chunks = nil
+	copied, err := w.ReadFrom(bytes.NewReader(data))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, copied, test.ShouldEqual, int64(len(data)))
+	test.That(t, chunks, test.ShouldHaveLength, 5)
+	test.That(t, bytes.Join(chunks, nil), test.ShouldResemble, data)
+
+	errSend := errors.New("whoops")
+	sent := 0
+	w = NewChunkWriter(func(chunk []byte) error {
+		if sent == 2 {
+			return errSend
+		}
+		sent++
+		return nil
+	}, 10)
+	n, err = w.Write(data)
+	test.That(t, err, test.ShouldEqual, errSend)
+	test.That(t, n, test.ShouldEqual, 20)
+
+	test.That(t, NewChunkWriter(nil, 0).chunkSize, test.ShouldEqual, DefaultChunkSize)
+}
+
+func TestChunkReader(t *testing.T) {
+	chunksToRecv := func(chunks ...string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			if len(chunks) == 0 {
+				return nil, io.EOF
+			}
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return []byte(chunk), nil
+		}
+	}
+
+	r := NewChunkReader(chunksToRecv("hello", "", " ", "world"))
+	buf := make([]byte, 3)
+	n, err := r.Read(buf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(buf[:n]), test.ShouldEqual, "hel")
+	rest, err := io.ReadAll(r)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(rest), test.ShouldEqual, "lo world")
+	_, err = r.Read(buf)
+	test.That(t, err, test.ShouldEqual, io.EOF)
+
+	var out bytes.Buffer
+	copied, err := io.Copy(&out, NewChunkReader(chunksToRecv("hello", " ", "world")))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, copied, test.ShouldEqual, int64(11))
+	test.That(t, out.String(), test.ShouldEqual, "hello world")
+
+	errRecv := errors.New("whoops")
+	recvd := false
+	r = NewChunkReader(func() ([]byte, error) {
+		if recvd {
+			return nil, errRecv
+		}
+		recvd = true
+		return []byte("hello"), nil
+	})
+	out.Reset()
+	copied, err = io.Copy(&out, r)
+	test.That(t, err, test.ShouldEqual, errRecv)
+	test.That(t, copied, test.ShouldEqual, int64(5))
+	_, err = r.Read(buf)
+	test.That(t, err, test.ShouldEqual, errRecv)
+}
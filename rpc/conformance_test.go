@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+
+	"go.viam.com/utils/rpc/rpctest"
+	"go.viam.com/utils/testutils"
+)
+
+func TestConformance(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&rpctest.ConformanceServiceDesc,
+		rpctest.NewConformanceServer(),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+	addr := httpListener.Addr().String()
+
+	passthroughUnary := func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	passthroughStream := func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	webrtcOpts := WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true})
+
+	for _, tc := range []struct {
+		name string
+		dial func() (ClientConn, error)
+	}{
+		{"grpc", func() (ClientConn, error) {
+			return DialDirectGRPC(context.Background(), addr, logger, WithInsecure())
+		}},
+		{"grpc with compression", func() (ClientConn, error) {
+			return DialDirectGRPC(context.Background(), addr, logger, WithInsecure(), WithCompressor("gzip"))
+		}},
+		{"webrtc", func() (ClientConn, error) {
+			return DialWebRTC(context.Background(), addr, "yeehaw", logger, webrtcOpts)
+		}},
+		{"webrtc with compression", func() (ClientConn, error) {
+			return DialWebRTC(context.Background(), addr, "yeehaw", logger, webrtcOpts, WithCompressor("gzip"))
+		}},
+		{"webrtc with interceptors", func() (ClientConn, error) {
+			return DialWebRTC(context.Background(), addr, "yeehaw", logger, webrtcOpts,
+				WithUnaryClientInterceptor(passthroughUnary),
+				WithStreamClientInterceptor(passthroughStream),
+			)
+		}},
+		{"webrtc with multiple data channels", func() (ClientConn, error) {
+			return DialWebRTC(context.Background(), addr, "yeehaw", logger, WithWebRTCOptions(DialWebRTCOptions{
+				SignalingInsecure: true,
+				DataChannels:      []DataChannelOptions{{}, {}},
+			}))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := tc.dial()
+			test.That(t, err, test.ShouldBeNil)
+			defer func() {
+				test.That(t, conn.Close(), test.ShouldBeNil)
+			}()
+			rpctest.RunConformanceTests(t, func(t *testing.T) grpc.ClientConnInterface {
+				return conn
+			})
+		})
+	}
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
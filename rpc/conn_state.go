@@ -0,0 +1,265 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"go.viam.com/utils"
+)
+
+// A ClientConnStateReporter is a ClientConn that can report whether it is live and which
+// transport it ended up using. Connections returned by Dial, DialDirectGRPC, and DialWebRTC
+// implement it, except for direct gRPC connections which are returned as a *grpc.ClientConn
+// as they always have been; use TransportInfoFromClientConn to describe any of them.
+type ClientConnStateReporter interface {
+	ClientConn
+
+	// State returns the current connectivity state of the connection.
+	State() connectivity.State
+
+	// WaitForStateChange waits until the state differs from sourceState or ctx is done.
+	// It returns true in the former case and false in the latter.
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+
+	// TransportInfo describes the path the connection is currently taking to the server.
+	TransportInfo() TransportInfo
+}
+
+// TransportType describes how a connection reaches its server.
+type TransportType string
+
+// Known transport types.
+const (
+	TransportTypeUnknown = TransportType("")
+	TransportTypeGRPC    = TransportType("grpc")
+	TransportTypeWebRTC  = TransportType("webrtc")
+	TransportTypeUnix    = TransportType("unix")
+)
+
+// TransportInfo describes the path a ClientConn takes to its server. Fields that do not
+// apply to the transport in use, or that are not known yet, are left empty.
+type TransportInfo struct {
+	Type TransportType
+
+	// MulticastDNS is true if the server's address was discovered via mDNS or another
+	// Discoverer.
+	MulticastDNS bool
+
+	// LocalAddress and RemoteAddress are the addresses of each end of the connection. For
+	// WebRTC, these come from the selected ICE candidate pair. For direct gRPC, only the
+	// dialed address is known.
+	LocalAddress  string
+	RemoteAddress string
+
+	// SignalingServer is the signaling server that was used to establish a WebRTC connection.
+	SignalingServer string
+
+	// PeerConnectionID identifies a WebRTC peer connection.
+	PeerConnectionID string
+
+	// ICECandidatePair is a description of the selected WebRTC ICE candidate pair.
+	ICECandidatePair string
+}
+
+// clientConnState returns the state of the given connection. Connections from outside this
+// package whose state cannot be determined are assumed to be ready.
+func clientConnState(conn ClientConn) connectivity.State {
+	switch c := conn.(type) {
+	case ClientConnStateReporter:
+		return c.State()
+	case *grpc.ClientConn:
+		return c.GetState()
+	default:
+		return connectivity.Ready
+	}
+}
+
+// clientConnWaitForStateChange waits for the state of the given connection to change from
+// sourceState. Connections whose state cannot be determined never change.
+func clientConnWaitForStateChange(ctx context.Context, conn ClientConn, sourceState connectivity.State) bool {
+	switch c := conn.(type) {
+	case ClientConnStateReporter:
+		return c.WaitForStateChange(ctx, sourceState)
+	case *grpc.ClientConn:
+		return c.WaitForStateChange(ctx, sourceState)
+	default:
+		<-ctx.Done()
+		return false
+	}
+}
+
+// TransportInfoFromClientConn describes the path the given connection is currently taking to
+// its server. It works for any connection returned by Dial, DialDirectGRPC, and DialWebRTC.
+func TransportInfoFromClientConn(conn ClientConn) TransportInfo {
+	return clientConnTransportInfo(conn)
+}
+
+// clientConnTransportInfo returns the transport info of the given connection, if it has any.
+func clientConnTransportInfo(conn ClientConn) TransportInfo {
+	switch c := conn.(type) {
+	case ClientConnStateReporter:
+		return c.TransportInfo()
+	case *grpc.ClientConn:
+		if info, ok := directGRPCTransportInfos.Load(c); ok {
+			return info.(TransportInfo)
+		}
+		return newDirectGRPCTransportInfo(c.Target(), &dialOptions{})
+	default:
+		if unwrapped := unwrapClientConn(conn); unwrapped != conn {
+			return clientConnTransportInfo(unwrapped)
+		}
+		return TransportInfo{}
+	}
+}
+
+// directGRPCTransportInfos holds what is known about how each direct gRPC connection still
+// open was made, by *grpc.ClientConn. Connections are returned as is, so this cannot be kept
+// on a wrapper.
+var directGRPCTransportInfos sync.Map
+
+// trackDirectGRPCTransportInfo remembers the transport info of the given connection until it
+// is closed.
+func trackDirectGRPCTransportInfo(conn *grpc.ClientConn, info TransportInfo) {
+	if _, loaded := directGRPCTransportInfos.LoadOrStore(conn, info); loaded {
+		// cached connections are already tracked
+		return
+	}
+	utils.PanicCapturingGo(func() {
+		for state := conn.GetState(); state != connectivity.Shutdown; state = conn.GetState() {
+			conn.WaitForStateChange(context.Background(), state)
+		}
+		directGRPCTransportInfos.Delete(conn)
+	})
+}
+
+// newDirectGRPCTransportInfo describes a direct gRPC connection to the given address.
+func newDirectGRPCTransportInfo(address string, dOpts *dialOptions) TransportInfo {
+	info := TransportInfo{
+		Type:          TransportTypeGRPC,
+		MulticastDNS:  dOpts.usingMDNS,
+		RemoteAddress: address,
+	}
+	if strings.HasPrefix(address, "unix://") {
+		info.Type = TransportTypeUnix
+		info.RemoteAddress = strings.TrimPrefix(address, "unix://")
+	}
+	return info
+}
+
+// The wrappers below pass through to whatever they wrap.
+
+func (rc *reffedConn) State() connectivity.State {
+	return clientConnState(rc.ClientConn)
+}
+
+func (rc *reffedConn) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	return clientConnWaitForStateChange(ctx, rc.ClientConn, sourceState)
+}
+
+func (rc *reffedConn) TransportInfo() TransportInfo {
+	return clientConnTransportInfo(rc.ClientConn)
+}
+
+func (cc *clientConnWithCloseFunc) State() connectivity.State {
+	return clientConnState(cc.ClientConn)
+}
+
+func (cc *clientConnWithCloseFunc) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	return clientConnWaitForStateChange(ctx, cc.ClientConn, sourceState)
+}
+
+func (cc *clientConnWithCloseFunc) TransportInfo() TransportInfo {
+	return clientConnTransportInfo(cc.ClientConn)
+}
+
+func (cc clientConnRPCAuthenticator) State() connectivity.State {
+	return clientConnState(cc.ClientConn)
+}
+
+func (cc clientConnRPCAuthenticator) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	return clientConnWaitForStateChange(ctx, cc.ClientConn, sourceState)
+}
+
+func (cc clientConnRPCAuthenticator) TransportInfo() TransportInfo {
+	return clientConnTransportInfo(cc.ClientConn)
+}
+
+// State returns the state of the reconnecting connection itself, which is Ready only
+// while it holds a connection.
+func (rc *reconnectingClientConn) State() connectivity.State {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.state
+}
+
+// WaitForStateChange waits until the reconnecting connection leaves sourceState.
+func (rc *reconnectingClientConn) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	for {
+		rc.mu.Lock()
+		state, stateChanged := rc.state, rc.stateChanged
+		rc.mu.Unlock()
+		if state != sourceState {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-stateChanged:
+		}
+	}
+}
+
+// TransportInfo describes the connection currently in use, if any.
+func (rc *reconnectingClientConn) TransportInfo() TransportInfo {
+	rc.mu.Lock()
+	conn := rc.conn
+	rc.mu.Unlock()
+	if conn == nil {
+		return TransportInfo{}
+	}
+	return clientConnTransportInfo(conn)
+}
+
+// State reports the state of the data channel and peer connection underneath.
+func (ch *webrtcClientChannel) State() connectivity.State {
+	ch.webrtcBaseChannel.mu.Lock()
+	defer ch.webrtcBaseChannel.mu.Unlock()
+	return ch.stateLocked()
+}
+
+// WaitForStateChange waits until the channel leaves sourceState.
+func (ch *webrtcClientChannel) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	for {
+		ch.webrtcBaseChannel.mu.Lock()
+		state, stateChanged := ch.stateLocked(), ch.stateChanged
+		ch.webrtcBaseChannel.mu.Unlock()
+		if state != sourceState {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-stateChanged:
+		}
+	}
+}
+
+// TransportInfo describes the WebRTC connection, including the ICE candidate pair
+// currently selected.
+func (ch *webrtcClientChannel) TransportInfo() TransportInfo {
+	info := ch.transportInfo
+	info.Type = TransportTypeWebRTC
+	info.PeerConnectionID = getWebRTCPeerConnectionStats(ch.peerConn).ID
+	if candPair, ok := webrtcPeerConnCandPair(ch.peerConn); ok {
+		info.ICECandidatePair = candPair.String()
+		info.LocalAddress = net.JoinHostPort(candPair.Local.Address, strconv.Itoa(int(candPair.Local.Port)))
+		info.RemoteAddress = net.JoinHostPort(candPair.Remote.Address, strconv.Itoa(int(candPair.Remote.Port)))
+	}
+	return info
+}
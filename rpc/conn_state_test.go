@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestClientConnStateDirectGRPC(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{Enable: false}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	for _, reconnect := range []bool{false, true} {
+		opts := []DialOption{WithForceDirectGRPC(), WithInsecure()}
+		if reconnect {
+			opts = append(opts, WithReconnect(ReconnectOptions{}))
+		}
+		conn, err := Dial(context.Background(), httpListener.Addr().String(), logger, opts...)
+		test.That(t, err, test.ShouldBeNil)
+
+		if !reconnect {
+			_, ok := conn.(*grpc.ClientConn)
+			test.That(t, ok, test.ShouldBeTrue)
+		}
+		test.That(t, clientConnState(conn), test.ShouldEqual, connectivity.Ready)
+		test.That(t, TransportInfoFromClientConn(conn), test.ShouldResemble, TransportInfo{
+			Type:          TransportTypeGRPC,
+			RemoteAddress: httpListener.Addr().String(),
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		test.That(t, clientConnWaitForStateChange(ctx, conn, connectivity.Ready), test.ShouldBeFalse)
+		cancel()
+
+		test.That(t, conn.Close(), test.ShouldBeNil)
+		test.That(t, clientConnState(conn), test.ShouldEqual, connectivity.Shutdown)
+		test.That(t, clientConnWaitForStateChange(context.Background(), conn, connectivity.Ready), test.ShouldBeTrue)
+	}
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestClientConnStateUnix(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{Enable: false}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	dir, err := os.MkdirTemp("", "viam-test-*")
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, os.RemoveAll(dir), test.ShouldBeNil)
+	}()
+	socketPath := filepath.ToSlash(filepath.Join(dir, "test.sock"))
+	if runtime.GOOS == "windows" {
+		// see TestDialUnix
+		socketPath = socketPath[2:]
+	}
+
+	httpListener, err := net.Listen("unix", socketPath)
+	test.That(t, err, test.ShouldBeNil)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := Dial(context.Background(), "unix://"+socketPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, clientConnState(conn), test.ShouldEqual, connectivity.Ready)
+	test.That(t, TransportInfoFromClientConn(conn), test.ShouldResemble, TransportInfo{
+		Type:          TransportTypeUnix,
+		RemoteAddress: socketPath,
+	})
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestClientConnStateWebRTC(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	reporter, ok := conn.(ClientConnStateReporter)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, reporter.State(), test.ShouldEqual, connectivity.Ready)
+	info := reporter.TransportInfo()
+	test.That(t, info.Type, test.ShouldEqual, TransportTypeWebRTC)
+	test.That(t, info.MulticastDNS, test.ShouldBeFalse)
+	test.That(t, info.SignalingServer, test.ShouldEqual, httpListener.Addr().String())
+	test.That(t, info.PeerConnectionID, test.ShouldNotBeEmpty)
+	test.That(t, info.ICECandidatePair, test.ShouldNotBeEmpty)
+	test.That(t, info.LocalAddress, test.ShouldNotBeEmpty)
+	test.That(t, info.RemoteAddress, test.ShouldNotBeEmpty)
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, reporter.State(), test.ShouldEqual, connectivity.Shutdown)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
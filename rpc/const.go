@@ -1,4 +1,5 @@
 package rpc
 
-// MaxMessageSize is the maximum size a gRPC message can be.
+// MaxMessageSize is the default maximum size of a gRPC message that can be received. Servers
+// can change it with WithMaxRecvMsgSize and connections with WithDialMaxRecvMsgSize.
 var MaxMessageSize = 1 << 25
@@ -7,17 +7,17 @@ import (
 	"net"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/edaniels/golog"
-	"github.com/edaniels/zeroconf"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 // Dial attempts to make the most convenient connection to the given address. It attempts to connect
 // via WebRTC if a signaling server is detected or provided. Otherwise it attempts to connect directly.
-// TODO(GOUT-7): figure out decent way to handle reconnect on connection termination.
+// By default, the returned connection is not reestablished if it terminates; see WithReconnect.
+// The address may also be a DNS SRV target of the form dns+srv://_service._proto.name, in which
+// case each endpoint found is tried in turn; see also WithFallbackAddresses.
 func Dial(ctx context.Context, address string, logger golog.Logger, opts ...DialOption) (ClientConn, error) {
 	var dOpts dialOptions
 	for _, opt := range opts {
@@ -40,7 +40,23 @@ func dialInner(
 	if address == "" {
 		return nil, errors.New("address empty")
 	}
+	if dOpts.reconnectOpts != nil {
+		return dialReconnecting(ctx, address, logger, dOpts)
+	}
+	if len(dOpts.fallbackAddresses) != 0 || strings.HasPrefix(address, srvAddressPrefix) {
+		return dialFailover(ctx, address, logger, dOpts)
+	}
+	return dialAddress(ctx, address, address, logger, dOpts)
+}
 
+// dialAddress dials a single address via all of the methods the options allow.
+func dialAddress(
+	ctx context.Context,
+	address string,
+	originalAddress string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+) (ClientConn, error) {
 	conn, cached, err := dialFunc(
 		ctx,
 		"multi",
@@ -55,9 +71,9 @@ func dialInner(
 				if dOpts.externalAuthAddr == "" {
 					// if we are not doing external auth, then the entity is assumed to be the actual address.
 					if dOpts.debug {
-						logger.Debugw("auth entity empty; setting to address", "address", address)
+						logger.Debugw("auth entity empty; setting to address", "address", originalAddress)
 					}
-					dOpts.authEntity = address
+					dOpts.authEntity = originalAddress
 				} else {
 					// otherwise it's the external auth address.
 					if dOpts.debug {
@@ -67,7 +83,7 @@ func dialInner(
 				}
 			}
 
-			conn, _, err := dial(ctx, address, address, logger, dOpts, true)
+			conn, _, err := dial(ctx, address, originalAddress, logger, dOpts, true)
 			return conn, err
 		})
 	if err != nil {
@@ -107,7 +123,12 @@ func dial(
 		}
 	}
 
-	if !dOpts.mdnsOptions.Disable && tryLocal && isJustDomain {
+	tryMDNS := !dOpts.mdnsOptions.Disable && tryLocal && isJustDomain
+	if dOpts.raceTransports {
+		return dialRace(ctx, address, originalAddress, logger, dOpts, tryMDNS)
+	}
+
+	if tryMDNS {
 		conn, cached, err := dialMulticastDNS(ctx, address, logger, dOpts)
 		if err != nil {
 			logger.Warnw("error dialing with mDNS; falling back to other methods", "error", err)
@@ -119,56 +140,8 @@ func dial(
 	}
 
 	if !dOpts.webrtcOpts.Disable {
-		signalingAddress := dOpts.webrtcOpts.SignalingServerAddress
-		if signalingAddress == "" || dOpts.webrtcOpts.AllowAutoDetectAuthOptions {
-			if signalingAddress == "" {
-				// try WebRTC at same address
-				signalingAddress = address
-			}
-			target, port, err := getWebRTCTargetFromAddressWithDefaults(signalingAddress)
-			if err != nil {
-				return nil, false, err
-			}
-			fixupWebRTCOptions(dOpts, target, port)
-
-			// When connecting to an external signaler for WebRTC, we assume we can use the external auth's material.
-			// This path is also called by an mdns direct connection and ignores that case.
-			// This will skip all Authenticate/AuthenticateTo calls for the signaler.
-			if !dOpts.usingMDNS && dOpts.authMaterial == "" && dOpts.webrtcOpts.SignalingExternalAuthAuthMaterial != "" {
-				logger.Debug("using signaling's external auth as auth material")
-				dOpts.authMaterial = dOpts.webrtcOpts.SignalingExternalAuthAuthMaterial
-				dOpts.creds = Credentials{}
-			}
-		}
-
-		if dOpts.debug {
-			logger.Debugw(
-				"trying WebRTC",
-				"signaling_server", dOpts.webrtcOpts.SignalingServerAddress,
-				"host", originalAddress,
-			)
-		}
-
-		conn, cached, err := dialFunc(
-			ctx,
-			"webrtc",
-			fmt.Sprintf("%s->%s", dOpts.webrtcOpts.SignalingServerAddress, originalAddress),
-			buildKeyExtra(dOpts),
-			func() (ClientConn, error) {
-				return dialWebRTC(
-					ctx,
-					dOpts.webrtcOpts.SignalingServerAddress,
-					originalAddress,
-					dOpts,
-					logger,
-				)
-			})
+		conn, cached, err := dialWebRTCFromOptions(ctx, address, originalAddress, logger, dOpts)
 		if err == nil {
-			if !cached {
-				logger.Debug("connected via WebRTC")
-			} else if dOpts.debug {
-				logger.Debug("connected via WebRTC (cached)")
-			}
 			return conn, cached, nil
 		}
 		if !errors.Is(err, ErrNoWebRTCSignaler) {
@@ -182,13 +155,98 @@ func dial(
 	if dOpts.disableDirect {
 		return nil, false, ErrConnectionOptionsExhausted
 	}
+	return dialDirectGRPCFromOptions(ctx, address, logger, dOpts)
+}
+
+// dialWebRTCFromOptions attempts a WebRTC connection to originalAddress, working out the
+// signaling server to use from the options if one was not given explicitly.
+func dialWebRTCFromOptions(
+	ctx context.Context,
+	address string,
+	originalAddress string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+) (ClientConn, bool, error) {
+	signalingAddress := dOpts.webrtcOpts.SignalingServerAddress
+	if signalingAddress == "" || dOpts.webrtcOpts.AllowAutoDetectAuthOptions {
+		if signalingAddress == "" {
+			// try WebRTC at same address
+			signalingAddress = address
+		}
+		target, port, err := getWebRTCTargetFromAddressWithDefaults(signalingAddress)
+		if err != nil {
+			return nil, false, err
+		}
+		fixupWebRTCOptions(dOpts, target, port)
+
+		// When connecting to an external signaler for WebRTC, we assume we can use the external auth's material.
+		// This path is also called by an mdns direct connection and ignores that case.
+		// This will skip all Authenticate/AuthenticateTo calls for the signaler.
+		if !dOpts.usingMDNS && dOpts.authMaterial == "" && dOpts.webrtcOpts.SignalingExternalAuthAuthMaterial != "" {
+			logger.Debug("using signaling's external auth as auth material")
+			dOpts.authMaterial = dOpts.webrtcOpts.SignalingExternalAuthAuthMaterial
+			dOpts.creds = Credentials{}
+		}
+	}
+
 	if dOpts.debug {
-		logger.Debugw("trying direct", "address", address)
+		logger.Debugw(
+			"trying WebRTC",
+			"signaling_server", dOpts.webrtcOpts.SignalingServerAddress,
+			"host", originalAddress,
+		)
 	}
-	conn, cached, err := dialDirectGRPC(ctx, address, dOpts, logger)
+
+	conn, cached, err := dialFunc(
+		ctx,
+		"webrtc",
+		fmt.Sprintf("%s->%s", dOpts.webrtcOpts.SignalingServerAddress, originalAddress),
+		buildKeyExtra(dOpts),
+		func() (ClientConn, error) {
+			if dOpts.webrtcOpts.PeerConnections > 1 {
+				return dialWebRTCPool(
+					ctx,
+					dOpts.webrtcOpts.SignalingServerAddress,
+					originalAddress,
+					dOpts,
+					logger,
+				)
+			}
+			return dialWebRTC(
+				ctx,
+				dOpts.webrtcOpts.SignalingServerAddress,
+				originalAddress,
+				dOpts,
+				logger,
+			)
+		})
 	if err != nil {
 		return nil, false, err
 	}
+	if !cached {
+		logger.Debug("connected via WebRTC")
+	} else if dOpts.debug {
+		logger.Debug("connected via WebRTC (cached)")
+	}
+	return conn, cached, nil
+}
+
+// dialDirectGRPCFromOptions attempts a direct gRPC connection to address.
+func dialDirectGRPCFromOptions(
+	ctx context.Context,
+	address string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+) (ClientConn, bool, error) {
+	if dOpts.debug {
+		logger.Debugw("trying direct", "address", address)
+	}
+	connectCtx, connectErr, cancel := withDialStageTimeout(ctx, DialStageDirectGRPCConnect, dOpts.timeouts.DirectGRPCConnect)
+	defer cancel()
+	conn, cached, err := dialDirectGRPC(connectCtx, address, dOpts, logger)
+	if err != nil {
+		return nil, false, connectErr(err)
+	}
 	if !cached {
 		logger.Debugw("connected via gRPC", "address", address)
 	} else if dOpts.debug {
@@ -203,53 +261,38 @@ func dialMulticastDNS(
 	logger golog.Logger,
 	dOpts *dialOptions,
 ) (ClientConn, bool, error) {
-	candidates := []string{address, strings.ReplaceAll(address, ".", "-")}
-	candidateLookup := func(ctx context.Context, candidates []string) (*zeroconf.ServiceEntry, error) {
-		resolver, err := zeroconf.NewResolver(logger, zeroconf.SelectIPRecordType(zeroconf.IPv4))
-		if err != nil {
-			return nil, err
-		}
-		defer resolver.Shutdown()
-		for _, candidate := range candidates {
-			entries := make(chan *zeroconf.ServiceEntry)
-			lookupCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
-			defer cancel()
-			if err := resolver.Lookup(lookupCtx, candidate, "_rpc._tcp", "local.", entries); err != nil {
-				logger.Errorw("error performing mDNS query", "error", err)
-				return nil, err
-			}
-			// entries gets closed after lookupCtx expires or there is a real entry
-			entry := <-entries
-			if entry != nil {
-				return entry, nil
-			}
-		}
-		return nil, ctx.Err()
+	discoverer := dOpts.discoverer
+	lookupTimeout := dOpts.timeouts.MulticastDNSLookup
+	if discoverer == nil {
+		// mDNS bounds its own lookups since not hearing back means nothing was found
+		discoverer = &mdnsDiscoverer{logger: logger, lookupTimeout: lookupTimeout}
+		lookupTimeout = 0
 	}
+	var rec *ServiceRecord
 	// lookup for candidates for backward compatibility
-	entry, err := candidateLookup(ctx, candidates)
-	if err != nil || entry == nil {
-		return nil, false, err
-	}
-	var hasGRPC, hasWebRTC bool
-	for _, field := range entry.Text {
-		// mdns service may advertise TXT field following https://datatracker.ietf.org/doc/html/rfc1464 (ex grpc=)
-		if strings.Contains(field, "grpc") {
-			hasGRPC = true
+	for _, candidate := range []string{address, strings.ReplaceAll(address, ".", "-")} {
+		var err error
+		lookupCtx, lookupErr, cancel := withDialStageTimeout(ctx, DialStageMulticastDNSLookup, lookupTimeout)
+		rec, err = discoverer.Discover(lookupCtx, candidate)
+		cancel()
+		if err != nil {
+			return nil, false, lookupErr(err)
 		}
-		if strings.Contains(field, "webrtc") {
-			hasWebRTC = true
+		if rec != nil {
+			break
 		}
 	}
-
-	// IPv6 with scope does not work with grpc-go which we would want here.
-	if !(hasGRPC || hasWebRTC) || len(entry.AddrIPv4) == 0 {
+	if rec == nil {
+		return nil, false, ctx.Err()
+	}
+	hasGRPC, hasWebRTC := rec.hasService("grpc"), rec.hasService("webrtc")
+	if !(hasGRPC || hasWebRTC) || rec.Host == "" {
 		return nil, false, nil
 	}
 
-	localAddress := fmt.Sprintf("%s:%d", entry.AddrIPv4[0], entry.Port)
+	localAddress := net.JoinHostPort(rec.Host, strconv.Itoa(rec.Port))
 	if dOpts.debug {
-		logger.Debugw("found address via mDNS", "address", localAddress)
+		logger.Debugw("found address via discovery", "address", localAddress)
 	}
 
 	dOptsCopy := *dOpts
@@ -268,7 +311,7 @@ func dialMulticastDNS(
 	}
 
 	if hasWebRTC {
-		fixupWebRTCOptions(&dOptsCopy, entry.AddrIPv4[0].String(), uint16(entry.Port))
+		fixupWebRTCOptions(&dOptsCopy, rec.Host, uint16(rec.Port))
 		if dOptsCopy.mdnsOptions.RemoveAuthCredentials {
 			dOptsCopy.webrtcOpts.SignalingAuthEntity = ""
 			dOptsCopy.webrtcOpts.SignalingCreds = Credentials{}
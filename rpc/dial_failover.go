@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// srvAddressPrefix marks an address as a DNS SRV target whose records name the endpoints
+// to dial.
+const srvAddressPrefix = "dns+srv://"
+
+// lookupSRV is a variable so that tests can avoid real DNS.
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// resolveDialEndpoints returns the endpoints an address refers to. Most addresses refer
+// only to themselves, but a dns+srv:// address refers to the targets of its SRV records, in
+// the order they should be tried.
+func resolveDialEndpoints(ctx context.Context, address string) ([]string, error) {
+	if !strings.HasPrefix(address, srvAddressPrefix) {
+		return []string{address}, nil
+	}
+	name := strings.TrimPrefix(address, srvAddressPrefix)
+	if name == "" {
+		return nil, errors.Errorf("expected a name after %q", srvAddressPrefix)
+	}
+	_, records, err := lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error looking up SRV records for %q", name)
+	}
+
+	// records come back sorted by priority and randomized by weight within a priority
+	endpoints := make([]string, 0, len(records))
+	for _, record := range records {
+		// a target of "." means the service is decidedly not available at this domain
+		if record.Target == "." {
+			continue
+		}
+		endpoints = append(endpoints, net.JoinHostPort(
+			strings.TrimSuffix(record.Target, "."),
+			strconv.Itoa(int(record.Port)),
+		))
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.Errorf("no usable SRV records found for %q", name)
+	}
+	return endpoints, nil
+}
+
+// A failoverEndpoint is a network address to dial along with the address it was resolved
+// from, which is what the server is known as (e.g. for WebRTC signaling and auth).
+type failoverEndpoint struct {
+	address         string
+	originalAddress string
+}
+
+// dialFailover dials every endpoint that the address and any fallback addresses refer to,
+// one at a time, until one of them connects. If ctx has a deadline, the time left is split
+// evenly between the endpoints that have yet to be tried so that one that hangs does not
+// prevent the others from being tried.
+func dialFailover(
+	ctx context.Context,
+	address string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+) (ClientConn, error) {
+	var errs error
+	var endpoints []failoverEndpoint
+	for _, addr := range append([]string{address}, dOpts.fallbackAddresses...) {
+		resolved, err := resolveDialEndpoints(ctx, addr)
+		if err != nil {
+			logger.Warnw("error resolving address; skipping", "address", addr, "error", err)
+			errs = multierr.Combine(errs, err)
+			continue
+		}
+		originalAddress := strings.TrimPrefix(addr, srvAddressPrefix)
+		for _, endpoint := range resolved {
+			endpoints = append(endpoints, failoverEndpoint{address: endpoint, originalAddress: originalAddress})
+		}
+	}
+
+	for i, endpoint := range endpoints {
+		if ctx.Err() != nil {
+			return nil, multierr.Combine(errs, ctx.Err())
+		}
+		if dOpts.debug {
+			logger.Debugw("trying endpoint", "address", endpoint.address, "original_address", endpoint.originalAddress)
+		}
+
+		// every endpoint starts from the same options since dialing modifies them
+		dOptsCopy := *dOpts
+		dOptsCopy.fallbackAddresses = nil
+		attemptCtx, cancel := failoverAttemptContext(ctx, len(endpoints)-i)
+		conn, err := dialAddress(attemptCtx, endpoint.address, endpoint.originalAddress, logger, &dOptsCopy)
+		cancel()
+		if err == nil {
+			if i != 0 {
+				logger.Debugw("connected after failing over", "address", endpoint.address)
+			}
+			return conn, nil
+		}
+		logger.Debugw("error dialing endpoint; failing over", "address", endpoint.address, "error", err)
+		errs = multierr.Combine(errs, errors.Wrapf(err, "error dialing %q", endpoint.address))
+	}
+	return nil, errs
+}
+
+// failoverAttemptContext returns a context for one of the remaining endpoints to be tried.
+func failoverAttemptContext(ctx context.Context, remaining int) (context.Context, func()) {
+	deadline, ok := ctx.Deadline()
+	if !ok || remaining <= 1 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(remaining))
+}
@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+)
+
+func TestResolveDialEndpoints(t *testing.T) {
+	prevLookupSRV := lookupSRV
+	defer func() {
+		lookupSRV = prevLookupSRV
+	}()
+
+	var lookedUp string
+	lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		lookedUp = name
+		switch name {
+		case "_rpc._tcp.example.com":
+			return name, []*net.SRV{
+				{Target: "one.example.com.", Port: 8080, Priority: 1},
+				{Target: ".", Port: 0, Priority: 2},
+				{Target: "two.example.com.", Port: 443, Priority: 3},
+			}, nil
+		case "_rpc._tcp.nowhere.com":
+			return name, []*net.SRV{{Target: "."}}, nil
+		default:
+			return "", nil, errors.New("no such host")
+		}
+	}
+
+	endpoints, err := resolveDialEndpoints(context.Background(), "example.com:8080")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, endpoints, test.ShouldResemble, []string{"example.com:8080"})
+	test.That(t, lookedUp, test.ShouldBeEmpty)
+
+	endpoints, err = resolveDialEndpoints(context.Background(), "dns+srv://_rpc._tcp.example.com")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, lookedUp, test.ShouldEqual, "_rpc._tcp.example.com")
+	test.That(t, endpoints, test.ShouldResemble, []string{"one.example.com:8080", "two.example.com:443"})
+
+	_, err = resolveDialEndpoints(context.Background(), "dns+srv://_rpc._tcp.nowhere.com")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no usable SRV records")
+
+	_, err = resolveDialEndpoints(context.Background(), "dns+srv://_rpc._tcp.unknown.com")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no such host")
+
+	_, err = resolveDialEndpoints(context.Background(), "dns+srv://")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDialFailover(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{Enable: false}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	// nothing is listening here
+	deadListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	deadAddress := deadListener.Addr().String()
+	test.That(t, deadListener.Close(), test.ShouldBeNil)
+
+	checkConn := func(conn ClientConn) {
+		t.Helper()
+		client := pb.NewEchoServiceClient(conn)
+		resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldEqual, "hello")
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	}
+
+	t.Run("fallback addresses", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		conn, err := Dial(ctx, deadAddress, logger,
+			WithInsecure(),
+			WithForceDirectGRPC(),
+			WithFallbackAddresses(httpListener.Addr().String()),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, TransportInfoFromClientConn(conn).RemoteAddress, test.ShouldEqual, httpListener.Addr().String())
+		checkConn(conn)
+	})
+
+	t.Run("dns srv", func(t *testing.T) {
+		prevLookupSRV := lookupSRV
+		defer func() {
+			lookupSRV = prevLookupSRV
+		}()
+		_, deadPortStr, err := net.SplitHostPort(deadAddress)
+		test.That(t, err, test.ShouldBeNil)
+		deadPort, err := strconv.Atoi(deadPortStr)
+		test.That(t, err, test.ShouldBeNil)
+		_, livePortStr, err := net.SplitHostPort(httpListener.Addr().String())
+		test.That(t, err, test.ShouldBeNil)
+		livePort, err := strconv.Atoi(livePortStr)
+		test.That(t, err, test.ShouldBeNil)
+		lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			return name, []*net.SRV{
+				{Target: "localhost.", Port: uint16(deadPort), Priority: 1},
+				{Target: "localhost.", Port: uint16(livePort), Priority: 2},
+			}, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		conn, err := Dial(ctx, "dns+srv://_rpc._tcp.example.com", logger,
+			WithInsecure(),
+			WithForceDirectGRPC(),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		checkConn(conn)
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := Dial(ctx, deadAddress, logger,
+			WithInsecure(),
+			WithForceDirectGRPC(),
+			WithFallbackAddresses(deadAddress),
+		)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, deadAddress)
+	})
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
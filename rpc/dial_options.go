@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"crypto/tls"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
@@ -64,6 +65,47 @@ type dialOptions struct {
 	// interceptors
 	unaryInterceptor  grpc.UnaryClientInterceptor
 	streamInterceptor grpc.StreamClientInterceptor
+
+	// reconnectOpts, if set, makes the returned connection reestablish itself on failure.
+	reconnectOpts *ReconnectOptions
+
+	// raceTransports makes dialing try transports concurrently, starting each one
+	// raceStagger after the previous.
+	raceTransports bool
+	raceStagger    time.Duration
+
+	// fallbackAddresses are dialed in order if the primary address cannot be.
+	fallbackAddresses []string
+
+	// discoverer finds addresses for names; mDNS is used if unset.
+	discoverer Discoverer
+
+	// retryPolicy, if set, retries failed unary calls.
+	retryPolicy *RetryPolicy
+
+	// timeouts bound each stage of a dial.
+	timeouts DialTimeouts
+
+	// compressor, if set, is the encoding calls are compressed with by default.
+	compressor string
+
+	// maxRecvMsgSize and maxSendMsgSize, if set, are the largest messages calls may receive
+	// and send by default.
+	maxRecvMsgSize int
+	maxSendMsgSize int
+}
+
+// clientUnaryInterceptor returns the interceptor unary calls should go through, if any.
+// Retries happen closest to the transport so that other interceptors see a call once.
+func (o *dialOptions) clientUnaryInterceptor() grpc.UnaryClientInterceptor {
+	if o.retryPolicy == nil {
+		return o.unaryInterceptor
+	}
+	retryInterceptor := UnaryClientRetryInterceptor(*o.retryPolicy)
+	if o.unaryInterceptor == nil {
+		return retryInterceptor
+	}
+	return grpc_middleware.ChainUnaryClient(o.unaryInterceptor, retryInterceptor)
 }
 
 // DialMulticastDNSOptions dictate any special settings to apply while dialing via mDNS.
@@ -280,3 +322,95 @@ func WithForceDirectGRPC() DialOption {
 		o.disableDirect = false
 	})
 }
+
+// WithReconnect returns a DialOption which makes the returned ClientConn heal itself
+// when its underlying transport fails. On failure, the full dial cascade (mDNS, WebRTC,
+// direct gRPC) is run again with the same options, including authentication, backing off
+// between attempts. Calls made while reconnecting wait for a new connection until their
+// context is done. Calls that were in flight when the failure happened are not retried.
+func WithReconnect(opts ReconnectOptions) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.reconnectOpts = &opts
+	})
+}
+
+// WithTransportRacing returns a DialOption which makes dialing race the available
+// transports (mDNS, WebRTC, direct gRPC) against each other instead of trying them one
+// after another. Transports are started in that order, each one after the previous has
+// either failed or been given stagger to connect; a stagger of zero uses a default of
+// 250ms. The first transport to connect is used and the rest are torn down.
+//
+// Note that this changes which errors fail a dial. Without racing, direct gRPC is only tried
+// if WebRTC finds no signaler, so any other WebRTC error is returned. With racing, such an
+// error is only logged if direct gRPC connects, and is returned only if every transport fails.
+func WithTransportRacing(stagger time.Duration) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.raceTransports = true
+		o.raceStagger = stagger
+	})
+}
+
+// WithFallbackAddresses returns a DialOption which makes dialing fail over to the given
+// addresses, in order, if the address being dialed cannot be connected to. Each address
+// is dialed as if it had been passed to Dial itself, so it may be a dns+srv:// target too.
+func WithFallbackAddresses(addresses ...string) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.fallbackAddresses = append(o.fallbackAddresses, addresses...)
+	})
+}
+
+// WithDiscoverer returns a DialOption which sets how addresses that are just names are
+// looked up, in place of mDNS. Discovery can still be turned off with
+// WithDialMulticastDNSOptions.
+func WithDiscoverer(discoverer Discoverer) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.discoverer = discoverer
+	})
+}
+
+// WithRetryPolicy returns a DialOption which retries failed unary calls according to the
+// given policy. Retries behave the same whether the connection is over WebRTC or direct
+// gRPC and happen after any interceptors added with WithUnaryClientInterceptor. Calls made to
+// a signaling server while connecting over WebRTC are never retried.
+func WithRetryPolicy(policy RetryPolicy) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.retryPolicy = &policy
+	})
+}
+
+// WithDialTimeouts returns a DialOption which bounds how long each stage of a dial may
+// take. A stage that takes too long fails with a DialTimeoutError naming it.
+func WithDialTimeouts(timeouts DialTimeouts) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.timeouts = timeouts
+	})
+}
+
+// WithCompressor returns a DialOption which compresses every call with the compressor
+// registered under the given name (see encoding.RegisterCompressor), as if each had been
+// made with grpc.UseCompressor. This works the same over WebRTC and direct gRPC. gzip is
+// always available.
+func WithCompressor(name string) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.compressor = name
+	})
+}
+
+// WithDialMaxRecvMsgSize returns a DialOption which sets the largest message in bytes calls
+// may receive, unless they ask otherwise with grpc.MaxCallRecvMsgSize. Over WebRTC, servers
+// are told about it so that they fail calls with larger responses instead of sending them.
+// The default is MaxMessageSize.
+func WithDialMaxRecvMsgSize(size int) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.maxRecvMsgSize = size
+	})
+}
+
+// WithDialMaxSendMsgSize returns a DialOption which sets the largest message in bytes calls
+// may send, unless they ask otherwise with grpc.MaxCallSendMsgSize. By default, only the
+// server limits what may be sent.
+func WithDialMaxSendMsgSize(size int) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.maxSendMsgSize = size
+	})
+}
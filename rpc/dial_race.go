@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+
+	"go.viam.com/utils"
+)
+
+// defaultTransportRaceStagger is how long a racing dial waits for one transport before
+// also starting the next one.
+const defaultTransportRaceStagger = 250 * time.Millisecond
+
+// A raceCandidate is one way of reaching an address that takes part in a racing dial.
+type raceCandidate struct {
+	name string
+	dial func(ctx context.Context, dOpts *dialOptions) (ClientConn, bool, error)
+}
+
+type raceResult struct {
+	index  int
+	conn   ClientConn
+	cached bool
+	err    error
+}
+
+// dialRace tries mDNS, WebRTC, and direct gRPC concurrently, in the same order of
+// preference as dial does sequentially. Each transport is started once the one before it
+// has failed or the stagger delay has passed, whichever happens first. The first transport
+// to connect wins and all others are canceled; any that connect anyway are closed so that
+// their peer connections and cached dialer references are released.
+//
+// Unlike dial, a transport that fails does not stop the ones after it, so a WebRTC error
+// other than ErrNoWebRTCSignaler does not fail the dial if direct gRPC connects. Such errors
+// are logged instead so that they are not lost.
+func dialRace(
+	ctx context.Context,
+	address string,
+	originalAddress string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+	tryMDNS bool,
+) (ClientConn, bool, error) {
+	var candidates []raceCandidate
+	if tryMDNS {
+		candidates = append(candidates, raceCandidate{"mdns", func(ctx context.Context, dOpts *dialOptions) (ClientConn, bool, error) {
+			conn, cached, err := dialMulticastDNS(ctx, address, logger, dOpts)
+			if err != nil {
+				logger.Warnw("error dialing with mDNS; falling back to other methods", "error", err)
+			}
+			if conn == nil {
+				return nil, false, errNotApplicable
+			}
+			return conn, cached, nil
+		}})
+	}
+	if !dOpts.webrtcOpts.Disable {
+		candidates = append(candidates, raceCandidate{"webrtc", func(ctx context.Context, dOpts *dialOptions) (ClientConn, bool, error) {
+			return dialWebRTCFromOptions(ctx, address, originalAddress, logger, dOpts)
+		}})
+	}
+	if !dOpts.disableDirect {
+		candidates = append(candidates, raceCandidate{"grpc", func(ctx context.Context, dOpts *dialOptions) (ClientConn, bool, error) {
+			return dialDirectGRPCFromOptions(ctx, address, logger, dOpts)
+		}})
+	}
+	if len(candidates) == 0 {
+		return nil, false, ErrConnectionOptionsExhausted
+	}
+
+	stagger := dOpts.raceStagger
+	if stagger <= 0 {
+		stagger = defaultTransportRaceStagger
+	}
+
+	// buffered so that attempts that finish after a winner is picked never block
+	results := make(chan raceResult, len(candidates))
+	cancels := make([]context.CancelFunc, len(candidates))
+	start := func(idx int) {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels[idx] = cancel
+		// each transport fixes up its own copy of the options
+		dOptsCopy := *dOpts
+		candidate := candidates[idx]
+		if dOpts.debug {
+			logger.Debugw("racing transport", "transport", candidate.name, "address", address)
+		}
+		utils.PanicCapturingGo(func() {
+			conn, cached, err := candidate.dial(attemptCtx, &dOptsCopy)
+			results <- raceResult{idx, conn, cached, err}
+		})
+	}
+	defer func() {
+		for _, cancel := range cancels {
+			if cancel != nil {
+				cancel()
+			}
+		}
+	}()
+
+	errs := make([]error, len(candidates))
+	started, finished := 1, 0
+	// closeLosers cancels all attempts but the winner; anything they still produce gets
+	// closed as it comes in.
+	closeLosers := func(winner int) {
+		for i, cancel := range cancels {
+			if i != winner && cancel != nil {
+				cancel()
+			}
+		}
+		remaining := started - finished
+		utils.PanicCapturingGo(func() {
+			for i := 0; i < remaining; i++ {
+				loser := <-results
+				if loser.conn == nil {
+					continue
+				}
+				if err := loser.conn.Close(); err != nil {
+					logger.Debugw("error closing losing connection",
+						"transport", candidates[loser.index].name, "error", err)
+				}
+			}
+		})
+	}
+	start(0)
+	timer := time.NewTimer(stagger)
+	defer timer.Stop()
+	startNext := func() {
+		if started < len(candidates) {
+			start(started)
+			started++
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(stagger)
+	}
+	for finished < len(candidates) {
+		select {
+		case <-timer.C:
+			startNext()
+		case res := <-results:
+			finished++
+			if res.err == nil {
+				if dOpts.debug {
+					logger.Debugw("transport won race", "transport", candidates[res.index].name)
+				}
+				for idx, err := range errs[:res.index] {
+					if err == nil || !isMeaningfulRaceErr(err) {
+						continue
+					}
+					logger.Warnw("more preferred transport failed; using a less preferred one",
+						"failed_transport", candidates[idx].name,
+						"error", err,
+						"transport", candidates[res.index].name,
+					)
+				}
+				closeLosers(res.index)
+				return res.conn, res.cached, nil
+			}
+			errs[res.index] = res.err
+			if dOpts.debug {
+				logger.Debugw("transport lost race", "transport", candidates[res.index].name, "error", res.err)
+			}
+			if ctx.Err() != nil {
+				closeLosers(-1)
+				return nil, false, ctx.Err()
+			}
+			// no reason to keep waiting on a transport that already failed
+			if started == finished {
+				startNext()
+			}
+		}
+	}
+
+	// report the most preferred transport that had something meaningful to say
+	for _, err := range errs {
+		if err == nil || !isMeaningfulRaceErr(err) {
+			continue
+		}
+		return nil, false, err
+	}
+	return nil, false, ErrConnectionOptionsExhausted
+}
+
+// isMeaningfulRaceErr returns whether the error a transport failed with says more than that
+// the transport cannot be used, i.e. whether dial would have stopped at it.
+func isMeaningfulRaceErr(err error) bool {
+	return !errors.Is(err, errNotApplicable) && !errors.Is(err, ErrNoWebRTCSignaler)
+}
+
+// errNotApplicable is used by a racing dial when a transport cannot be used at all.
+var errNotApplicable = errors.New("transport not applicable")
@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+)
+
+func TestDialWithTransportRacing(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{Enable: false}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	// a signaling server that accepts connections but never says anything
+	blackhole, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	blackholeDone := make(chan struct{})
+	go func() {
+		defer close(blackholeDone)
+		var conns []net.Conn
+		for {
+			conn, err := blackhole.Accept()
+			if err != nil {
+				break
+			}
+			conns = append(conns, conn)
+		}
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	// WebRTC would hang until the deadline but direct gRPC gets started after the stagger
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	conn, err := Dial(ctx, httpListener.Addr().String(), logger,
+		WithInsecure(),
+		WithDialMulticastDNSOptions(DialMulticastDNSOptions{Disable: true}),
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingServerAddress: blackhole.Addr().String(),
+			SignalingInsecure:      true,
+		}),
+		WithTransportRacing(10*time.Millisecond),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeLessThan, 4*time.Second)
+	test.That(t, TransportInfoFromClientConn(conn).Type, test.ShouldEqual, TransportTypeGRPC)
+
+	client := pb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, blackhole.Close(), test.ShouldBeNil)
+	<-blackholeDone
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestDialWithTransportRacingAllFail(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	// nothing is listening here
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	address := listener.Addr().String()
+	test.That(t, listener.Close(), test.ShouldBeNil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = Dial(ctx, address, logger,
+		WithInsecure(),
+		WithDialMulticastDNSOptions(DialMulticastDNSOptions{Disable: true}),
+		WithWebRTCOptions(DialWebRTCOptions{Disable: true}),
+		WithDisableDirectGRPC(),
+		WithTransportRacing(0),
+	)
+	test.That(t, err, test.ShouldEqual, ErrConnectionOptionsExhausted)
+
+	_, err = Dial(ctx, address, logger,
+		WithInsecure(),
+		WithDialMulticastDNSOptions(DialMulticastDNSOptions{Disable: true}),
+		WithWebRTCOptions(DialWebRTCOptions{Disable: true}),
+		WithTransportRacing(0),
+	)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err, test.ShouldNotEqual, ErrConnectionOptionsExhausted)
+}
@@ -0,0 +1,486 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/utils"
+)
+
+// Defaults used when the corresponding ReconnectOptions field is not set.
+const (
+	defaultReconnectMinBackoff     = 100 * time.Millisecond
+	defaultReconnectMaxBackoff     = 10 * time.Second
+	defaultReconnectAttemptTimeout = 20 * time.Second
+)
+
+// ReconnectOptions control how a ClientConn dialed with WithReconnect reestablishes
+// its underlying transport after it fails.
+type ReconnectOptions struct {
+	// MinBackoff is how long to wait before the first reconnection attempt. The wait
+	// doubles (with jitter) after each failed attempt. Defaults to 100ms.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the wait between reconnection attempts. Defaults to 10s.
+	MaxBackoff time.Duration
+
+	// MaxAttempts is the number of consecutive failed reconnection attempts after which
+	// the connection gives up and shuts down. Zero means to try forever.
+	MaxAttempts int
+
+	// AttemptTimeout bounds how long a single pass through the dial cascade may take.
+	// Defaults to 20s.
+	AttemptTimeout time.Duration
+
+	// OnStateChange, if set, is called with every connectivity state transition,
+	// starting with Ready once the initial dial succeeds. If the initial dial fails,
+	// Dial returns its error and OnStateChange is never called. Calls are delivered
+	// in order from a single goroutine; the function should not block and must not
+	// call Close on the connection.
+	OnStateChange func(state connectivity.State)
+}
+
+// errReconnectingConnClosed is returned for calls made after a reconnecting connection
+// has been closed.
+var errReconnectingConnClosed = status.Error(codes.Canceled, "connection closed")
+
+// A reconnectingClientConn is a ClientConn that redials its address with the original
+// dial options whenever the connection it currently holds fails. Calls made while no
+// connection is available wait for one until their context is done.
+type reconnectingClientConn struct {
+	address string
+	logger  golog.Logger
+	opts    ReconnectOptions
+	dial    func(ctx context.Context) (ClientConn, error)
+
+	mu           sync.Mutex
+	conn         ClientConn
+	state        connectivity.State
+	stateChanged chan struct{}
+	lastErr      error
+	// trackHandler is set on every connection made.
+	trackHandler TrackHandler
+
+	// state transitions queued for OnStateChange, in the order they happened.
+	pendingStates     []connectivity.State
+	pendingStatesCh   chan struct{}
+	stateNotifierDone chan struct{}
+
+	closeCtx                context.Context
+	closeCancel             func()
+	activeBackgroundWorkers sync.WaitGroup
+}
+
+// dialReconnecting performs the initial dial for a connection that will reconnect itself
+// for its lifetime. Each reconnection attempt starts from a fresh copy of the given options
+// so that state recorded by the cascade (e.g. a detected signaling address) does not leak
+// between attempts; this also means that authentication is performed anew each time.
+func dialReconnecting(
+	ctx context.Context,
+	address string,
+	logger golog.Logger,
+	dOpts *dialOptions,
+) (ClientConn, error) {
+	opts := *dOpts.reconnectOpts
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultReconnectMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultReconnectMaxBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+	if opts.AttemptTimeout <= 0 {
+		opts.AttemptTimeout = defaultReconnectAttemptTimeout
+	}
+
+	pristine := *dOpts
+	pristine.reconnectOpts = nil
+	dialOnce := func(ctx context.Context) (ClientConn, error) {
+		dOptsCopy := pristine
+		return dialInner(ctx, address, logger, &dOptsCopy)
+	}
+
+	conn, err := dialOnce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rc := newReconnectingClientConn(address, logger, opts, dialOnce)
+	rc.setConn(conn)
+	return rc, nil
+}
+
+func newReconnectingClientConn(
+	address string,
+	logger golog.Logger,
+	opts ReconnectOptions,
+	dial func(ctx context.Context) (ClientConn, error),
+) *reconnectingClientConn {
+	// Reconnection attempts outlive the context of the original dial, so they
+	// are bound only to the lifetime of the connection itself. Note that this
+	// also means a Dialer attached to the original context is not used when
+	// reconnecting; it may very well be caching the connection that just failed.
+	closeCtx, closeCancel := context.WithCancel(context.Background())
+	rc := &reconnectingClientConn{
+		address:      address,
+		logger:       logger,
+		opts:         opts,
+		dial:         dial,
+		state:        connectivity.Connecting,
+		stateChanged: make(chan struct{}),
+		closeCtx:     closeCtx,
+		closeCancel:  closeCancel,
+	}
+	if opts.OnStateChange != nil {
+		rc.pendingStatesCh = make(chan struct{}, 1)
+		rc.stateNotifierDone = make(chan struct{})
+		utils.PanicCapturingGo(rc.notifyStateChanges)
+	}
+	return rc
+}
+
+// notifyStateChanges delivers queued state transitions to OnStateChange in order until
+// the terminal Shutdown state has been delivered.
+func (rc *reconnectingClientConn) notifyStateChanges() {
+	defer close(rc.stateNotifierDone)
+	for range rc.pendingStatesCh {
+		rc.mu.Lock()
+		states := rc.pendingStates
+		rc.pendingStates = nil
+		rc.mu.Unlock()
+		for _, state := range states {
+			rc.opts.OnStateChange(state)
+			if state == connectivity.Shutdown {
+				return
+			}
+		}
+	}
+}
+
+func (rc *reconnectingClientConn) setState(state connectivity.State) {
+	rc.mu.Lock()
+	rc.setStateLocked(state)
+	rc.mu.Unlock()
+}
+
+// setStateLocked transitions to the given state, waking up anyone waiting on a change and
+// queueing a notification. Nothing moves out of Shutdown.
+func (rc *reconnectingClientConn) setStateLocked(state connectivity.State) {
+	if rc.state == state || rc.state == connectivity.Shutdown {
+		return
+	}
+	rc.state = state
+	close(rc.stateChanged)
+	rc.stateChanged = make(chan struct{})
+	if rc.pendingStatesCh == nil {
+		return
+	}
+	rc.pendingStates = append(rc.pendingStates, state)
+	select {
+	case rc.pendingStatesCh <- struct{}{}:
+	default:
+	}
+}
+
+// setConn installs a freshly dialed connection and starts watching it for failure.
+func (rc *reconnectingClientConn) setConn(conn ClientConn) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.state == connectivity.Shutdown {
+		utils.UncheckedError(conn.Close())
+		return
+	}
+	rc.conn = conn
+	rc.lastErr = nil
+	if rc.trackHandler != nil {
+		clientConnOnTrack(conn, rc.trackHandler)
+	}
+	rc.setStateLocked(connectivity.Ready)
+	rc.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer rc.activeBackgroundWorkers.Done()
+		rc.watchConn(conn)
+	})
+}
+
+// watchConn waits for the transport underneath conn to fail and then starts reconnecting.
+// It returns once conn is no longer usable or the reconnecting connection is closed.
+func (rc *reconnectingClientConn) watchConn(conn ClientConn) {
+	switch c := unwrapClientConn(conn).(type) {
+	case *webrtcClientChannel:
+		select {
+		case <-rc.closeCtx.Done():
+			return
+		case <-c.Ready():
+		}
+		select {
+		case <-rc.closeCtx.Done():
+		case <-c.ctx.Done():
+			_, reason := c.Closed()
+			if reason == nil {
+				reason = errDataChannelClosed
+			}
+			rc.onTransportFailure(conn, reason)
+		}
+	case *webrtcClientPool:
+		select {
+		case <-rc.closeCtx.Done():
+		case <-c.done:
+			rc.onTransportFailure(conn, errDataChannelClosed)
+		}
+	case *grpc.ClientConn:
+		state := c.GetState()
+		for {
+			switch state {
+			case connectivity.Idle:
+				// gRPC idles a connection whose transport went away until something
+				// uses it; find out now whether it can actually be reestablished.
+				c.Connect()
+			case connectivity.TransientFailure, connectivity.Shutdown:
+				rc.onTransportFailure(conn, errors.Errorf("gRPC connection is %s", state))
+				return
+			case connectivity.Connecting, connectivity.Ready:
+			}
+			if !c.WaitForStateChange(rc.closeCtx, state) {
+				return
+			}
+			state = c.GetState()
+		}
+	}
+}
+
+// onTransportFailure discards the given connection, if it is still the current one, and
+// starts reconnecting in the background.
+func (rc *reconnectingClientConn) onTransportFailure(conn ClientConn, err error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.conn != conn || rc.state == connectivity.Shutdown {
+		return
+	}
+	rc.conn = nil
+	rc.lastErr = err
+	rc.setStateLocked(connectivity.TransientFailure)
+	rc.logger.Debugw("connection failed; reconnecting", "address", rc.address, "error", err)
+
+	rc.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer rc.activeBackgroundWorkers.Done()
+		if err := conn.Close(); err != nil && status.Convert(err).Code() != codes.Canceled {
+			rc.logger.Debugw("error closing failed connection", "error", err)
+		}
+		rc.reconnect()
+	})
+}
+
+// reconnect runs the dial cascade with exponential backoff until it succeeds, the
+// connection is closed, or the maximum number of attempts is reached.
+func (rc *reconnectingClientConn) reconnect() {
+	backoff := rc.opts.MinBackoff
+	for attempt := 1; ; attempt++ {
+		if rc.opts.MaxAttempts > 0 && attempt > rc.opts.MaxAttempts {
+			rc.mu.Lock()
+			lastErr := rc.lastErr
+			rc.mu.Unlock()
+			rc.logger.Warnw("giving up on reconnecting", "address", rc.address, "attempts", rc.opts.MaxAttempts, "error", lastErr)
+			rc.setState(connectivity.Shutdown)
+			return
+		}
+
+		// jitter within the upper half of the backoff so that a fleet of clients that
+		// lost the same network does not reconnect in lockstep.
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)) //nolint:gosec
+		if !utils.SelectContextOrWait(rc.closeCtx, wait) {
+			return
+		}
+		if backoff *= 2; backoff > rc.opts.MaxBackoff {
+			backoff = rc.opts.MaxBackoff
+		}
+
+		rc.setState(connectivity.Connecting)
+		attemptCtx, cancel := context.WithTimeout(rc.closeCtx, rc.opts.AttemptTimeout)
+		conn, err := rc.dial(attemptCtx)
+		cancel()
+		if err == nil {
+			rc.logger.Debugw("reconnected", "address", rc.address, "attempt", attempt)
+			rc.setConn(conn)
+			return
+		}
+		if rc.closeCtx.Err() != nil {
+			return
+		}
+		rc.logger.Debugw("error reconnecting", "address", rc.address, "attempt", attempt, "error", err)
+		rc.mu.Lock()
+		rc.lastErr = err
+		rc.mu.Unlock()
+		rc.setState(connectivity.TransientFailure)
+	}
+}
+
+// currentConn returns the current connection, waiting for one to become available
+// until the given context is done.
+func (rc *reconnectingClientConn) currentConn(ctx context.Context) (ClientConn, error) {
+	for {
+		rc.mu.Lock()
+		conn, state, stateChanged, lastErr := rc.conn, rc.state, rc.stateChanged, rc.lastErr
+		rc.mu.Unlock()
+		if state == connectivity.Shutdown {
+			if lastErr != nil {
+				return nil, status.Errorf(codes.Unavailable, "connection lost and could not be reestablished: %v", lastErr)
+			}
+			return nil, errReconnectingConnClosed
+		}
+		if conn != nil {
+			return conn, nil
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, status.Errorf(codes.Unavailable, "%v; last connection error: %v", ctx.Err(), lastErr)
+			}
+			return nil, ctx.Err()
+		case <-stateChanged:
+		}
+	}
+}
+
+// checkCallErr starts reconnecting if the given call error indicates that the transport
+// underneath conn has failed.
+func (rc *reconnectingClientConn) checkCallErr(conn ClientConn, err error) {
+	if isTransportFailure(conn, err) {
+		rc.onTransportFailure(conn, err)
+	}
+}
+
+// Invoke invokes the RPC on the current connection, waiting for a reconnection first if
+// necessary.
+func (rc *reconnectingClientConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	conn, err := rc.currentConn(ctx)
+	if err != nil {
+		return err
+	}
+	err = conn.Invoke(ctx, method, args, reply, opts...)
+	rc.checkCallErr(conn, err)
+	return err
+}
+
+// NewStream creates a stream on the current connection, waiting for a reconnection first
+// if necessary.
+func (rc *reconnectingClientConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	conn, err := rc.currentConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		rc.checkCallErr(conn, err)
+		return nil, err
+	}
+	return &reconnectingClientStream{ClientStream: stream, conn: conn, rc: rc}, nil
+}
+
+// Close stops any reconnection in progress and closes the current connection.
+func (rc *reconnectingClientConn) Close() error {
+	rc.mu.Lock()
+	conn := rc.conn
+	rc.conn = nil
+	if rc.state != connectivity.Shutdown {
+		rc.lastErr = nil
+	}
+	rc.setStateLocked(connectivity.Shutdown)
+	rc.mu.Unlock()
+
+	rc.closeCancel()
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+	rc.activeBackgroundWorkers.Wait()
+	if rc.stateNotifierDone != nil {
+		<-rc.stateNotifierDone
+	}
+	return err
+}
+
+// A reconnectingClientStream reports transport failures seen on a stream back to its
+// reconnecting connection.
+type reconnectingClientStream struct {
+	grpc.ClientStream
+	conn ClientConn
+	rc   *reconnectingClientConn
+}
+
+func (s *reconnectingClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if !errors.Is(err, io.EOF) {
+		s.rc.checkCallErr(s.conn, err)
+	}
+	return err
+}
+
+func (s *reconnectingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if !errors.Is(err, io.EOF) {
+		s.rc.checkCallErr(s.conn, err)
+	}
+	return err
+}
+
+// isTransportFailure determines if a call error means that the connection it was made on
+// is no longer usable, as opposed to the call itself failing. Status codes alone are not
+// trusted since a healthy server may return any of them on purpose.
+func isTransportFailure(conn ClientConn, err error) bool {
+	if err == nil {
+		return false
+	}
+	if pool, ok := unwrapClientConn(conn).(*webrtcClientPool); ok {
+		// one peer connection closing leaves the others to carry on
+		return !pool.hasLiveMembers()
+	}
+	if errors.Is(err, io.ErrClosedPipe) || errors.Is(err, errDataChannelClosed) ||
+		errors.Is(err, errWebRTCKeepaliveTimeout) {
+		return true
+	}
+	if grpcConn, ok := unwrapClientConn(conn).(*grpc.ClientConn); ok {
+		state := grpcConn.GetState()
+		return state == connectivity.TransientFailure || state == connectivity.Shutdown
+	}
+	return false
+}
+
+// unwrapClientConn peels off the wrappers this package puts around connections and
+// returns the connection that actually owns the transport.
+func unwrapClientConn(conn ClientConn) ClientConn {
+	for {
+		switch c := conn.(type) {
+		case *reffedConn:
+			conn = c.ClientConn
+		case *clientConnWithCloseFunc:
+			conn = c.ClientConn
+		case clientConnRPCAuthenticator:
+			conn = c.ClientConn
+		default:
+			return conn
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+)
+
+// unavailableEchoServer is an echo server that can be told to return Unavailable
+// on purpose, as a healthy server might.
+type unavailableEchoServer struct {
+	echoserver.Server
+	mu          sync.Mutex
+	unavailable bool
+}
+
+func (srv *unavailableEchoServer) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	srv.mu.Lock()
+	unavailable := srv.unavailable
+	srv.mu.Unlock()
+	if unavailable {
+		return nil, status.Error(codes.Unavailable, "try again later")
+	}
+	return srv.Server.Echo(ctx, req)
+}
+
+func TestDialWithReconnect(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	echoServer := &unavailableEchoServer{}
+	startServer := func(address string) (Server, string, <-chan error) {
+		rpcServer, err := NewServer(logger, WithUnauthenticated())
+		test.That(t, err, test.ShouldBeNil)
+		err = rpcServer.RegisterServiceServer(
+			context.Background(),
+			&pb.EchoService_ServiceDesc,
+			echoServer,
+			pb.RegisterEchoServiceHandlerFromEndpoint,
+		)
+		test.That(t, err, test.ShouldBeNil)
+
+		httpListener, err := net.Listen("tcp", address)
+		test.That(t, err, test.ShouldBeNil)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- rpcServer.Serve(httpListener)
+		}()
+		return rpcServer, httpListener.Addr().String(), errChan
+	}
+
+	rpcServer, address, errChan := startServer("localhost:0")
+
+	states := make(chan connectivity.State, 100)
+	conn, err := Dial(
+		context.Background(),
+		address,
+		logger,
+		WithInsecure(),
+		WithWebRTCOptions(DialWebRTCOptions{Disable: true}),
+		WithReconnect(ReconnectOptions{
+			MinBackoff:     10 * time.Millisecond,
+			MaxBackoff:     50 * time.Millisecond,
+			AttemptTimeout: time.Second,
+			OnStateChange: func(state connectivity.State) {
+				states <- state
+			},
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, <-states, test.ShouldEqual, connectivity.Ready)
+
+	rc, ok := conn.(*reconnectingClientConn)
+	test.That(t, ok, test.ShouldBeTrue)
+	rc.mu.Lock()
+	firstConn := rc.conn
+	rc.mu.Unlock()
+
+	client := pb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+	// an Unavailable returned by a healthy server is not a transport failure
+	echoServer.mu.Lock()
+	echoServer.unavailable = true
+	echoServer.mu.Unlock()
+	_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+	echoServer.mu.Lock()
+	echoServer.unavailable = false
+	echoServer.mu.Unlock()
+	resp, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "still here"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "still here")
+	rc.mu.Lock()
+	test.That(t, rc.conn, test.ShouldEqual, firstConn)
+	rc.mu.Unlock()
+	test.That(t, states, test.ShouldBeEmpty)
+
+	// the drop is noticed without any call being made
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	test.That(t, <-errChan, test.ShouldBeNil)
+	test.That(t, <-states, test.ShouldEqual, connectivity.TransientFailure)
+
+	rpcServer, _, errChan = startServer(address)
+
+	// calls made while reconnecting wait for the new connection
+	resp, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "world"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "world")
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+
+	var last connectivity.State
+	for len(states) > 0 {
+		last = <-states
+	}
+	test.That(t, last, test.ShouldEqual, connectivity.Shutdown)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	test.That(t, <-errChan, test.ShouldBeNil)
+}
+
+type fakeReconnectConn struct {
+	mu        sync.Mutex
+	invokeErr error
+	closed    bool
+}
+
+func (c *fakeReconnectConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.invokeErr
+}
+
+func (c *fakeReconnectConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeReconnectConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func TestReconnectingClientConnGivesUp(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	first := &fakeReconnectConn{}
+	dialErr := errors.New("network unreachable")
+	var dialCount int
+	var dialMu sync.Mutex
+	states := make(chan connectivity.State, 100)
+	rc := newReconnectingClientConn("somewhere", logger, ReconnectOptions{
+		MinBackoff:     time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		MaxAttempts:    3,
+		AttemptTimeout: time.Second,
+		OnStateChange: func(state connectivity.State) {
+			states <- state
+		},
+	}, func(ctx context.Context) (ClientConn, error) {
+		dialMu.Lock()
+		defer dialMu.Unlock()
+		dialCount++
+		return nil, dialErr
+	})
+	rc.setConn(first)
+	test.That(t, <-states, test.ShouldEqual, connectivity.Ready)
+	test.That(t, rc.Invoke(context.Background(), "/a/b", nil, nil), test.ShouldBeNil)
+
+	// errors from the call itself do not affect the connection
+	for _, code := range []codes.Code{codes.InvalidArgument, codes.Unavailable, codes.Canceled} {
+		first.mu.Lock()
+		first.invokeErr = status.Error(code, "bad")
+		first.mu.Unlock()
+		test.That(t, status.Code(rc.Invoke(context.Background(), "/a/b", nil, nil)), test.ShouldEqual, code)
+	}
+	test.That(t, states, test.ShouldBeEmpty)
+	dialMu.Lock()
+	test.That(t, dialCount, test.ShouldEqual, 0)
+	dialMu.Unlock()
+
+	first.mu.Lock()
+	first.invokeErr = errDataChannelClosed
+	first.mu.Unlock()
+	test.That(t, rc.Invoke(context.Background(), "/a/b", nil, nil), test.ShouldEqual, errDataChannelClosed)
+
+	test.That(t, <-states, test.ShouldEqual, connectivity.TransientFailure)
+	for i := 0; i < 3; i++ {
+		test.That(t, <-states, test.ShouldEqual, connectivity.Connecting)
+		test.That(t, <-states, test.ShouldEqual, connectivity.TransientFailure)
+	}
+	test.That(t, <-states, test.ShouldEqual, connectivity.Shutdown)
+
+	dialMu.Lock()
+	test.That(t, dialCount, test.ShouldEqual, 3)
+	dialMu.Unlock()
+	first.mu.Lock()
+	test.That(t, first.closed, test.ShouldBeTrue)
+	first.mu.Unlock()
+
+	err := rc.Invoke(context.Background(), "/a/b", nil, nil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+	test.That(t, err.Error(), test.ShouldContainSubstring, dialErr.Error())
+
+	test.That(t, rc.Close(), test.ShouldBeNil)
+}
+
+func TestReconnectingClientConnWaitRespectsContext(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	first := &fakeReconnectConn{invokeErr: io.ErrClosedPipe}
+	dialStarted := make(chan struct{}, 1)
+	rc := newReconnectingClientConn("somewhere", logger, ReconnectOptions{
+		MinBackoff:     time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		AttemptTimeout: time.Minute,
+	}, func(ctx context.Context) (ClientConn, error) {
+		select {
+		case dialStarted <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	rc.setConn(first)
+	test.That(t, rc.Invoke(context.Background(), "/a/b", nil, nil), test.ShouldEqual, io.ErrClosedPipe)
+	<-dialStarted
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := rc.Invoke(ctx, "/a/b", nil, nil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+
+	// closing interrupts the in progress reconnect
+	test.That(t, rc.Close(), test.ShouldBeNil)
+	test.That(t, status.Code(rc.Invoke(context.Background(), "/a/b", nil, nil)), test.ShouldEqual, codes.Canceled)
+}
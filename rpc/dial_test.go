@@ -25,6 +25,7 @@ import (
 	"go.uber.org/multierr"
 	"go.viam.com/test"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/status"
 
 	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
@@ -1706,3 +1707,109 @@ func signTestAuthToken(t *testing.T, privKey *rsa.PrivateKey, aud, ent string, c
 
 	return tokenString
 }
+
+func TestDialWithReconnectWebRTC(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+
+	var authMu sync.Mutex
+	var authCount int
+	rpcServer, err := NewServer(
+		logger,
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+		WithAuthHandler("fake", AuthHandlerFunc(func(ctx context.Context, entity, payload string) (map[string]string, error) {
+			authMu.Lock()
+			defer authMu.Unlock()
+			authCount++
+			return map[string]string{}, nil
+		})),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	states := make(chan connectivity.State, 100)
+	conn, err := Dial(context.Background(), "yeehaw", logger,
+		WithDisableDirectGRPC(),
+		WithDialMulticastDNSOptions(DialMulticastDNSOptions{Disable: true}),
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingServerAddress: httpListener.Addr().String(),
+			SignalingInsecure:      true,
+			SignalingCreds:         Credentials{Type: "fake"},
+		}),
+		WithReconnect(ReconnectOptions{
+			MinBackoff: 10 * time.Millisecond,
+			MaxBackoff: 50 * time.Millisecond,
+			OnStateChange: func(state connectivity.State) {
+				states <- state
+			},
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, <-states, test.ShouldEqual, connectivity.Ready)
+
+	client := pb.NewEchoServiceClient(conn)
+	echoResp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, echoResp.GetMessage(), test.ShouldEqual, "hello")
+
+	authMu.Lock()
+	authCountBefore := authCount
+	authMu.Unlock()
+	test.That(t, authCountBefore, test.ShouldBeGreaterThan, 0)
+
+	rc, ok := conn.(*reconnectingClientConn)
+	test.That(t, ok, test.ShouldBeTrue)
+	rc.mu.Lock()
+	firstCh, ok := unwrapClientConn(rc.conn).(*webrtcClientChannel)
+	rc.mu.Unlock()
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// drop the peer connection out from under the client without any call in flight
+	test.That(t, firstCh.peerConn.Close(), test.ShouldBeNil)
+	test.That(t, <-states, test.ShouldEqual, connectivity.TransientFailure)
+	for state := range states {
+		if state == connectivity.Ready {
+			break
+		}
+		test.That(t, state, test.ShouldBeIn, connectivity.Connecting, connectivity.TransientFailure)
+	}
+
+	rc.mu.Lock()
+	secondCh, ok := unwrapClientConn(rc.conn).(*webrtcClientChannel)
+	rc.mu.Unlock()
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, secondCh, test.ShouldNotEqual, firstCh)
+
+	echoResp, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "world"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, echoResp.GetMessage(), test.ShouldEqual, "world")
+
+	// signaling for the new peer connection authenticated again
+	authMu.Lock()
+	test.That(t, authCount, test.ShouldBeGreaterThan, authCountBefore)
+	authMu.Unlock()
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
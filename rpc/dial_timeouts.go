@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DialTimeouts bound how long each stage of a dial may take. A stage without a timeout is
+// only bounded by the context of the dial and, for WebRTC, the offer deadline.
+type DialTimeouts struct {
+	// MulticastDNSLookup bounds each mDNS lookup. A lookup that times out is treated as
+	// nothing having been found. Defaults to 1s.
+	MulticastDNSLookup time.Duration
+
+	// SignalingConnect bounds connecting to the signaling server and fetching its WebRTC
+	// configuration.
+	SignalingConnect time.Duration
+
+	// ICEGathering bounds gathering local ICE candidates. With trickle ICE, only the first
+	// host candidate is waited for.
+	ICEGathering time.Duration
+
+	// ICEConnect bounds the time from sending an offer until ICE has connected.
+	ICEConnect time.Duration
+
+	// DataChannelOpen bounds the time from ICE connecting until the data channel is open.
+	DataChannelOpen time.Duration
+
+	// DirectGRPCConnect bounds connecting directly over gRPC.
+	DirectGRPCConnect time.Duration
+}
+
+// A DialStage is one part of dialing that can time out on its own.
+type DialStage string
+
+// Known dial stages.
+const (
+	DialStageMulticastDNSLookup = DialStage("mDNS lookup")
+	DialStageSignalingConnect   = DialStage("signaling connect")
+	DialStageICEGathering       = DialStage("ICE gathering")
+	DialStageICEConnect         = DialStage("ICE connect")
+	DialStageDataChannelOpen    = DialStage("data channel open")
+	DialStageDirectGRPCConnect  = DialStage("direct gRPC connect")
+)
+
+// A DialTimeoutError is returned when a stage of a dial takes longer than its timeout in
+// DialTimeouts allows. It is a context.DeadlineExceeded error.
+type DialTimeoutError struct {
+	Stage   DialStage
+	Timeout time.Duration
+}
+
+func (e *DialTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for %s", e.Timeout, e.Stage)
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *DialTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Timeout returns true.
+func (e *DialTimeoutError) Timeout() bool {
+	return true
+}
+
+// withDialStageTimeout bounds a stage of a dial by the given timeout, if there is one. The
+// returned function turns an error caused by the stage timing out into a DialTimeoutError.
+func withDialStageTimeout(
+	ctx context.Context,
+	stage DialStage,
+	timeout time.Duration,
+) (context.Context, func(err error) error, func()) {
+	if timeout <= 0 {
+		return ctx, func(err error) error { return err }, func() {}
+	}
+	stageCtx, cancel := context.WithTimeout(ctx, timeout)
+	stageErr := func(err error) error {
+		if err == nil || ctx.Err() != nil || !errors.Is(stageCtx.Err(), context.DeadlineExceeded) {
+			return err
+		}
+		return &DialTimeoutError{Stage: stage, Timeout: timeout}
+	}
+	return stageCtx, stageErr, cancel
+}
@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+)
+
+func TestWithDialStageTimeout(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	stageCtx, stageErr, cancel := withDialStageTimeout(context.Background(), DialStageICEConnect, 0)
+	test.That(t, stageCtx, test.ShouldEqual, context.Background())
+	test.That(t, stageErr(errFailed), test.ShouldEqual, errFailed)
+	cancel()
+
+	stageCtx, stageErr, cancel = withDialStageTimeout(context.Background(), DialStageICEConnect, time.Millisecond)
+	defer cancel()
+	test.That(t, stageErr(nil), test.ShouldBeNil)
+	<-stageCtx.Done()
+	err := stageErr(stageCtx.Err())
+	var timeoutErr *DialTimeoutError
+	test.That(t, errors.As(err, &timeoutErr), test.ShouldBeTrue)
+	test.That(t, timeoutErr.Stage, test.ShouldEqual, DialStageICEConnect)
+	test.That(t, timeoutErr.Timeout, test.ShouldEqual, time.Millisecond)
+	test.That(t, errors.Is(err, context.DeadlineExceeded), test.ShouldBeTrue)
+	test.That(t, err.Error(), test.ShouldEqual, "timed out after 1ms waiting for ICE connect")
+
+	// the dial as a whole ending is not blamed on the stage
+	parentCtx, parentCancel := context.WithCancel(context.Background())
+	stageCtx, stageErr, cancel = withDialStageTimeout(parentCtx, DialStageICEConnect, time.Hour)
+	defer cancel()
+	parentCancel()
+	<-stageCtx.Done()
+	test.That(t, stageErr(stageCtx.Err()), test.ShouldEqual, context.Canceled)
+}
+
+func TestDialTimeouts(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	// accepts connections but never says anything
+	blackhole, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	blackholeDone := make(chan struct{})
+	go func() {
+		defer close(blackholeDone)
+		var conns []net.Conn
+		for {
+			conn, err := blackhole.Accept()
+			if err != nil {
+				break
+			}
+			conns = append(conns, conn)
+		}
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	defer func() {
+		test.That(t, blackhole.Close(), test.ShouldBeNil)
+		<-blackholeDone
+	}()
+
+	for _, tc := range []struct {
+		stage DialStage
+		opts  []DialOption
+	}{
+		{
+			DialStageDirectGRPCConnect,
+			[]DialOption{
+				WithWebRTCOptions(DialWebRTCOptions{Disable: true}),
+				WithDialTimeouts(DialTimeouts{DirectGRPCConnect: 100 * time.Millisecond}),
+			},
+		},
+		{
+			DialStageSignalingConnect,
+			[]DialOption{
+				WithWebRTCOptions(DialWebRTCOptions{
+					SignalingServerAddress: blackhole.Addr().String(),
+					SignalingInsecure:      true,
+				}),
+				WithDialTimeouts(DialTimeouts{SignalingConnect: 100 * time.Millisecond}),
+			},
+		},
+	} {
+		t.Run(string(tc.stage), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			opts := append([]DialOption{
+				WithInsecure(),
+				WithDialMulticastDNSOptions(DialMulticastDNSOptions{Disable: true}),
+			}, tc.opts...)
+			start := time.Now()
+			_, err := Dial(ctx, blackhole.Addr().String(), logger, opts...)
+			test.That(t, time.Since(start), test.ShouldBeLessThan, 5*time.Second)
+			var timeoutErr *DialTimeoutError
+			test.That(t, errors.As(err, &timeoutErr), test.ShouldBeTrue)
+			test.That(t, timeoutErr.Stage, test.ShouldEqual, tc.stage)
+		})
+	}
+}
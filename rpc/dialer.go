@@ -8,6 +8,7 @@ import (
 	"hash/fnv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -18,6 +19,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
@@ -68,16 +70,73 @@ type ClientConnAuthenticator interface {
 	Authenticate(ctx context.Context) (string, error)
 }
 
+// CachedDialerOptions control how a cached Dialer looks after the connections it holds.
+type CachedDialerOptions struct {
+	// HealthCheckInterval is how often cached connections are checked. Connections that
+	// have failed are evicted so that they are no longer handed out; whoever still holds
+	// them keeps them until they are closed. If zero, connections are only checked when
+	// they are about to be handed out.
+	HealthCheckInterval time.Duration
+
+	// HealthCheck, if set, is used on every HealthCheckInterval to probe connections in
+	// addition to checking their connectivity state. It is given a context bounded by the
+	// interval. A connection that fails the probe is evicted.
+	HealthCheck func(ctx context.Context, conn ClientConn) error
+
+	// MaxIdle is how long a connection that is no longer referenced by anyone is kept open
+	// in case it is asked for again. If zero, a connection is closed as soon as its last
+	// reference is closed.
+	MaxIdle time.Duration
+
+	// ConnectionsPerKey is how many connections may be made to the same target in order to
+	// spread references out across them. New connections are made until there are this many
+	// and then the least referenced one is handed out. Defaults to 1.
+	ConnectionsPerKey int
+}
+
 type cachedDialer struct {
 	mu    sync.Mutex // Note(erd): not suitable for highly concurrent usage
-	conns map[string]*refCountedConnWrapper
+	conns map[string][]*refCountedConnWrapper
+	opts  CachedDialerOptions
+
+	cancelBackgroundWorkers func()
+	activeBackgroundWorkers sync.WaitGroup
 }
 
 // NewCachedDialer returns a Dialer that returns the same connection if it
 // already has been established at a particular target (regardless of the
 // options used).
 func NewCachedDialer() Dialer {
-	return &cachedDialer{conns: map[string]*refCountedConnWrapper{}}
+	return NewCachedDialerWithOptions(CachedDialerOptions{})
+}
+
+// NewCachedDialerWithOptions returns a Dialer like NewCachedDialer that additionally
+// health checks, idles, and spreads out its connections as described by opts.
+func NewCachedDialerWithOptions(opts CachedDialerOptions) Dialer {
+	if opts.ConnectionsPerKey <= 0 {
+		opts.ConnectionsPerKey = 1
+	}
+	cd := &cachedDialer{conns: map[string][]*refCountedConnWrapper{}, opts: opts}
+
+	checkInterval := opts.HealthCheckInterval
+	if opts.MaxIdle > 0 && (checkInterval == 0 || opts.MaxIdle < checkInterval) {
+		checkInterval = opts.MaxIdle
+	}
+	if checkInterval == 0 {
+		return cd
+	}
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cd.cancelBackgroundWorkers = cancel
+	cd.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		for {
+			if !utils.SelectContextOrWait(cancelCtx, checkInterval) {
+				return
+			}
+			cd.checkConns(cancelCtx)
+		}
+	}, cd.activeBackgroundWorkers.Done)
+	return cd
 }
 
 func (cd *cachedDialer) DialDirect(
@@ -103,11 +162,8 @@ func (cd *cachedDialer) DialFunc(
 	dialNew func() (ClientConn, func() error, error),
 ) (ClientConn, bool, error) {
 	key := fmt.Sprintf("%s:%s:%s", proto, target, keyExtra)
-	cd.mu.Lock()
-	c, ok := cd.conns[key]
-	cd.mu.Unlock()
-	if ok {
-		return c.Ref(), true, nil
+	if conn := cd.refExisting(key, nil); conn != nil {
+		return conn, true, nil
 	}
 
 	// assume any difference in opts does not matter
@@ -116,33 +172,138 @@ func (cd *cachedDialer) DialFunc(
 		return nil, false, err
 	}
 	conn = wrapClientConnWithCloseFunc(conn, onClose)
-	refConn := newRefCountedConnWrapper(proto, conn, func() {
-		cd.mu.Lock()
-		delete(cd.conns, key)
-		cd.mu.Unlock()
+	var refConn *refCountedConnWrapper
+	refConn = newRefCountedConnWrapper(proto, conn, func() {
+		cd.remove(key, refConn)
 	})
-	cd.mu.Lock()
-	defer cd.mu.Unlock()
+	refConn.maxIdle = cd.opts.MaxIdle
 
 	// someone else might have already connected
-	c, ok = cd.conns[key]
-	if ok {
+	if existing := cd.refExisting(key, refConn); existing != nil {
 		if err := conn.Close(); err != nil {
 			return nil, false, err
 		}
-		return c.Ref(), true, nil
+		return existing, true, nil
 	}
-	cd.conns[key] = refConn
 	return refConn.Ref(), false, nil
 }
 
+// refExisting returns a reference to a healthy connection for the given key if one should
+// be used instead of making a new connection. That is the case when there are already
+// ConnectionsPerKey of them or, when looking before dialing, one of them is sitting idle.
+// If a newly made connection is given and nothing is returned, it is added to the cache.
+func (cd *cachedDialer) refExisting(key string, newConn *refCountedConnWrapper) ClientConn {
+	cd.mu.Lock()
+	var unhealthy []*refCountedConnWrapper
+	defer func() {
+		cd.mu.Unlock()
+		for _, c := range unhealthy {
+			cd.evict(c)
+		}
+	}()
+
+	var best *refCountedConnWrapper
+	bestRefs := -1
+	var healthy int
+	for _, c := range cd.conns[key] {
+		if !connStateHealthy(c.actual) {
+			unhealthy = append(unhealthy, c)
+			continue
+		}
+		refs, ok := c.refCount()
+		if !ok {
+			continue
+		}
+		healthy++
+		if bestRefs == -1 || refs < bestRefs {
+			best, bestRefs = c, refs
+		}
+	}
+	if best != nil && (healthy >= cd.opts.ConnectionsPerKey || (newConn == nil && bestRefs == 0)) {
+		if conn, ok := best.tryRef(); ok {
+			return conn
+		}
+	}
+	if newConn != nil {
+		cd.conns[key] = append(cd.conns[key], newConn)
+	}
+	return nil
+}
+
+// remove forgets about the given connection, if it is still known.
+func (cd *cachedDialer) remove(key string, c *refCountedConnWrapper) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	conns := cd.conns[key]
+	for i, other := range conns {
+		if other != c {
+			continue
+		}
+		conns = append(conns[:i:i], conns[i+1:]...)
+		if len(conns) == 0 {
+			delete(cd.conns, key)
+		} else {
+			cd.conns[key] = conns
+		}
+		return
+	}
+}
+
+// evict stops the given connection from being handed out again. It is closed now if no
+// one is using it or otherwise once the last reference to it is closed.
+func (cd *cachedDialer) evict(c *refCountedConnWrapper) {
+	if !c.evict() {
+		return
+	}
+	if utils.Debug {
+		golog.Global().Debugw("closing evicted conn", "proto", c.proto)
+	}
+	if err := c.actual.Close(); err != nil && status.Convert(err).Code() != codes.Canceled {
+		golog.Global().Debugw("error closing evicted conn", "proto", c.proto, "error", err)
+	}
+}
+
+// checkConns evicts any connections that are unhealthy or have been idle for too long.
+func (cd *cachedDialer) checkConns(ctx context.Context) {
+	cd.mu.Lock()
+	var conns []*refCountedConnWrapper
+	for _, keyConns := range cd.conns {
+		conns = append(conns, keyConns...)
+	}
+	cd.mu.Unlock()
+
+	for _, c := range conns {
+		if ctx.Err() != nil {
+			return
+		}
+		if c.idleFor() > cd.opts.MaxIdle || !connStateHealthy(c.actual) {
+			cd.evict(c)
+			continue
+		}
+		if cd.opts.HealthCheck == nil || cd.opts.HealthCheckInterval == 0 {
+			continue
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, cd.opts.HealthCheckInterval)
+		err := cd.opts.HealthCheck(checkCtx, c.actual)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			golog.Global().Debugw("cached conn failed health check", "proto", c.proto, "error", err)
+			cd.evict(c)
+		}
+	}
+}
+
 func (cd *cachedDialer) Close() error {
+	if cd.cancelBackgroundWorkers != nil {
+		cd.cancelBackgroundWorkers()
+		cd.activeBackgroundWorkers.Wait()
+	}
 	cd.mu.Lock()
 	// need a copy of cd.conns as we can't hold the lock, since .Close() fires the onUnref() set (above) in DialFunc()
 	// that uses the same lock and directly modifies cd.conns when the dialer is reused at different layers (e.g. auth and multi)
 	var conns []*refCountedConnWrapper
-	for _, c := range cd.conns {
-		conns = append(conns, c)
+	for _, keyConns := range cd.conns {
+		conns = append(conns, keyConns...)
 	}
 	cd.mu.Unlock()
 	var err error
@@ -154,22 +315,111 @@ func (cd *cachedDialer) Close() error {
 	return err
 }
 
+// connStateHealthy returns whether or not the given connection is worth handing out.
+func connStateHealthy(conn ClientConn) bool {
+	state := clientConnState(conn)
+	return state != connectivity.TransientFailure && state != connectivity.Shutdown
+}
+
 // newRefCountedConnWrapper wraps the given connection to be able to be reference counted.
 func newRefCountedConnWrapper(proto string, conn ClientConn, onUnref func()) *refCountedConnWrapper {
-	return &refCountedConnWrapper{proto, utils.NewRefCountedValue(conn), conn, onUnref}
+	return &refCountedConnWrapper{proto: proto, actual: conn, onUnref: onUnref}
 }
 
-// refCountedConnWrapper wraps a ClientConn to be reference counted.
+// refCountedConnWrapper wraps a ClientConn to be reference counted. Once it is no longer
+// referenced, it is released unless maxIdle is set, in which case it may be referenced
+// again until it is evicted.
 type refCountedConnWrapper struct {
 	proto   string
-	ref     utils.RefCountedValue
 	actual  ClientConn
 	onUnref func()
+	maxIdle time.Duration
+
+	mu        sync.Mutex
+	count     int
+	idleSince time.Time
+	evicted   bool
+	released  bool
 }
 
 // Ref returns a new reference to the underlying ClientConn.
 func (w *refCountedConnWrapper) Ref() ClientConn {
-	return &reffedConn{ClientConn: w.ref.Ref().(ClientConn), proto: w.proto, deref: w.ref.Deref, onUnref: w.onUnref}
+	conn, ok := w.tryRef()
+	if !ok {
+		panic("already released")
+	}
+	return conn
+}
+
+// tryRef returns a new reference to the underlying ClientConn if it has not yet been
+// released or evicted.
+func (w *refCountedConnWrapper) tryRef() (ClientConn, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.released || w.evicted {
+		return nil, false
+	}
+	w.count++
+	return &reffedConn{ClientConn: w.actual, proto: w.proto, deref: w.deref, onUnref: w.onUnref}, true
+}
+
+// deref drops a reference and returns whether or not the underlying connection should now
+// be closed.
+func (w *refCountedConnWrapper) deref() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.count <= 0 {
+		panic("deref when count already zero")
+	}
+	w.count--
+	if w.count > 0 {
+		return false
+	}
+	if w.maxIdle > 0 && !w.evicted {
+		w.idleSince = time.Now()
+		return false
+	}
+	w.released = true
+	return true
+}
+
+// refCount returns the number of references, if the connection has not yet been released.
+func (w *refCountedConnWrapper) refCount() (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count, !w.released && !w.evicted
+}
+
+// idleFor returns how long the connection has gone unreferenced.
+func (w *refCountedConnWrapper) idleFor() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.count > 0 || w.released || w.idleSince.IsZero() {
+		return 0
+	}
+	return time.Since(w.idleSince)
+}
+
+// evict prevents any new references from being made and returns whether or not the caller
+// must now close the underlying connection because no one is referencing it.
+func (w *refCountedConnWrapper) evict() bool {
+	w.mu.Lock()
+	if w.evicted || w.released {
+		w.mu.Unlock()
+		return false
+	}
+	w.evicted = true
+	release := w.count == 0
+	if release {
+		w.released = true
+	}
+	w.mu.Unlock()
+	// either way it is no longer handed out; if it is still referenced, the last
+	// reference to be closed will close it.
+	if w.onUnref != nil {
+		w.onUnref()
+	}
+	return release
 }
 
 // A reffedConn reference counts a ClieentConn and closes it on the last dereference.
@@ -223,9 +473,16 @@ func DialDirectGRPC(ctx context.Context, address string, logger golog.Logger, op
 
 // dialDirectGRPC dials a gRPC server directly.
 func dialDirectGRPC(ctx context.Context, address string, dOpts *dialOptions, logger golog.Logger) (ClientConn, bool, error) {
+	maxRecvMsgSize := MaxMessageSize
+	if dOpts.maxRecvMsgSize > 0 {
+		maxRecvMsgSize = dOpts.maxRecvMsgSize
+	}
 	dialOpts := []grpc.DialOption{
 		grpc.WithBlock(),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMessageSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMsgSize)),
+	}
+	if dOpts.maxSendMsgSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(dOpts.maxSendMsgSize)))
 	}
 	if dOpts.insecure {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -265,6 +522,10 @@ func dialDirectGRPC(ctx context.Context, address string, dOpts *dialOptions, log
 		dialOpts = append(dialOpts, grpc.WithStatsHandler(dOpts.statsHandler))
 	}
 
+	if dOpts.compressor != "" {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(dOpts.compressor)))
+	}
+
 	grpcLogger := logger.Desugar()
 	if !(dOpts.debug || utils.Debug) {
 		grpcLogger = grpcLogger.WithOptions(zap.IncreaseLevel(zap.LevelEnablerFunc(zapcore.ErrorLevel.Enabled)))
@@ -272,8 +533,8 @@ func dialDirectGRPC(ctx context.Context, address string, dOpts *dialOptions, log
 	var unaryInterceptors []grpc.UnaryClientInterceptor
 	unaryInterceptors = append(unaryInterceptors, grpc_zap.UnaryClientInterceptor(grpcLogger))
 	unaryInterceptors = append(unaryInterceptors, UnaryClientTracingInterceptor())
-	if dOpts.unaryInterceptor != nil {
-		unaryInterceptors = append(unaryInterceptors, dOpts.unaryInterceptor)
+	if unaryInterceptor := dOpts.clientUnaryInterceptor(); unaryInterceptor != nil {
+		unaryInterceptors = append(unaryInterceptors, unaryInterceptor)
 	}
 	unaryInterceptor := grpc_middleware.ChainUnaryClient(unaryInterceptors...)
 	dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(unaryInterceptor))
@@ -366,6 +627,9 @@ func dialDirectGRPC(ctx context.Context, address string, dOpts *dialOptions, log
 	if connPtr != nil {
 		*connPtr = conn
 	}
+	if grpcConn, ok := unwrapClientConn(conn).(*grpc.ClientConn); ok {
+		trackDirectGRPCTransportInfo(grpcConn, newDirectGRPCTransportInfo(address, dOpts))
+	}
 	if rpcCreds != nil {
 		conn = clientConnRPCAuthenticator{conn, rpcCreds}
 	}
@@ -2,13 +2,17 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"net"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"go.viam.com/test"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
@@ -542,3 +546,199 @@ func TestWithStreamInterceptor(t *testing.T) {
 	)
 	test.That(t, interceptedCount, test.ShouldEqual, 1)
 }
+
+type fakeStateConn struct {
+	fakeReconnectConn
+	stateMu sync.Mutex
+	state   connectivity.State
+}
+
+func (c *fakeStateConn) State() connectivity.State {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.state
+}
+
+func (c *fakeStateConn) setState(state connectivity.State) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.state = state
+}
+
+func (c *fakeStateConn) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	<-ctx.Done()
+	return false
+}
+
+func (c *fakeStateConn) TransportInfo() TransportInfo {
+	return TransportInfo{}
+}
+
+func (c *fakeStateConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func dialFake(cd Dialer, conn *fakeStateConn) (ClientConn, bool, error) {
+	return cd.DialFunc("fake", "somewhere", "", func() (ClientConn, func() error, error) {
+		return conn, nil, nil
+	})
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCachedDialerEvictsUnhealthy(t *testing.T) {
+	cachedDialer := NewCachedDialer()
+
+	first := &fakeStateConn{state: connectivity.Ready}
+	conn1, cached, err := dialFake(cachedDialer, first)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+
+	conn2, cached, err := dialFake(cachedDialer, &fakeStateConn{state: connectivity.Ready})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeTrue)
+	test.That(t, conn2.(*reffedConn).ClientConn, test.ShouldEqual, conn1.(*reffedConn).ClientConn)
+
+	// a failed connection is no longer handed out
+	first.setState(connectivity.TransientFailure)
+	second := &fakeStateConn{state: connectivity.Ready}
+	conn3, cached, err := dialFake(cachedDialer, second)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	test.That(t, conn3.(*reffedConn).ClientConn, test.ShouldNotEqual, conn1.(*reffedConn).ClientConn)
+
+	// but it stays open until everyone is done with it
+	test.That(t, first.isClosed(), test.ShouldBeFalse)
+	test.That(t, conn1.Close(), test.ShouldBeNil)
+	test.That(t, first.isClosed(), test.ShouldBeFalse)
+	test.That(t, conn2.Close(), test.ShouldBeNil)
+	test.That(t, first.isClosed(), test.ShouldBeTrue)
+
+	test.That(t, conn3.Close(), test.ShouldBeNil)
+	test.That(t, second.isClosed(), test.ShouldBeTrue)
+	test.That(t, cachedDialer.Close(), test.ShouldBeNil)
+}
+
+func TestCachedDialerHealthCheck(t *testing.T) {
+	var checkMu sync.Mutex
+	failing := map[ClientConn]bool{}
+	cachedDialer := NewCachedDialerWithOptions(CachedDialerOptions{
+		HealthCheckInterval: 10 * time.Millisecond,
+		HealthCheck: func(ctx context.Context, conn ClientConn) error {
+			checkMu.Lock()
+			defer checkMu.Unlock()
+			if failing[conn.(*clientConnWithCloseFunc).ClientConn] {
+				return errors.New("no good")
+			}
+			return nil
+		},
+	})
+	cd := cachedDialer.(*cachedDialer)
+	numConns := func() int {
+		cd.mu.Lock()
+		defer cd.mu.Unlock()
+		var num int
+		for _, conns := range cd.conns {
+			num += len(conns)
+		}
+		return num
+	}
+
+	first := &fakeStateConn{state: connectivity.Ready}
+	conn1, _, err := dialFake(cachedDialer, first)
+	test.That(t, err, test.ShouldBeNil)
+
+	// evicted in the background once it fails
+	first.setState(connectivity.Shutdown)
+	waitFor(t, func() bool { return numConns() == 0 })
+	test.That(t, first.isClosed(), test.ShouldBeFalse)
+	test.That(t, conn1.Close(), test.ShouldBeNil)
+	test.That(t, first.isClosed(), test.ShouldBeTrue)
+
+	// or once it fails the probe
+	second := &fakeStateConn{state: connectivity.Ready}
+	conn2, _, err := dialFake(cachedDialer, second)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, numConns(), test.ShouldEqual, 1)
+	checkMu.Lock()
+	failing[second] = true
+	checkMu.Unlock()
+	waitFor(t, func() bool { return numConns() == 0 })
+	test.That(t, conn2.Close(), test.ShouldBeNil)
+	test.That(t, second.isClosed(), test.ShouldBeTrue)
+
+	test.That(t, cachedDialer.Close(), test.ShouldBeNil)
+}
+
+func TestCachedDialerMaxIdle(t *testing.T) {
+	cachedDialer := NewCachedDialerWithOptions(CachedDialerOptions{MaxIdle: 50 * time.Millisecond})
+
+	first := &fakeStateConn{state: connectivity.Ready}
+	conn1, cached, err := dialFake(cachedDialer, first)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	test.That(t, conn1.Close(), test.ShouldBeNil)
+	test.That(t, first.isClosed(), test.ShouldBeFalse)
+
+	// still around to be reused
+	conn2, cached, err := dialFake(cachedDialer, &fakeStateConn{state: connectivity.Ready})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeTrue)
+	test.That(t, conn2.(*reffedConn).ClientConn, test.ShouldEqual, conn1.(*reffedConn).ClientConn)
+	test.That(t, conn2.Close(), test.ShouldBeNil)
+
+	waitFor(t, first.isClosed)
+
+	second := &fakeStateConn{state: connectivity.Ready}
+	conn3, cached, err := dialFake(cachedDialer, second)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	test.That(t, conn3.Close(), test.ShouldBeNil)
+
+	test.That(t, cachedDialer.Close(), test.ShouldBeNil)
+	test.That(t, second.isClosed(), test.ShouldBeTrue)
+}
+
+func TestCachedDialerConnectionsPerKey(t *testing.T) {
+	cachedDialer := NewCachedDialerWithOptions(CachedDialerOptions{ConnectionsPerKey: 2})
+
+	first := &fakeStateConn{state: connectivity.Ready}
+	second := &fakeStateConn{state: connectivity.Ready}
+	conn1, cached, err := dialFake(cachedDialer, first)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	conn2, cached, err := dialFake(cachedDialer, second)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	test.That(t, conn2.(*reffedConn).ClientConn, test.ShouldNotEqual, conn1.(*reffedConn).ClientConn)
+
+	// full, so the least referenced connection is shared
+	test.That(t, conn1.Close(), test.ShouldBeNil)
+	test.That(t, first.isClosed(), test.ShouldBeTrue)
+	third := &fakeStateConn{state: connectivity.Ready}
+	conn3, cached, err := dialFake(cachedDialer, third)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeFalse)
+	conn4, cached, err := dialFake(cachedDialer, &fakeStateConn{state: connectivity.Ready})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeTrue)
+	test.That(t, conn3.(*reffedConn).ClientConn, test.ShouldNotEqual, conn2.(*reffedConn).ClientConn)
+
+	test.That(t, conn2.Close(), test.ShouldBeNil)
+	test.That(t, conn3.Close(), test.ShouldBeNil)
+	test.That(t, conn4.Close(), test.ShouldBeNil)
+	test.That(t, second.isClosed(), test.ShouldBeTrue)
+	test.That(t, third.isClosed(), test.ShouldBeTrue)
+	test.That(t, cachedDialer.Close(), test.ShouldBeNil)
+}
@@ -0,0 +1,168 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// A ServiceRecord describes where a server instance can be reached.
+type ServiceRecord struct {
+	// InstanceName is the name the server is known by.
+	InstanceName string `json:"instance_name"`
+
+	// Host is the IP address of the server.
+	Host string `json:"host"`
+
+	// Port is the port the server is listening on.
+	Port int `json:"port"`
+
+	// Services lists the means of connecting to the server that it supports ("grpc", "webrtc").
+	Services []string `json:"services"`
+}
+
+// hasService returns whether or not the record advertises the given service.
+func (rec ServiceRecord) hasService(service string) bool {
+	for _, other := range rec.Services {
+		if other == service {
+			return true
+		}
+	}
+	return false
+}
+
+// A Discoverer finds where server instances can be reached. Dial uses one to look up
+// addresses that are just names.
+type Discoverer interface {
+	// Discover returns the record for the given instance name. It returns nil and no error
+	// if the instance could not be found before ctx is done.
+	Discover(ctx context.Context, instanceName string) (*ServiceRecord, error)
+}
+
+// An Advertiser makes server instances discoverable. A Server uses one to advertise each
+// of its instance names.
+type Advertiser interface {
+	// Advertise advertises the given record until the returned function is called.
+	Advertise(record ServiceRecord) (stop func(), err error)
+}
+
+// A StaticRegistry is an in-memory Discoverer and Advertiser. It is useful for discovery
+// without multicast, such as in tests or when the set of servers is known up front.
+type StaticRegistry struct {
+	mu      sync.Mutex
+	records map[string]ServiceRecord
+}
+
+// NewStaticRegistry returns a registry containing the given records.
+func NewStaticRegistry(records ...ServiceRecord) *StaticRegistry {
+	reg := &StaticRegistry{records: map[string]ServiceRecord{}}
+	for _, rec := range records {
+		reg.records[rec.InstanceName] = rec
+	}
+	return reg
+}
+
+// NewStaticRegistryFromFile returns a registry containing the records found in the JSON
+// array stored at the given path.
+func NewStaticRegistryFromFile(path string) (*StaticRegistry, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var records []ServiceRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrapf(err, "error parsing registry file %q", path)
+	}
+	for _, rec := range records {
+		if rec.InstanceName == "" {
+			return nil, errors.Errorf("registry file %q has a record without an instance name", path)
+		}
+	}
+	return NewStaticRegistry(records...), nil
+}
+
+// Discover returns the record registered for the given instance name, if any.
+func (reg *StaticRegistry) Discover(ctx context.Context, instanceName string) (*ServiceRecord, error) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	rec, ok := reg.records[instanceName]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+// Advertise registers the record until the returned function is called.
+func (reg *StaticRegistry) Advertise(record ServiceRecord) (func(), error) {
+	if record.InstanceName == "" {
+		return nil, errors.New("instance name required")
+	}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.records[record.InstanceName] = record
+	return func() {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		// only remove what was advertised here in case it has since been replaced
+		if current, ok := reg.records[record.InstanceName]; ok && current.Host == record.Host &&
+			current.Port == record.Port {
+			delete(reg.records, record.InstanceName)
+		}
+	}, nil
+}
+
+// advertiseHostForIP returns the host to advertise a server listening on the given IP as.
+// Since an unspecified IP (0.0.0.0 or ::) cannot be dialed, it is resolved to the address of
+// an interface that is up, preferring IPv4, and to loopback if there is no such interface.
+func advertiseHostForIP(ip net.IP) (string, error) {
+	switch {
+	case ip.IsLoopback():
+		if ip.To4() != nil {
+			return "127.0.0.1", nil
+		}
+		return net.IPv6loopback.String(), nil
+	case ip.IsUnspecified():
+		ifcs, err := net.Interfaces()
+		if err != nil {
+			return "", err
+		}
+		onlyIPv4 := ip.To4() != nil
+		var ipv6 net.IP
+		for _, ifc := range ifcs {
+			if (ifc.Flags&net.FlagUp) == 0 || (ifc.Flags&net.FlagLoopback) != 0 {
+				continue
+			}
+			addrs, err := ifc.Addrs()
+			if err != nil {
+				return "", err
+			}
+			for _, addr := range addrs {
+				ipNet, ok := addr.(*net.IPNet)
+				// link-local addresses need a zone to be dialed
+				if !ok || ipNet.IP.IsLinkLocalUnicast() {
+					continue
+				}
+				if ipv4 := ipNet.IP.To4(); ipv4 != nil {
+					return ipv4.String(), nil
+				}
+				if !onlyIPv4 && ipv6 == nil {
+					ipv6 = ipNet.IP
+				}
+			}
+		}
+		if ipv6 != nil {
+			return ipv6.String(), nil
+		}
+		if onlyIPv4 {
+			return advertiseHostForIP(net.IPv4(127, 0, 0, 1))
+		}
+		return advertiseHostForIP(net.IPv6loopback)
+	default:
+		return ip.String(), nil
+	}
+}
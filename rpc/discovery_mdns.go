@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/edaniels/zeroconf"
+)
+
+const (
+	mdnsService = "_rpc._tcp"
+	mdnsDomain  = "local."
+)
+
+// NewMulticastDNSDiscoverer returns a Discoverer that looks for instances advertised over
+// mDNS on the local network. This is what Dial uses unless told otherwise.
+func NewMulticastDNSDiscoverer(logger golog.Logger) Discoverer {
+	return &mdnsDiscoverer{logger: logger}
+}
+
+type mdnsDiscoverer struct {
+	logger golog.Logger
+	// lookupTimeout bounds how long a single lookup waits for an answer.
+	lookupTimeout time.Duration
+}
+
+const defaultMulticastDNSLookupTimeout = time.Second
+
+func (md *mdnsDiscoverer) Discover(ctx context.Context, instanceName string) (*ServiceRecord, error) {
+	resolver, err := zeroconf.NewResolver(md.logger, zeroconf.SelectIPRecordType(zeroconf.IPv4))
+	if err != nil {
+		return nil, err
+	}
+	defer resolver.Shutdown()
+
+	entries := make(chan *zeroconf.ServiceEntry)
+	lookupTimeout := md.lookupTimeout
+	if lookupTimeout <= 0 {
+		lookupTimeout = defaultMulticastDNSLookupTimeout
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	if err := resolver.Lookup(lookupCtx, instanceName, mdnsService, mdnsDomain, entries); err != nil {
+		md.logger.Errorw("error performing mDNS query", "error", err)
+		return nil, err
+	}
+	// entries gets closed after lookupCtx expires or there is a real entry
+	entry := <-entries
+	if entry == nil {
+		return nil, ctx.Err()
+	}
+
+	// IPv6 with scope does not work with grpc-go which we would want here.
+	if len(entry.AddrIPv4) == 0 {
+		return nil, nil
+	}
+	rec := &ServiceRecord{
+		InstanceName: instanceName,
+		Host:         entry.AddrIPv4[0].String(),
+		Port:         entry.Port,
+	}
+	for _, field := range entry.Text {
+		// mdns service may advertise TXT field following https://datatracker.ietf.org/doc/html/rfc1464 (ex grpc=)
+		if strings.Contains(field, "grpc") {
+			rec.Services = append(rec.Services, "grpc")
+		}
+		if strings.Contains(field, "webrtc") {
+			rec.Services = append(rec.Services, "webrtc")
+		}
+	}
+	return rec, nil
+}
+
+// NewMulticastDNSAdvertiser returns an Advertiser that advertises instances over mDNS on
+// the local network. This is what a Server uses unless told otherwise. Records for
+// loopback hosts are only advertised on loopback interfaces.
+func NewMulticastDNSAdvertiser(logger golog.Logger) Advertiser {
+	return &mdnsAdvertiser{logger: logger}
+}
+
+type mdnsAdvertiser struct {
+	logger golog.Logger
+	// hostname and loopbackIfaces are what loopback hosts are advertised with. They are
+	// looked up when first needed if not set.
+	hostname       string
+	loopbackIfaces []net.Interface
+}
+
+// lookupLoopback looks up what loopback hosts are advertised with.
+func (ma *mdnsAdvertiser) lookupLoopback() error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	ifcs, err := net.Interfaces()
+	if err != nil {
+		return err
+	}
+	var loopbackIfaces []net.Interface
+	for _, ifc := range ifcs {
+		if (ifc.Flags&net.FlagUp) == 0 || (ifc.Flags&net.FlagLoopback) == 0 {
+			continue
+		}
+		loopbackIfaces = append(loopbackIfaces, ifc)
+		break
+	}
+	ma.hostname = hostname
+	ma.loopbackIfaces = loopbackIfaces
+	return nil
+}
+
+func (ma *mdnsAdvertiser) Advertise(record ServiceRecord) (func(), error) {
+	if ip := net.ParseIP(record.Host); ip == nil || !ip.IsLoopback() {
+		mdnsServer, err := zeroconf.RegisterDynamic(
+			record.InstanceName,
+			mdnsService,
+			mdnsDomain,
+			record.Port,
+			record.Services,
+			nil,
+			ma.logger,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return mdnsServer.Shutdown, nil
+	}
+
+	if ma.hostname == "" {
+		if err := ma.lookupLoopback(); err != nil {
+			return nil, err
+		}
+	}
+	mdnsServer, err := zeroconf.RegisterProxy(
+		record.InstanceName,
+		mdnsService,
+		mdnsDomain,
+		record.Port,
+		ma.hostname,
+		[]string{record.Host},
+		record.Services,
+		ma.loopbackIfaces,
+		ma.logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return mdnsServer.Shutdown, nil
+}
@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+)
+
+func TestStaticRegistry(t *testing.T) {
+	reg := NewStaticRegistry(ServiceRecord{InstanceName: "one", Host: "10.0.0.1", Port: 8080, Services: []string{"grpc"}})
+
+	rec, err := reg.Discover(context.Background(), "one")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec, test.ShouldResemble, &ServiceRecord{
+		InstanceName: "one",
+		Host:         "10.0.0.1",
+		Port:         8080,
+		Services:     []string{"grpc"},
+	})
+
+	rec, err = reg.Discover(context.Background(), "two")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec, test.ShouldBeNil)
+
+	stop, err := reg.Advertise(ServiceRecord{InstanceName: "two", Host: "10.0.0.2", Port: 8080})
+	test.That(t, err, test.ShouldBeNil)
+	rec, err = reg.Discover(context.Background(), "two")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec.Host, test.ShouldEqual, "10.0.0.2")
+
+	// a newer advertisement is not removed by an older one stopping
+	stop2, err := reg.Advertise(ServiceRecord{InstanceName: "two", Host: "10.0.0.3", Port: 8080})
+	test.That(t, err, test.ShouldBeNil)
+	stop()
+	rec, err = reg.Discover(context.Background(), "two")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec.Host, test.ShouldEqual, "10.0.0.3")
+	stop2()
+	rec, err = reg.Discover(context.Background(), "two")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec, test.ShouldBeNil)
+
+	_, err = reg.Advertise(ServiceRecord{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestStaticRegistryFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.json")
+	test.That(t, os.WriteFile(path, []byte(`[
+		{"instance_name": "one", "host": "10.0.0.1", "port": 8080, "services": ["grpc", "webrtc"]}
+	]`), 0o600), test.ShouldBeNil)
+
+	reg, err := NewStaticRegistryFromFile(path)
+	test.That(t, err, test.ShouldBeNil)
+	rec, err := reg.Discover(context.Background(), "one")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec, test.ShouldResemble, &ServiceRecord{
+		InstanceName: "one",
+		Host:         "10.0.0.1",
+		Port:         8080,
+		Services:     []string{"grpc", "webrtc"},
+	})
+
+	test.That(t, os.WriteFile(path, []byte(`[{"host": "10.0.0.1"}]`), 0o600), test.ShouldBeNil)
+	_, err = NewStaticRegistryFromFile(path)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	test.That(t, os.WriteFile(path, []byte(`{`), 0o600), test.ShouldBeNil)
+	_, err = NewStaticRegistryFromFile(path)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = NewStaticRegistryFromFile(filepath.Join(dir, "missing.json"))
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestAdvertiseHostForIP(t *testing.T) {
+	host, err := advertiseHostForIP(net.ParseIP("127.0.0.1"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, host, test.ShouldEqual, "127.0.0.1")
+
+	host, err = advertiseHostForIP(net.IPv6loopback)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, host, test.ShouldEqual, "::1")
+
+	host, err = advertiseHostForIP(net.ParseIP("10.0.0.1"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, host, test.ShouldEqual, "10.0.0.1")
+
+	host, err = advertiseHostForIP(net.IPv4zero)
+	test.That(t, err, test.ShouldBeNil)
+	ip := net.ParseIP(host)
+	test.That(t, ip, test.ShouldNotBeNil)
+	test.That(t, ip.IsUnspecified(), test.ShouldBeFalse)
+	test.That(t, ip.To4(), test.ShouldNotBeNil)
+
+	host, err = advertiseHostForIP(net.IPv6unspecified)
+	test.That(t, err, test.ShouldBeNil)
+	ip = net.ParseIP(host)
+	test.That(t, ip, test.ShouldNotBeNil)
+	test.That(t, ip.IsUnspecified(), test.ShouldBeFalse)
+}
+
+func TestServerAndDialWithStaticRegistry(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	reg := NewStaticRegistry()
+
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("some.robot"),
+		WithAdvertiser(reg),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rpcServer.Start(), test.ShouldBeNil)
+
+	// both the name and its dashed form are advertised
+	for _, name := range []string{"some.robot", "some-robot"} {
+		rec, err := reg.Discover(context.Background(), name)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, rec, test.ShouldNotBeNil)
+		test.That(t, rec.Services, test.ShouldResemble, []string{"grpc"})
+	}
+
+	conn, err := Dial(context.Background(), "some.robot", logger, WithInsecure(), WithDiscoverer(reg))
+	test.That(t, err, test.ShouldBeNil)
+	info := TransportInfoFromClientConn(conn)
+	test.That(t, info.Type, test.ShouldEqual, TransportTypeGRPC)
+	test.That(t, info.MulticastDNS, test.ShouldBeTrue)
+	test.That(t, info.RemoteAddress, test.ShouldEqual, rpcServer.InternalAddr().String())
+
+	client := pb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+
+	// no longer advertised once stopped
+	rec, err := reg.Discover(context.Background(), "some.robot")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rec, test.ShouldBeNil)
+}
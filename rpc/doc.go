@@ -15,6 +15,11 @@ mechanisms to connect to a target server. By default it will try to connect in t
 order: mDNS (direct/WebRTC), WebRTC, Direct gRPC. This ordering can be modified by disabling
 some of these methods with DialOptions.
 
+TransportInfoFromClientConn reports which transport a connection returned by Dial ended up using.
+Connections other than plain direct gRPC ones, which are returned as a *grpc.ClientConn, also
+implement ClientConnStateReporter, which reports whether the connection is live. With the WithReconnect DialOption, a connection that fails is
+reestablished by running through the same mechanisms again.
+
 # Direct gRPC
 
 This is the simplest form of connection and for the most part passes straight through to the gRPC
@@ -28,7 +33,13 @@ has the client and server act as peers and connects them in the best way possibl
 a DataChannel is established that we use to tunnel over gRPC method calls
 (mimicking the gRPC over HTTP2 specification (https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md)).
 Using this is a powerful means of connection because it exposes ContextPeerConnection which makes it possible
-to use gRPC methods to modify the Video/Audio part of the connection.
+to use gRPC methods to modify the Video/Audio part of the connection. Handlers can add media tracks to the peer
+connection of their call with AddTrack, which renegotiates the connection over a dedicated DataChannel, and clients
+receive them by setting a TrackHandler on their connection with ClientConnTrackReceiver.
+
+Messages larger than a DataChannel packet are split up and reassembled on the other end before they are
+handled. Byte oriented streaming calls, like file uploads, can instead use a ChunkWriter and ChunkReader to
+send data as messages that each fit in a single packet, so that neither end holds more than a message at a time.
 
 Multicast DNS (mDNS)
 
@@ -43,6 +54,10 @@ request client certificates. This will not negatively affect any UI hosted (peer
 the server's GRPCHandler because since that handler will only use the tls.Config of the http.Server hosting it,
 separate from the internal one.
 
+mDNS is only the default means of discovery. A different Advertiser and Discoverer can be plugged in with the
+WithAdvertiser ServerOption and the WithDiscoverer DialOption respectively. A StaticRegistry is provided
+that does both in memory, which is helpful for tests or fleets whose addresses are known ahead of time.
+
 # Authentication Modes
 
 Authentication into gRPC works by configuring a server with a series of authentication handlers provided
@@ -67,6 +82,11 @@ the authentication middleware before JWT presence is checked. If any of the DNS
 client certificate match that of the entities checked in WithTLSAuthHandler, then the request will be
 allowed to proceed.
 
+Local callers can skip token exchange entirely by connecting over a unix socket, either the internal
+listener bound with WithInternalUnixSocket or a unix listener passed to Serve. On Linux, the
+WithPeerCredentialsAuthHandler ServerOption authenticates these callers by the user and group IDs of their
+process (SO_PEERCRED) when no JWT is present.
+
 For WebRTC, we assume that signaling is implemented as an authenticated/authorized service and for now,
 do not pass any JWTs over the WebRTC data channels that are established. For more info,
 see https://github.com/viamrobotics/goutils/issues/12.
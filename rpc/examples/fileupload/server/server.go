@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	pb "go.viam.com/utils/proto/rpc/examples/fileupload/v1"
+	"go.viam.com/utils/rpc"
 )
 
 // Server implements a simple file upload service.
@@ -24,39 +25,38 @@ func (srv *Server) SetFail(fail bool) {
 	srv.mu.Unlock()
 }
 
-// UploadFile receives a file over a series of chunks.
+// UploadFile receives a file over a series of chunks. The file is streamed through as it
+// arrives rather than held in memory, so files of any size can be uploaded.
 func (srv *Server) UploadFile(server pb.FileUploadService_UploadFileServer) error {
-	var haveName bool
-	var fileName string
-	var data []byte
-	for {
-		select {
-		case <-server.Context().Done():
-			return server.Context().Err()
-		default:
+	req, err := server.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return server.Send(&pb.UploadFileResponse{})
 		}
+		return err
+	}
+	name, ok := req.Data.(*pb.UploadFileRequest_Name)
+	if !ok {
+		return errors.New("first provide file name")
+	}
+	file := rpc.NewChunkReader(func() ([]byte, error) {
 		req, err := server.Recv()
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				// at this point, you can do something with the file
-				return server.Send(&pb.UploadFileResponse{Name: fileName, Size: int64(len(data))})
-			}
-			return err
+			return nil, err
 		}
 		switch d := req.Data.(type) {
-		case (*pb.UploadFileRequest_Name):
-			if haveName {
-				return errors.New("received name more than once")
-			}
-			haveName = true
-			fileName = d.Name
-		case (*pb.UploadFileRequest_ChunkData):
-			if !haveName {
-				return errors.New("first provide file name")
-			}
-			data = append(data, d.ChunkData...)
+		case *pb.UploadFileRequest_Name:
+			return nil, errors.New("received name more than once")
+		case *pb.UploadFileRequest_ChunkData:
+			return d.ChunkData, nil
 		default:
-			return errors.Errorf("unknown data type %T", req.Data)
+			return nil, errors.Errorf("unknown data type %T", req.Data)
 		}
+	})
+	// at this point, you can do something with the file as it is read
+	size, err := io.Copy(io.Discard, file)
+	if err != nil {
+		return err
 	}
+	return server.Send(&pb.UploadFileResponse{Name: name.Name, Size: size})
 }
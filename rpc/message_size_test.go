@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestMessageSizeLimits(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+		WithMaxRecvMsgSize(1<<16),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+	addr := httpListener.Addr().String()
+
+	tooLargeForServer := strings.Repeat("a", 1<<17)
+	tooLargeForClient := strings.Repeat("a", 1<<11)
+
+	for _, tc := range []struct {
+		transport string
+		dial      func(opts ...DialOption) (ClientConn, error)
+	}{
+		{"grpc", func(opts ...DialOption) (ClientConn, error) {
+			return DialDirectGRPC(context.Background(), addr, logger, append(opts, WithInsecure())...)
+		}},
+		{"webrtc", func(opts ...DialOption) (ClientConn, error) {
+			return DialWebRTC(context.Background(), addr, "yeehaw", logger,
+				append(opts, WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}))...)
+		}},
+	} {
+		t.Run(tc.transport, func(t *testing.T) {
+			conn, err := tc.dial(WithDialMaxRecvMsgSize(1 << 10))
+			test.That(t, err, test.ShouldBeNil)
+			defer func() {
+				test.That(t, conn.Close(), test.ShouldBeNil)
+			}()
+			client := pb.NewEchoServiceClient(conn)
+
+			_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: tooLargeForServer})
+			test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+
+			_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: tooLargeForClient})
+			test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+
+			_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"}, grpc.MaxCallSendMsgSize(4))
+			test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+
+			// calls may ask for more than the connection allows
+			resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: tooLargeForClient},
+				grpc.MaxCallRecvMsgSize(1<<12))
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, resp.Message, test.ShouldEqual, tooLargeForClient)
+
+			// the connection is still usable after calls fail
+			resp, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, resp.Message, test.ShouldEqual, "hello")
+		})
+	}
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestWebRTCBaseStreamMessageSizeLimit(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bs := newWebRTCBaseStream(ctx, cancel, &webrtcpb.Stream{Id: 1}, func(id uint64) {}, logger)
+	bs.maxRecvMsgSize = 4
+
+	_, eom, err := bs.processMessage(&webrtcpb.PacketMessage{Data: []byte("abc")})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, eom, test.ShouldBeFalse)
+	_, _, err = bs.processMessage(&webrtcpb.PacketMessage{Data: []byte("de"), Eom: true})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+
+	data, eom, err := bs.processMessage(&webrtcpb.PacketMessage{Data: []byte("abcd"), Eom: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, eom, test.ShouldBeTrue)
+	test.That(t, data, test.ShouldResemble, []byte("abcd"))
+
+	bs.maxSendMsgSize = 4
+	_, err = bs.marshal(&webrtcpb.Stream{Id: 1 << 40})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+}
@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/utils"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = 100 * time.Millisecond
+	defaultRetryMaxBackoff  = 5 * time.Second
+)
+
+// A RetryPolicy dictates how failed unary calls are retried. Since a call that failed may
+// still have had an effect on the server, only methods marked as idempotent are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a call is made, including the first. Defaults to 3.
+	MaxAttempts int
+
+	// MinBackoff is how long to wait before the first retry. The wait doubles (with jitter)
+	// after each failed attempt. Defaults to 100ms.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts. Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// RetryableCodes are the status codes that a call may be retried on. Defaults to
+	// Unavailable.
+	RetryableCodes []codes.Code
+
+	// IdempotentMethods lists the methods that are safe to retry, either by full method
+	// name (e.g. "/proto.rpc.examples.echo.v1.EchoService/Echo") or by service with a
+	// trailing slash (e.g. "/proto.rpc.examples.echo.v1.EchoService/").
+	IdempotentMethods []string
+
+	// AllIdempotent treats every method as idempotent.
+	AllIdempotent bool
+
+	// MethodPolicies override the policy for particular methods, named the same way as in
+	// IdempotentMethods. A full method name takes precedence over a service. The
+	// MethodPolicies of an override are ignored.
+	MethodPolicies map[string]RetryPolicy
+}
+
+// withDefaults returns the policy with all unset fields defaulted.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = defaultRetryMinBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = p.MinBackoff
+	}
+	if len(p.RetryableCodes) == 0 {
+		p.RetryableCodes = []codes.Code{codes.Unavailable}
+	}
+	return p
+}
+
+// forMethod returns the policy that applies to the given method.
+func (p RetryPolicy) forMethod(method string) RetryPolicy {
+	if override, ok := p.MethodPolicies[method]; ok {
+		return override.withDefaults()
+	}
+	if idx := strings.LastIndex(method, "/"); idx != -1 {
+		if override, ok := p.MethodPolicies[method[:idx+1]]; ok {
+			return override.withDefaults()
+		}
+	}
+	return p.withDefaults()
+}
+
+// isIdempotent returns whether or not the given method may be retried.
+func (p RetryPolicy) isIdempotent(method string) bool {
+	if p.AllIdempotent {
+		return true
+	}
+	for _, other := range p.IdempotentMethods {
+		if other == method || (strings.HasSuffix(other, "/") && strings.HasPrefix(method, other)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryable returns whether or not the given error may be retried.
+func (p RetryPolicy) isRetryable(err error) bool {
+	code := status.Code(err)
+	for _, other := range p.RetryableCodes {
+		if other == code {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryClientRetryInterceptor returns an interceptor that retries unary calls according to
+// the given policy. It works the same over any transport.
+func UnaryClientRetryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		methodPolicy := policy.forMethod(method)
+		if !policy.isIdempotent(method) && !methodPolicy.isIdempotent(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := methodPolicy.MinBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= methodPolicy.MaxAttempts || !methodPolicy.isRetryable(err) {
+				return err
+			}
+
+			// jitter within the upper half of the backoff so that many clients failing at
+			// once do not all retry at once.
+			wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)) //nolint:gosec
+			if !utils.SelectContextOrWait(ctx, wait) {
+				// the last error says more about what went wrong than the context does
+				return err
+			}
+			if backoff *= 2; backoff > methodPolicy.MaxBackoff {
+				backoff = methodPolicy.MaxBackoff
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestUnaryClientRetryInterceptor(t *testing.T) {
+	const echoMethod = "/proto.rpc.examples.echo.v1.EchoService/Echo"
+	const echoService = "/proto.rpc.examples.echo.v1.EchoService/"
+
+	invokeWith := func(policy RetryPolicy, method string, errs ...error) (int, error) {
+		var attempts int
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			attempts++
+			if attempts > len(errs) {
+				return nil
+			}
+			return errs[attempts-1]
+		}
+		err := UnaryClientRetryInterceptor(policy)(context.Background(), method, nil, nil, nil, invoker)
+		return attempts, err
+	}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	internal := status.Error(codes.Internal, "internal")
+	fast := RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("not idempotent", func(t *testing.T) {
+		attempts, err := invokeWith(fast, echoMethod, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, unavailable)
+	})
+
+	t.Run("idempotent method", func(t *testing.T) {
+		policy := fast
+		policy.IdempotentMethods = []string{echoMethod}
+		attempts, err := invokeWith(policy, echoMethod, unavailable, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 3)
+		test.That(t, err, test.ShouldBeNil)
+
+		// gives up after MaxAttempts
+		attempts, err = invokeWith(policy, echoMethod, unavailable, unavailable, unavailable, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 3)
+		test.That(t, err, test.ShouldEqual, unavailable)
+
+		// only retryable codes are retried
+		attempts, err = invokeWith(policy, echoMethod, internal)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, internal)
+
+		// other methods are left alone
+		attempts, err = invokeWith(policy, "/some.Service/Method", unavailable)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, unavailable)
+	})
+
+	t.Run("idempotent service", func(t *testing.T) {
+		policy := fast
+		policy.IdempotentMethods = []string{echoService}
+		attempts, err := invokeWith(policy, echoMethod, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 2)
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("all idempotent", func(t *testing.T) {
+		policy := fast
+		policy.AllIdempotent = true
+		policy.RetryableCodes = []codes.Code{codes.Internal}
+		attempts, err := invokeWith(policy, "/some.Service/Method", internal)
+		test.That(t, attempts, test.ShouldEqual, 2)
+		test.That(t, err, test.ShouldBeNil)
+		attempts, err = invokeWith(policy, "/some.Service/Method", unavailable)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, unavailable)
+	})
+
+	t.Run("method overrides", func(t *testing.T) {
+		policy := fast
+		policy.AllIdempotent = true
+		policy.MethodPolicies = map[string]RetryPolicy{
+			echoService: {MaxAttempts: 5, MinBackoff: time.Millisecond},
+			echoMethod:  {MaxAttempts: 1},
+		}
+		attempts, err := invokeWith(policy, echoMethod, unavailable, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, unavailable)
+
+		attempts, err = invokeWith(policy, echoService+"EchoMultiple", unavailable, unavailable, unavailable, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 5)
+		test.That(t, err, test.ShouldBeNil)
+
+		// an override can mark a method idempotent
+		policy = fast
+		policy.MethodPolicies = map[string]RetryPolicy{
+			echoMethod: {AllIdempotent: true, MinBackoff: time.Millisecond},
+		}
+		attempts, err = invokeWith(policy, echoMethod, unavailable)
+		test.That(t, attempts, test.ShouldEqual, 2)
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		policy := RetryPolicy{AllIdempotent: true, MinBackoff: time.Hour}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		var attempts int
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			attempts++
+			return unavailable
+		}
+		err := UnaryClientRetryInterceptor(policy)(ctx, echoMethod, nil, nil, nil, invoker)
+		test.That(t, attempts, test.ShouldEqual, 1)
+		test.That(t, err, test.ShouldEqual, unavailable)
+	})
+}
+
+// flakyEchoServer fails a given number of echoes before succeeding.
+type flakyEchoServer struct {
+	echoserver.Server
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (srv *flakyEchoServer) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	srv.mu.Lock()
+	srv.calls++
+	fail := srv.calls <= srv.failures
+	srv.mu.Unlock()
+	if fail {
+		return nil, status.Error(codes.Unavailable, "not yet")
+	}
+	return srv.Server.Echo(ctx, req)
+}
+
+func TestDialWithRetryPolicy(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	echoServer := &flakyEchoServer{}
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		echoServer,
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	policy := RetryPolicy{
+		MinBackoff:        time.Millisecond,
+		IdempotentMethods: []string{"/proto.rpc.examples.echo.v1.EchoService/Echo"},
+	}
+	for _, transport := range []string{"grpc", "webrtc"} {
+		t.Run(transport, func(t *testing.T) {
+			var conn ClientConn
+			var err error
+			if transport == "grpc" {
+				conn, err = DialDirectGRPC(context.Background(), httpListener.Addr().String(), logger,
+					WithInsecure(),
+					WithRetryPolicy(policy),
+				)
+			} else {
+				testutils.SkipUnlessInternet(t)
+				conn, err = DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+					WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+					WithRetryPolicy(policy),
+				)
+			}
+			test.That(t, err, test.ShouldBeNil)
+			client := pb.NewEchoServiceClient(conn)
+
+			echoServer.mu.Lock()
+			echoServer.calls = 0
+			echoServer.failures = 2
+			echoServer.mu.Unlock()
+			resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+			echoServer.mu.Lock()
+			test.That(t, echoServer.calls, test.ShouldEqual, 3)
+			echoServer.calls = 0
+			echoServer.failures = 3
+			echoServer.mu.Unlock()
+			_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+			test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+			echoServer.mu.Lock()
+			test.That(t, echoServer.calls, test.ShouldEqual, 3)
+			echoServer.mu.Unlock()
+
+			test.That(t, conn.Close(), test.ShouldBeNil)
+		})
+	}
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
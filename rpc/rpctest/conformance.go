@@ -0,0 +1,252 @@
+package rpctest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// LargeMessageSize is the size of the messages sent by the large message cases. It is well
+// over what fits in a single WebRTC data channel message but under gRPC's default limit.
+const LargeMessageSize = 2 << 20
+
+// A ConnFactory returns a connection to a server serving ConformanceServiceDesc. It is called
+// once for every case and may return the same connection each time. The harness never closes
+// the connections it is given.
+type ConnFactory func(t *testing.T) grpc.ClientConnInterface
+
+// A conformanceCase checks one behavior of a connection.
+type conformanceCase struct {
+	name string
+	run  func(t *testing.T, conn grpc.ClientConnInterface)
+}
+
+var conformanceCases = []conformanceCase{
+	{"unary", testUnary},
+	{"unary with large message", testUnaryLargeMessage},
+	{"unary with error details", testUnaryErrorDetails},
+	{"unary deadline", testUnaryDeadline},
+	{"unary cancellation", testUnaryCancellation},
+	{"unimplemented", testUnimplemented},
+	{"client stream", testClientStream},
+	{"server stream", testServerStream},
+	{"bidi stream", testBidiStream},
+	{"bidi stream with large messages", testBidiStreamLargeMessages},
+	{"stream with error details", testStreamErrorDetails},
+	{"stream deadline", testStreamDeadline},
+	{"stream cancellation", testStreamCancellation},
+}
+
+// RunConformanceTests runs the standard set of cases against connections made by newConn:
+// unary, client streaming, server streaming, and bidirectional streaming calls, with
+// metadata, trailers, errors with details, large messages, deadlines, and cancellation.
+func RunConformanceTests(t *testing.T, newConn ConnFactory) {
+	t.Helper()
+	for _, tc := range conformanceCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newConn(t))
+		})
+	}
+}
+
+func echoContext(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, echoMetadataPrefix+"key", "value")
+}
+
+func behaviorContext(ctx context.Context, behavior string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, behaviorMetadataKey, behavior)
+}
+
+func expectEchoedMetadata(tb testing.TB, md metadata.MD) {
+	tb.Helper()
+	test.That(tb, md.Get(echoMetadataPrefix+"key"), test.ShouldResemble, []string{"value"})
+}
+
+func expectFailure(tb testing.TB, err error) {
+	tb.Helper()
+	expected := failureStatus()
+	actual, ok := status.FromError(err)
+	test.That(tb, ok, test.ShouldBeTrue)
+	test.That(tb, actual.Code(), test.ShouldEqual, expected.Code())
+	test.That(tb, actual.Message(), test.ShouldEqual, expected.Message())
+	test.That(tb, actual.Details(), test.ShouldHaveLength, 1)
+	details, ok := actual.Details()[0].(proto.Message)
+	test.That(tb, ok, test.ShouldBeTrue)
+	test.That(tb, proto.Equal(details, failureDetails()), test.ShouldBeTrue)
+}
+
+func largeMessage() []byte {
+	return bytes.Repeat([]byte{'a', 'b', 'c', 'd'}, LargeMessageSize/4)
+}
+
+func testUnary(t *testing.T, conn grpc.ClientConnInterface) {
+	var header, trailer metadata.MD
+	resp := new(wrapperspb.BytesValue)
+	err := conn.Invoke(
+		echoContext(context.Background()),
+		fullMethod("Unary"),
+		wrapperspb.Bytes([]byte("hello")),
+		resp,
+		grpc.Header(&header),
+		grpc.Trailer(&trailer),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Value, test.ShouldResemble, []byte("hello"))
+	expectEchoedMetadata(t, header)
+	expectEchoedMetadata(t, trailer)
+}
+
+func testUnaryLargeMessage(t *testing.T, conn grpc.ClientConnInterface) {
+	msg := largeMessage()
+	resp := new(wrapperspb.BytesValue)
+	err := conn.Invoke(context.Background(), fullMethod("Unary"), wrapperspb.Bytes(msg), resp)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, bytes.Equal(resp.Value, msg), test.ShouldBeTrue)
+}
+
+func testUnaryErrorDetails(t *testing.T, conn grpc.ClientConnInterface) {
+	var trailer metadata.MD
+	err := conn.Invoke(
+		behaviorContext(echoContext(context.Background()), behaviorFail),
+		fullMethod("Unary"),
+		wrapperspb.Bytes(nil),
+		new(wrapperspb.BytesValue),
+		grpc.Trailer(&trailer),
+	)
+	expectFailure(t, err)
+	expectEchoedMetadata(t, trailer)
+}
+
+func testUnaryDeadline(t *testing.T, conn grpc.ClientConnInterface) {
+	ctx, cancel := context.WithTimeout(behaviorContext(context.Background(), behaviorBlock), 200*time.Millisecond)
+	defer cancel()
+	err := conn.Invoke(ctx, fullMethod("Unary"), wrapperspb.Bytes(nil), new(wrapperspb.BytesValue))
+	test.That(t, status.Code(err), test.ShouldEqual, codes.DeadlineExceeded)
+}
+
+func testUnaryCancellation(t *testing.T, conn grpc.ClientConnInterface) {
+	ctx, cancel := context.WithCancel(behaviorContext(context.Background(), behaviorBlock))
+	defer cancel()
+	timer := time.AfterFunc(200*time.Millisecond, cancel)
+	defer timer.Stop()
+	err := conn.Invoke(ctx, fullMethod("Unary"), wrapperspb.Bytes(nil), new(wrapperspb.BytesValue))
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+}
+
+func testUnimplemented(t *testing.T, conn grpc.ClientConnInterface) {
+	err := conn.Invoke(context.Background(), fullMethod("Unknown"), wrapperspb.Bytes(nil), new(wrapperspb.BytesValue))
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unimplemented)
+}
+
+func testClientStream(t *testing.T, conn grpc.ClientConnInterface) {
+	stream, err := conn.NewStream(echoContext(context.Background()), streamDesc("ClientStream"), fullMethod("ClientStream"))
+	test.That(t, err, test.ShouldBeNil)
+	for _, part := range []string{"one", "two", "three"} {
+		test.That(t, stream.SendMsg(wrapperspb.Bytes([]byte(part))), test.ShouldBeNil)
+	}
+	test.That(t, stream.CloseSend(), test.ShouldBeNil)
+
+	resp := new(wrapperspb.BytesValue)
+	test.That(t, stream.RecvMsg(resp), test.ShouldBeNil)
+	test.That(t, resp.Value, test.ShouldResemble, []byte("onetwothree"))
+	test.That(t, stream.RecvMsg(resp), test.ShouldEqual, io.EOF)
+
+	header, err := stream.Header()
+	test.That(t, err, test.ShouldBeNil)
+	expectEchoedMetadata(t, header)
+	expectEchoedMetadata(t, stream.Trailer())
+}
+
+func testServerStream(t *testing.T, conn grpc.ClientConnInterface) {
+	stream, err := conn.NewStream(echoContext(context.Background()), streamDesc("ServerStream"), fullMethod("ServerStream"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream.SendMsg(wrapperspb.Bytes([]byte("hello"))), test.ShouldBeNil)
+	test.That(t, stream.CloseSend(), test.ShouldBeNil)
+
+	for i := 0; i < serverStreamResponses; i++ {
+		resp := new(wrapperspb.BytesValue)
+		test.That(t, stream.RecvMsg(resp), test.ShouldBeNil)
+		test.That(t, resp.Value, test.ShouldResemble, []byte("hello"))
+	}
+	test.That(t, stream.RecvMsg(new(wrapperspb.BytesValue)), test.ShouldEqual, io.EOF)
+
+	header, err := stream.Header()
+	test.That(t, err, test.ShouldBeNil)
+	expectEchoedMetadata(t, header)
+	expectEchoedMetadata(t, stream.Trailer())
+}
+
+func testBidiStream(t *testing.T, conn grpc.ClientConnInterface) {
+	stream, err := conn.NewStream(echoContext(context.Background()), streamDesc("BidiStream"), fullMethod("BidiStream"))
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, part := range []string{"one", "two", "three"} {
+		test.That(t, stream.SendMsg(wrapperspb.Bytes([]byte(part))), test.ShouldBeNil)
+		resp := new(wrapperspb.BytesValue)
+		test.That(t, stream.RecvMsg(resp), test.ShouldBeNil)
+		test.That(t, resp.Value, test.ShouldResemble, []byte(part))
+	}
+	// headers are sent along with the first response
+	header, err := stream.Header()
+	test.That(t, err, test.ShouldBeNil)
+	expectEchoedMetadata(t, header)
+	test.That(t, stream.CloseSend(), test.ShouldBeNil)
+	test.That(t, stream.RecvMsg(new(wrapperspb.BytesValue)), test.ShouldEqual, io.EOF)
+	expectEchoedMetadata(t, stream.Trailer())
+}
+
+func testBidiStreamLargeMessages(t *testing.T, conn grpc.ClientConnInterface) {
+	stream, err := conn.NewStream(context.Background(), streamDesc("BidiStream"), fullMethod("BidiStream"))
+	test.That(t, err, test.ShouldBeNil)
+
+	msg := largeMessage()
+	for i := 0; i < 3; i++ {
+		test.That(t, stream.SendMsg(wrapperspb.Bytes(msg)), test.ShouldBeNil)
+		resp := new(wrapperspb.BytesValue)
+		test.That(t, stream.RecvMsg(resp), test.ShouldBeNil)
+		test.That(t, bytes.Equal(resp.Value, msg), test.ShouldBeTrue)
+	}
+	test.That(t, stream.CloseSend(), test.ShouldBeNil)
+	test.That(t, stream.RecvMsg(new(wrapperspb.BytesValue)), test.ShouldEqual, io.EOF)
+}
+
+func testStreamErrorDetails(t *testing.T, conn grpc.ClientConnInterface) {
+	stream, err := conn.NewStream(
+		behaviorContext(echoContext(context.Background()), behaviorFail),
+		streamDesc("BidiStream"),
+		fullMethod("BidiStream"),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	expectFailure(t, stream.RecvMsg(new(wrapperspb.BytesValue)))
+	expectEchoedMetadata(t, stream.Trailer())
+}
+
+func testStreamDeadline(t *testing.T, conn grpc.ClientConnInterface) {
+	ctx, cancel := context.WithTimeout(behaviorContext(context.Background(), behaviorBlock), 200*time.Millisecond)
+	defer cancel()
+	stream, err := conn.NewStream(ctx, streamDesc("BidiStream"), fullMethod("BidiStream"))
+	test.That(t, err, test.ShouldBeNil)
+	err = stream.RecvMsg(new(wrapperspb.BytesValue))
+	test.That(t, status.Code(err), test.ShouldEqual, codes.DeadlineExceeded)
+}
+
+func testStreamCancellation(t *testing.T, conn grpc.ClientConnInterface) {
+	ctx, cancel := context.WithCancel(behaviorContext(context.Background(), behaviorBlock))
+	defer cancel()
+	stream, err := conn.NewStream(ctx, streamDesc("BidiStream"), fullMethod("BidiStream"))
+	test.That(t, err, test.ShouldBeNil)
+	cancel()
+	err = stream.RecvMsg(new(wrapperspb.BytesValue))
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+}
@@ -0,0 +1,245 @@
+// Package rpctest provides a conformance test harness for gRPC client connections, such as
+// those returned by rpc.Dial, so that every transport and interceptor behaves like gRPC does.
+//
+// The server behind the connections under test must serve ConformanceServiceDesc with the
+// implementation from NewConformanceServer. RunConformanceTests then runs a standard set of
+// cases against it.
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	serviceName = "proto.rpc.rpctest.v1.ConformanceService"
+
+	// echoMetadataPrefix marks request metadata that is sent back as both header and trailer.
+	echoMetadataPrefix = "rpctest-echo-"
+	// behaviorMetadataKey asks the server to misbehave in one of the ways below.
+	behaviorMetadataKey = "rpctest-behavior"
+	behaviorFail        = "fail"
+	behaviorBlock       = "block"
+
+	// serverStreamResponses is how many times ServerStream responds with its request.
+	serverStreamResponses = 3
+)
+
+// failureStatus is what calls asked to fail fail with.
+func failureStatus() *status.Status {
+	st, err := status.New(codes.FailedPrecondition, "asked to fail").WithDetails(failureDetails())
+	if err != nil {
+		panic(err)
+	}
+	return st
+}
+
+func failureDetails() *wrapperspb.StringValue {
+	return wrapperspb.String("some details")
+}
+
+// ConformanceServiceServer is the server API for the service the conformance tests call.
+type ConformanceServiceServer interface {
+	// Unary responds with its request.
+	Unary(context.Context, *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error)
+	// ClientStream responds with all of its requests joined together.
+	ClientStream(grpc.ServerStream) error
+	// ServerStream responds with its request a few times.
+	ServerStream(*wrapperspb.BytesValue, grpc.ServerStream) error
+	// BidiStream responds with each of its requests.
+	BidiStream(grpc.ServerStream) error
+}
+
+// NewConformanceServer returns the implementation of the service the conformance tests call.
+func NewConformanceServer() ConformanceServiceServer {
+	return conformanceServer{}
+}
+
+// ConformanceServiceDesc describes the service the conformance tests call.
+var ConformanceServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*ConformanceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Unary",
+			Handler:    unaryHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ClientStream",
+			Handler:       clientStreamHandler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ServerStream",
+			Handler:       serverStreamHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BidiStream",
+			Handler:       bidiStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+func fullMethod(name string) string {
+	return "/" + serviceName + "/" + name
+}
+
+func streamDesc(name string) *grpc.StreamDesc {
+	for i := range ConformanceServiceDesc.Streams {
+		if ConformanceServiceDesc.Streams[i].StreamName == name {
+			return &ConformanceServiceDesc.Streams[i]
+		}
+	}
+	panic(name)
+}
+
+func unaryHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(wrapperspb.BytesValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConformanceServiceServer).Unary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: fullMethod("Unary"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConformanceServiceServer).Unary(ctx, req.(*wrapperspb.BytesValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func clientStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConformanceServiceServer).ClientStream(stream)
+}
+
+func serverStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(wrapperspb.BytesValue)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ConformanceServiceServer).ServerStream(in, stream)
+}
+
+func bidiStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConformanceServiceServer).BidiStream(stream)
+}
+
+type conformanceServer struct{}
+
+// behave echoes metadata and misbehaves as asked to by the metadata of a call.
+func behave(ctx context.Context, setHeader, setTrailer func(metadata.MD) error) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	echoed := metadata.MD{}
+	for key, values := range md {
+		if strings.HasPrefix(key, echoMetadataPrefix) {
+			echoed[key] = values
+		}
+	}
+	if len(echoed) != 0 {
+		if err := setHeader(echoed); err != nil {
+			return err
+		}
+		if err := setTrailer(echoed); err != nil {
+			return err
+		}
+	}
+
+	if behavior := md.Get(behaviorMetadataKey); len(behavior) != 0 {
+		switch behavior[0] {
+		case behaviorFail:
+			return failureStatus().Err()
+		case behaviorBlock:
+			<-ctx.Done()
+			return ctx.Err()
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown behavior %q", behavior[0])
+		}
+	}
+	return nil
+}
+
+func behaveOnStream(stream grpc.ServerStream) error {
+	return behave(stream.Context(), stream.SetHeader, func(md metadata.MD) error {
+		stream.SetTrailer(md)
+		return nil
+	})
+}
+
+func (srv conformanceServer) Unary(ctx context.Context, req *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := behave(
+		ctx,
+		func(md metadata.MD) error { return grpc.SetHeader(ctx, md) },
+		func(md metadata.MD) error { return grpc.SetTrailer(ctx, md) },
+	); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (srv conformanceServer) ClientStream(stream grpc.ServerStream) error {
+	if err := behaveOnStream(stream); err != nil {
+		return err
+	}
+	var joined []byte
+	for {
+		req := new(wrapperspb.BytesValue)
+		if err := stream.RecvMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return stream.SendMsg(wrapperspb.Bytes(joined))
+			}
+			return err
+		}
+		joined = append(joined, req.Value...)
+	}
+}
+
+func (srv conformanceServer) ServerStream(req *wrapperspb.BytesValue, stream grpc.ServerStream) error {
+	if err := behaveOnStream(stream); err != nil {
+		return err
+	}
+	for i := 0; i < serverStreamResponses; i++ {
+		if err := stream.SendMsg(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv conformanceServer) BidiStream(stream grpc.ServerStream) error {
+	if err := behaveOnStream(stream); err != nil {
+		return err
+	}
+	for {
+		req := new(wrapperspb.BytesValue)
+		if err := stream.RecvMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := stream.SendMsg(req); err != nil {
+			return err
+		}
+	}
+}
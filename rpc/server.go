@@ -15,7 +15,6 @@ import (
 	"time"
 
 	"github.com/edaniels/golog"
-	"github.com/edaniels/zeroconf"
 	"github.com/google/uuid"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -117,7 +116,7 @@ type simpleServer struct {
 	serviceServerCancels    []func()
 	signalingCallQueue      WebRTCCallQueue
 	signalingServer         *WebRTCSignalingServer
-	mdnsServers             []*zeroconf.Server
+	advertisements          []func()
 	// exempt methods do not perform any auth
 	exemptMethods map[string]bool
 	// public methods attempt, but do not require, authentication
@@ -132,6 +131,7 @@ type simpleServer struct {
 	internalUUID         string
 	internalCreds        Credentials
 	tlsAuthHandler       func(ctx context.Context, entities ...string) error
+	peerCredsAuthHandler PeerCredentialsAuthHandler
 	authRSAPrivKey       *rsa.PrivateKey
 	authRSAPrivKeyKID    string
 	authHandlersForCreds map[CredentialsType]credAuthHandlers
@@ -145,7 +145,10 @@ type simpleServer struct {
 	authIssuer string
 }
 
-var errMixedUnauthAndAuth = errors.New("cannot use unauthenticated and auth handlers at same time")
+var (
+	errMixedUnauthAndAuth = errors.New("cannot use unauthenticated and auth handlers at same time")
+	errUnixSocketWithTLS  = errors.New("cannot use an internal TLS config with an internal unix socket")
+)
 
 // NewServer returns a new server ready to be started that
 // will listen on localhost on a random port unless TLS is turned
@@ -158,26 +161,41 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 			return nil, err
 		}
 	}
-	if sOpts.unauthenticated && (len(sOpts.authHandlersForCreds) != 0 || sOpts.tlsAuthHandler != nil) {
+	if sOpts.unauthenticated && (len(sOpts.authHandlersForCreds) != 0 ||
+		sOpts.tlsAuthHandler != nil ||
+		sOpts.peerCredentialsAuthHandler != nil) {
 		return nil, errMixedUnauthAndAuth
 	}
+	if sOpts.unixSocketPath != "" && sOpts.tlsConfig != nil {
+		return nil, errUnixSocketWithTLS
+	}
 
-	grpcBindAddr := sOpts.bindAddress
-	if grpcBindAddr == "" {
-		if sOpts.tlsConfig == nil || sOpts.unauthenticated {
-			grpcBindAddr = "localhost:0"
-		} else {
-			grpcBindAddr = ":0"
+	var serverOpts []grpc.ServerOption
+
+	var grpcListener net.Listener
+	var err error
+	if sOpts.unixSocketPath != "" {
+		grpcListener, err = listenUnixSocket(sOpts.unixSocketPath)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(peerCredentialsTransportCredentials{}))
+	} else {
+		grpcBindAddr := sOpts.bindAddress
+		if grpcBindAddr == "" {
+			if sOpts.tlsConfig == nil || sOpts.unauthenticated {
+				grpcBindAddr = "localhost:0"
+			} else {
+				grpcBindAddr = ":0"
+			}
 		}
-	}
 
-	grpcListener, err := net.Listen("tcp", grpcBindAddr)
-	if err != nil {
-		return nil, err
+		grpcListener, err = net.Listen("tcp", grpcBindAddr)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var serverOpts []grpc.ServerOption
-
 	var firstSeenTLSCert *tls.Certificate
 	if sOpts.tlsConfig != nil {
 		if len(sOpts.tlsConfig.Certificates) == 0 {
@@ -258,6 +276,7 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 			Payload: base64.StdEncoding.EncodeToString(internalCredsKey),
 		},
 		tlsAuthHandler:       sOpts.tlsAuthHandler,
+		peerCredsAuthHandler: sOpts.peerCredentialsAuthHandler,
 		authHandlersForCreds: sOpts.authHandlersForCreds,
 		authToHandler:        sOpts.authToHandler,
 		authAudience:         sOpts.authAudience,
@@ -346,6 +365,15 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 		serverOpts = append(serverOpts, grpc.StatsHandler(sOpts.statsHandler))
 	}
 
+	maxRecvMsgSize := MaxMessageSize
+	if sOpts.maxRecvMsgSize > 0 {
+		maxRecvMsgSize = sOpts.maxRecvMsgSize
+	}
+	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(maxRecvMsgSize))
+	if sOpts.maxSendMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxSendMsgSize(sOpts.maxSendMsgSize))
+	}
+
 	grpcServer := grpc.NewServer(
 		serverOpts...,
 	)
@@ -397,7 +425,7 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 	var mDNSAddress *net.TCPAddr
 	if sOpts.listenerAddress != nil {
 		mDNSAddress = sOpts.listenerAddress
-	} else {
+	} else if sOpts.unixSocketPath == "" {
 		var ok bool
 		mDNSAddress, ok = grpcListener.Addr().(*net.TCPAddr)
 		if !ok {
@@ -411,11 +439,16 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 	}
 	instanceNames := sOpts.instanceNames
 	if len(instanceNames) == 0 {
-		instanceName, err := InstanceNameFromAddress(mDNSAddress.String())
-		if err != nil {
-			return nil, err
+		if mDNSAddress == nil {
+			// only reachable by a unix socket path which does not make for a name
+			instanceNames = []string{uuid.NewString()}
+		} else {
+			instanceName, err := InstanceNameFromAddress(mDNSAddress.String())
+			if err != nil {
+				return nil, err
+			}
+			instanceNames = []string{instanceName}
 		}
-		instanceNames = []string{instanceName}
 	}
 	server.instanceNames = instanceNames
 
@@ -429,66 +462,40 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 		server.authIssuer = server.authAudience[0]
 	}
 
-	if !sOpts.disableMDNS {
-		if mDNSAddress.IP.IsLoopback() {
-			hostname, err := os.Hostname()
-			if err != nil {
-				return nil, err
-			}
-			ifcs, err := net.Interfaces()
-			if err != nil {
-				return nil, err
-			}
-			var loopbackIfaces []net.Interface
-			for _, ifc := range ifcs {
-				if (ifc.Flags&net.FlagUp) == 0 || (ifc.Flags&net.FlagLoopback) == 0 {
-					continue
-				}
-				loopbackIfaces = append(loopbackIfaces, ifc)
-				break
-			}
-			for _, host := range instanceNames {
-				hosts := []string{host, strings.ReplaceAll(host, ".", "-")}
-				for _, host := range hosts {
-					mdnsServer, err := zeroconf.RegisterProxy(
-						host,
-						"_rpc._tcp",
-						"local.",
-						mDNSAddress.Port,
-						hostname,
-						[]string{"127.0.0.1"},
-						supportedServices,
-						loopbackIfaces,
-						logger,
-					)
-					if err != nil {
-						logger.Warnw(mDNSerr, "error", err)
-						sOpts.disableMDNS = true
-						break
-					}
-					server.mdnsServers = append(server.mdnsServers, mdnsServer)
+	if !sOpts.disableMDNS && mDNSAddress == nil {
+		logger.Debug("not advertising over mDNS since there is only a unix socket to connect to")
+	} else if !sOpts.disableMDNS {
+		advertiseHost, err := advertiseHostForIP(mDNSAddress.IP)
+		if err != nil {
+			return nil, err
+		}
+		advertiser := sOpts.advertiser
+		if advertiser == nil {
+			mdnsAdvertiser := &mdnsAdvertiser{logger: logger}
+			// look up what loopback hosts are advertised with now so that failing to do so
+			// fails here rather than only disabling mDNS
+			if ip := net.ParseIP(advertiseHost); ip != nil && ip.IsLoopback() {
+				if err := mdnsAdvertiser.lookupLoopback(); err != nil {
+					return nil, err
 				}
 			}
-		} else {
-			for _, host := range instanceNames {
-				hosts := []string{host, strings.ReplaceAll(host, ".", "-")}
-				for _, host := range hosts {
-					mdnsServer, err := zeroconf.RegisterDynamic(
-						host,
-						"_rpc._tcp",
-						"local.",
-						mDNSAddress.Port,
-						supportedServices,
-						nil,
-						logger,
-					)
-					if err != nil {
-						logger.Warnw(mDNSerr, "error", err)
-						sOpts.disableMDNS = true
-						break
-					}
-					server.mdnsServers = append(server.mdnsServers, mdnsServer)
+			advertiser = mdnsAdvertiser
+		}
+		for _, host := range instanceNames {
+			hosts := []string{host, strings.ReplaceAll(host, ".", "-")}
+			for _, host := range hosts {
+				stop, err := advertiser.Advertise(ServiceRecord{
+					InstanceName: host,
+					Host:         advertiseHost,
+					Port:         mDNSAddress.Port,
+					Services:     supportedServices,
+				})
+				if err != nil {
+					logger.Warnw(mDNSerr, "error", err)
+					sOpts.disableMDNS = true
+					break
 				}
+				server.advertisements = append(server.advertisements, stop)
 			}
 		}
 	}
@@ -534,6 +541,9 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 		if sOpts.webrtcOpts.OnPeerRemoved != nil {
 			server.webrtcServer.onPeerRemoved = sOpts.webrtcOpts.OnPeerRemoved
 		}
+		server.webrtcServer.keepalive = sOpts.webrtcOpts.Keepalive
+		server.webrtcServer.maxRecvMsgSize = sOpts.maxRecvMsgSize
+		server.webrtcServer.maxSendMsgSize = sOpts.maxSendMsgSize
 		reflection.Register(server.webrtcServer)
 
 		config := DefaultWebRTCConfiguration
@@ -562,6 +572,7 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 				server.webrtcServer,
 				sOpts.webrtcOpts.ExternalSignalingDialOpts,
 				config,
+				sOpts.webrtcOpts.OfferDeadline,
 				logger.Named("external_signaler"),
 			))
 		} else {
@@ -572,7 +583,14 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 			logger.Debug("will run internal signaling service")
 			signalingCallQueue := NewMemoryWebRTCCallQueue(logger)
 			server.signalingCallQueue = signalingCallQueue
-			server.signalingServer = NewWebRTCSignalingServer(signalingCallQueue, nil, logger, internalSignalingHosts...)
+			server.signalingServer = NewWebRTCSignalingServerWithOptions(
+				signalingCallQueue,
+				nil,
+				logger,
+				WithSignalingForHosts(internalSignalingHosts...),
+				WithSignalingOfferDeadline(sOpts.webrtcOpts.OfferDeadline),
+				WithSignalingAuthorizer(sOpts.webrtcOpts.InternalSignalingAuthorizer),
+			)
 			if err := server.RegisterServiceServer(
 				context.Background(),
 				&webrtcpb.SignalingService_ServiceDesc,
@@ -581,8 +599,18 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 			); err != nil {
 				return nil, err
 			}
+			if sOpts.webrtcOpts.EnableInternalSignalingAdmin {
+				if err := server.RegisterServiceServer(
+					context.Background(),
+					&webrtcpb.SignalingAdminService_ServiceDesc,
+					NewWebRTCSignalingAdminServer(signalingCallQueue),
+					webrtcpb.RegisterSignalingAdminServiceHandlerFromEndpoint,
+				); err != nil {
+					return nil, err
+				}
+			}
 
-			address := grpcListener.Addr().String()
+			address := server.internalDialAddress()
 			logger.Debugw(
 				"will run internal signaling answerer",
 				"signaling_address", address,
@@ -605,6 +633,7 @@ func NewServer(logger golog.Logger, opts ...ServerOption) (Server, error) {
 				server.webrtcServer,
 				answererDialOpts,
 				config,
+				sOpts.webrtcOpts.OfferDeadline,
 				logger.Named("internal_signaler"),
 			))
 		}
@@ -685,6 +714,14 @@ func (ss *simpleServer) InternalAddr() net.Addr {
 	return ss.grpcListener.Addr()
 }
 
+// internalDialAddress returns the address to dial the gRPC listener at.
+func (ss *simpleServer) internalDialAddress() string {
+	if ss.grpcListener.Addr().Network() == "unix" {
+		return "unix://" + ss.grpcListener.Addr().String()
+	}
+	return ss.grpcListener.Addr().String()
+}
+
 func (ss *simpleServer) Start() error {
 	ss.mu.Lock()
 	if ss.stopped {
@@ -728,6 +765,9 @@ func (ss *simpleServer) serveTLS(listener net.Listener, certFile, keyFile string
 	}
 	ss.httpServer.Addr = listener.Addr().String()
 	ss.httpServer.Handler = ss
+	if ss.peerCredsAuthHandler != nil {
+		ss.httpServer.ConnContext = peerCredentialsConnContext
+	}
 	secure := true
 	if certFile == "" && keyFile == "" {
 		secure = false
@@ -800,8 +840,8 @@ func (ss *simpleServer) Stop() error {
 		ss.webrtcServer.Stop()
 		ss.logger.Debug("WebRTC server stopped")
 	}
-	for _, mdnsServer := range ss.mdnsServers {
-		mdnsServer.Shutdown()
+	for _, stopAdvertising := range ss.advertisements {
+		stopAdvertising()
 	}
 	ss.logger.Debug("shutting down HTTP server")
 	err = multierr.Combine(err, ss.httpServer.Shutdown(context.Background()))
@@ -835,7 +875,7 @@ func (ss *simpleServer) RegisterServiceServer(
 		ss.webrtcServer.RegisterService(svcDesc, svcServer)
 	}
 	if len(svcHandlers) != 0 {
-		addr := ss.grpcListener.Addr().String()
+		addr := ss.internalDialAddress()
 		opts := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMessageSize))}
 		if ss.tlsConfig == nil {
 			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -885,6 +925,17 @@ func streamServerCodeInterceptor() grpc.StreamServerInterceptor {
 	}
 }
 
+// listenUnixSocket listens on a unix socket at the given path, removing any socket left
+// behind by a previous process. Anything else at the path is left alone.
+func listenUnixSocket(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen("unix", path)
+}
+
 // InstanceNameFromAddress returns a suitable instance name given an address.
 // If it's empty or an IP address, a new UUID is returned.
 func InstanceNameFromAddress(addr string) (string, error) {
@@ -284,6 +284,16 @@ func (ss *simpleServer) tryAuth(ctx context.Context) (context.Context, error) {
 func (ss *simpleServer) ensureAuthed(ctx context.Context) (context.Context, error) {
 	tokenString, err := tokenFromContext(ctx)
 	if err != nil {
+		// check unix socket peer
+		if ss.peerCredsAuthHandler != nil {
+			if creds, ok := peerCredentialsFromContext(ctx); ok {
+				if credsErr := ss.peerCredsAuthHandler(ctx, creds); credsErr != nil {
+					return nil, status.Errorf(codes.Unauthenticated, "unauthenticated: %s", credsErr)
+				}
+				return ContextWithAuthEntity(ctx, EntityInfo{Entity: creds.entity(), Data: creds}), nil
+			}
+		}
+
 		// check TLS state
 		if ss.tlsAuthHandler == nil {
 			return nil, err
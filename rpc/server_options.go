@@ -5,6 +5,7 @@ import (
 	"crypto/rsa"
 	"crypto/tls"
 	"net"
+	"time"
 
 	"github.com/pion/webrtc/v3"
 	"github.com/pkg/errors"
@@ -17,6 +18,7 @@ import (
 // serverOptions change the runtime behavior of the server.
 type serverOptions struct {
 	bindAddress       string
+	unixSocketPath    string
 	listenerAddress   *net.TCPAddr
 	tlsConfig         *tls.Config
 	webrtcOpts        WebRTCServerOptions
@@ -43,8 +45,9 @@ type serverOptions struct {
 	// It will output much more logs.
 	debug bool
 
-	tlsAuthHandler       func(ctx context.Context, entities ...string) error
-	authHandlersForCreds map[CredentialsType]credAuthHandlers
+	tlsAuthHandler             func(ctx context.Context, entities ...string) error
+	peerCredentialsAuthHandler PeerCredentialsAuthHandler
+	authHandlersForCreds       map[CredentialsType]credAuthHandlers
 
 	// authAudience is the JWT audience (aud) that will be used/expected
 	// for our service. When unset, it will be debug logged that
@@ -58,11 +61,18 @@ type serverOptions struct {
 
 	authToHandler AuthenticateToHandler
 	disableMDNS   bool
+	// advertiser advertises instance names; mDNS is used if unset.
+	advertiser Advertiser
 
 	// stats monitoring on the connections.
 	statsHandler stats.Handler
 
 	unknownStreamDesc *grpc.StreamDesc
+
+	// maxRecvMsgSize and maxSendMsgSize, if set, are the largest messages calls may receive
+	// and send.
+	maxRecvMsgSize int
+	maxSendMsgSize int
 }
 
 // WebRTCServerOptions control how WebRTC is utilized in a server.
@@ -87,6 +97,11 @@ type WebRTCServerOptions struct {
 	// if ExternalSignalingAddress is unset.
 	EnableInternalSignaling bool
 
+	// EnableInternalSignalingAdmin specifies whether the internal signaling service
+	// should be accompanied by a SignalingAdminService reporting on its call queue.
+	// Like every other service, it requires authentication unless disabled.
+	EnableInternalSignalingAdmin bool
+
 	// ExternalSignalingHosts specifies what hosts are being listened for when answering
 	// externally.
 	ExternalSignalingHosts []string
@@ -95,6 +110,11 @@ type WebRTCServerOptions struct {
 	// internally.
 	InternalSignalingHosts []string
 
+	// InternalSignalingAuthorizer, if set, decides which hosts an authenticated entity may
+	// call and answer for through the internal signaling service, including the server's own
+	// answerer. Unauthenticated signaling requests are rejected once it is set.
+	InternalSignalingAuthorizer SignalingAuthorizer
+
 	// Config is the WebRTC specific configuration (i.e. ICE settings)
 	Config *webrtc.Configuration
 
@@ -103,6 +123,15 @@ type WebRTCServerOptions struct {
 
 	// OnPeerRemoved is called when an existing peer connection is removed.
 	OnPeerRemoved func(pc *webrtc.PeerConnection)
+
+	// Keepalive controls how peers that have gone away are noticed. Peers that go quiet
+	// are pinged regardless of PermitWithoutStream.
+	Keepalive WebRTCKeepaliveParameters
+
+	// OfferDeadline is how long calls have to be answered, both by the internal signaling
+	// service and by answerers when their signaling service does not say. It should match
+	// the DialWebRTCOptions.OfferDeadline of clients. Defaults to 10s.
+	OfferDeadline time.Duration
 }
 
 // A ServerOption changes the runtime behavior of the server.
@@ -138,6 +167,21 @@ func WithInternalBindAddress(address string) ServerOption {
 	})
 }
 
+// WithInternalUnixSocket returns a ServerOption which binds the gRPC listener to a
+// unix socket at the given path instead of to TCP. Any socket left over at the path is
+// removed first. The socket is not advertised over mDNS unless WithExternalListenerAddress
+// is also used. To serve the HTTP handler over a unix socket as well, pass a unix listener
+// to Serve.
+func WithInternalUnixSocket(path string) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) error {
+		if path == "" {
+			return errors.New("unix socket path must be non-empty")
+		}
+		o.unixSocketPath = path
+		return nil
+	})
+}
+
 // WithExternalListenerAddress returns a ServerOption which sets the listener address
 // if the server is going to be served via its handlers and not internally.
 // This is only helpful for mDNS broadcasting. If the server has TLS enabled
@@ -281,6 +325,19 @@ func WithTLSAuthHandler(entities []string) ServerOption {
 	})
 }
 
+// WithPeerCredentialsAuthHandler returns a ServerOption which authenticates callers
+// connected over a unix socket (see WithInternalUnixSocket) by the user and group of
+// their process in the event that no other authentication has been established via the
+// standard auth handler. The entity of an authenticated caller is "unix:<uid>:<gid>"
+// and its PeerCredentials are available as the data of ContextAuthEntity. Peer
+// credentials are only supported on Linux.
+func WithPeerCredentialsAuthHandler(handler PeerCredentialsAuthHandler) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) error {
+		o.peerCredentialsAuthHandler = handler
+		return nil
+	})
+}
+
 // WithAuthHandler returns a ServerOption which adds an auth handler associated
 // to the given credential type to use for authentication requests.
 func WithAuthHandler(forType CredentialsType, handler AuthHandler) ServerOption {
@@ -400,6 +457,16 @@ func WithDisableMulticastDNS() ServerOption {
 	})
 }
 
+// WithAdvertiser returns a ServerOption which sets how the server's instance names are
+// advertised, in place of mDNS. Advertising can still be turned off with
+// WithDisableMulticastDNS.
+func WithAdvertiser(advertiser Advertiser) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) error {
+		o.advertiser = advertiser
+		return nil
+	})
+}
+
 // WithUnknownServiceHandler returns a ServerOption that allows for adding a custom
 // unknown service handler. The provided method is a bidi-streaming RPC service
 // handler that will be invoked instead of returning the "unimplemented" gRPC
@@ -445,3 +512,30 @@ func WithPublicMethods(fullMethods []string) ServerOption {
 		return nil
 	})
 }
+
+// WithMaxRecvMsgSize returns a ServerOption which sets the largest message in bytes calls
+// may receive over both gRPC and WebRTC. Calls receiving larger ones fail with
+// ResourceExhausted. The default is MaxMessageSize.
+func WithMaxRecvMsgSize(size int) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) error {
+		if size <= 0 {
+			return errors.New("max receive message size must be positive")
+		}
+		o.maxRecvMsgSize = size
+		return nil
+	})
+}
+
+// WithMaxSendMsgSize returns a ServerOption which sets the largest message in bytes calls
+// may send over both gRPC and WebRTC. Calls sending larger ones fail with ResourceExhausted.
+// WebRTC clients may ask for a lower limit for their own calls. By default, there is no
+// limit besides that.
+func WithMaxSendMsgSize(size int) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) error {
+		if size <= 0 {
+			return errors.New("max send message size must be positive")
+		}
+		o.maxSendMsgSize = size
+		return nil
+	})
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerCredentials are the credentials of the process on the other end of a unix socket
+// as reported by the operating system (SO_PEERCRED on Linux).
+type PeerCredentials struct {
+	PID int
+	UID int
+	GID int
+}
+
+// entity is used as the auth entity of callers authenticated by their peer credentials.
+// The PID is left out since it is not a stable identity.
+func (creds PeerCredentials) entity() string {
+	return fmt.Sprintf("unix:%d:%d", creds.UID, creds.GID)
+}
+
+// A PeerCredentialsAuthHandler authenticates callers connected over a unix socket by
+// their peer credentials. It returns nil if the caller should be allowed in.
+type PeerCredentialsAuthHandler func(ctx context.Context, creds PeerCredentials) error
+
+// MakePeerUIDChecker returns a PeerCredentialsAuthHandler that only allows in callers
+// running as one of the given user IDs.
+func MakePeerUIDChecker(uids ...int) PeerCredentialsAuthHandler {
+	return func(ctx context.Context, creds PeerCredentials) error {
+		for _, uid := range uids {
+			if creds.UID == uid {
+				return nil
+			}
+		}
+		return errors.Errorf("uid %d not allowed", creds.UID)
+	}
+}
+
+var errPeerCredentialsUnsupported = errors.New("peer credentials are not supported on this platform")
+
+// peerCredentialsTransportCredentials reads the peer credentials of every connection
+// accepted over a unix socket. It does not secure the connection in any way.
+type peerCredentialsTransportCredentials struct{}
+
+// peerCredentialsAuthInfo is the AuthInfo of connections accepted with
+// peerCredentialsTransportCredentials.
+type peerCredentialsAuthInfo struct {
+	credentials.CommonAuthInfo
+	creds PeerCredentials
+	ok    bool
+}
+
+func (info peerCredentialsAuthInfo) AuthType() string {
+	return "unix"
+}
+
+func (peerCredentialsTransportCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("peer credentials are only for servers")
+}
+
+func (peerCredentialsTransportCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info := peerCredentialsAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+	}
+	// a connection we cannot get credentials for can still authenticate in other ways
+	if creds, err := readPeerCredentials(conn); err == nil {
+		info.creds = creds
+		info.ok = true
+	}
+	return conn, info, nil
+}
+
+func (peerCredentialsTransportCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "unix"}
+}
+
+func (creds peerCredentialsTransportCredentials) Clone() credentials.TransportCredentials {
+	return creds
+}
+
+func (peerCredentialsTransportCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+type ctxKeyPeerCredentials int
+
+// contextWithPeerCredentials attaches peer credentials to a context. This is used for
+// requests coming in over HTTP where there is no gRPC handshake to read them in.
+func contextWithPeerCredentials(ctx context.Context, creds PeerCredentials) context.Context {
+	return context.WithValue(ctx, ctxKeyPeerCredentials(0), creds)
+}
+
+// peerCredentialsFromContext returns the peer credentials of the caller, if known.
+func peerCredentialsFromContext(ctx context.Context) (PeerCredentials, bool) {
+	if p, ok := peer.FromContext(ctx); ok && p.AuthInfo != nil {
+		if info, ok := p.AuthInfo.(peerCredentialsAuthInfo); ok && info.ok {
+			return info.creds, true
+		}
+	}
+	creds, ok := ctx.Value(ctxKeyPeerCredentials(0)).(PeerCredentials)
+	return creds, ok
+}
+
+// peerCredentialsConnContext is used as an http.Server's ConnContext so that requests
+// served over a unix socket carry the credentials of the caller.
+func peerCredentialsConnContext(ctx context.Context, conn net.Conn) context.Context {
+	creds, err := readPeerCredentials(conn)
+	if err != nil {
+		return ctx
+	}
+	return contextWithPeerCredentials(ctx, creds)
+}
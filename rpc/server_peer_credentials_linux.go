@@ -0,0 +1,38 @@
+//go:build linux
+
+package rpc
+
+import (
+	"crypto/tls"
+	"net"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// readPeerCredentials reads the credentials of the process on the other end of a unix
+// socket connection.
+func readPeerCredentials(conn net.Conn) (PeerCredentials, error) {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return PeerCredentials{}, errors.Errorf("expected *net.UnixConn but got %T", conn)
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return PeerCredentials{}, err
+	}
+	var ucred *syscall.Ucred
+	var ucredErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		ucred, ucredErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return PeerCredentials{}, err
+	}
+	if ucredErr != nil {
+		return PeerCredentials{}, ucredErr
+	}
+	return PeerCredentials{PID: int(ucred.Pid), UID: int(ucred.Uid), GID: int(ucred.Gid)}, nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package rpc
+
+import "net"
+
+// readPeerCredentials is only supported on Linux.
+func readPeerCredentials(conn net.Conn) (PeerCredentials, error) {
+	return PeerCredentials{}, errPeerCredentialsUnsupported
+}
@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+)
+
+func TestServerWithInternalUnixSocket(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on linux")
+	}
+	logger := golog.NewTestLogger(t)
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+
+	// left over from a previous process
+	stale, err := net.Listen("unix", socketPath)
+	test.That(t, err, test.ShouldBeNil)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	test.That(t, stale.Close(), test.ShouldBeNil)
+
+	var mu sync.Mutex
+	var entity EntityInfo
+	rpcServer, err := NewServer(
+		logger,
+		WithInternalUnixSocket(socketPath),
+		WithPeerCredentialsAuthHandler(MakePeerUIDChecker(os.Getuid())),
+		WithUnaryServerInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			mu.Lock()
+			entity = MustContextAuthEntity(ctx)
+			mu.Unlock()
+			return handler(ctx, req)
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rpcServer.InternalAddr().Network(), test.ShouldEqual, "unix")
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rpcServer.Start(), test.ShouldBeNil)
+
+	conn, err := Dial(context.Background(), "unix://"+socketPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	mu.Lock()
+	test.That(t, entity.Entity, test.ShouldEqual, fmt.Sprintf("unix:%d:%d", os.Getuid(), os.Getgid()))
+	test.That(t, entity.Data, test.ShouldResemble, PeerCredentials{PID: os.Getpid(), UID: os.Getuid(), GID: os.Getgid()})
+	mu.Unlock()
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	_, err = os.Stat(socketPath)
+	test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+}
+
+func TestServerPeerCredentialsRejected(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on linux")
+	}
+	logger := golog.NewTestLogger(t)
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+
+	rpcServer, err := NewServer(
+		logger,
+		WithInternalUnixSocket(socketPath),
+		WithPeerCredentialsAuthHandler(MakePeerUIDChecker(os.Getuid()+1)),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rpcServer.Start(), test.ShouldBeNil)
+
+	conn, err := Dial(context.Background(), "unix://"+socketPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+	_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unauthenticated)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+}
+
+func TestServerPeerCredentialsOverHTTP(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on linux")
+	}
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithDisableMulticastDNS(),
+		WithPeerCredentialsAuthHandler(MakePeerUIDChecker(os.Getuid())),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	httpListener, err := net.Listen("unix", socketPath)
+	test.That(t, err, test.ShouldBeNil)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := Dial(context.Background(), "unix://"+socketPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	// callers over TCP have no peer credentials to go by
+	conn, err = Dial(context.Background(), rpcServer.InternalAddr().String(), logger, WithInsecure())
+	test.That(t, err, test.ShouldBeNil)
+	client = pb.NewEchoServiceClient(conn)
+	_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unauthenticated)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestServerUnixSocketWithTLS(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	_, err := NewServer(
+		logger,
+		WithInternalUnixSocket(filepath.Join(t.TempDir(), "test.sock")),
+		WithInternalTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+	)
+	test.That(t, err, test.ShouldEqual, errUnixSocketWithTLS)
+
+	_, err = NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithPeerCredentialsAuthHandler(MakePeerUIDChecker(os.Getuid())),
+	)
+	test.That(t, err, test.ShouldEqual, errMixedUnauthAndAuth)
+}
@@ -370,6 +370,7 @@ func TestServerWithExternalListenerAddress(t *testing.T) {
 	)
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, conn.(*grpc.ClientConn).Target(), test.ShouldEqual, listener.Addr().String())
+	test.That(t, TransportInfoFromClientConn(conn).MulticastDNS, test.ShouldBeTrue)
 	test.That(t, conn.Close(), test.ShouldBeNil)
 
 	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
@@ -6,11 +6,14 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/pion/dtls/v2"
 	"github.com/pion/sctp"
 	"github.com/pion/webrtc/v3"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/protobuf/proto"
 
 	"go.viam.com/utils"
@@ -23,12 +26,29 @@ type webrtcBaseChannel struct {
 	ctx                     context.Context
 	cancel                  func()
 	ready                   chan struct{}
+	isReady                 bool
+	iceState                webrtc.ICEConnectionState
+	iceConnected            chan struct{}
+	iceConnectedOnce        sync.Once
+	stateChanged            chan struct{}
 	closed                  bool
 	closedReason            error
 	activeBackgroundWorkers sync.WaitGroup
 	logger                  golog.Logger
 	bufferWriteMu           sync.RWMutex
 	bufferWriteCond         *sync.Cond
+
+	// ownsPeerConn is false for additional data channels on a peer connection that is
+	// owned and watched by the channel of its first data channel.
+	ownsPeerConn bool
+
+	// lastReceived is when anything was last received on the peer connection, in unix
+	// nanoseconds, and peerAnswersPings whether the other end has shown that it knows about
+	// keepalive pings. peerRestartsICE is whether the other end said that it restarts ICE
+	// when its connection drops.
+	lastReceived     *atomic.Int64
+	peerAnswersPings atomic.Bool
+	peerRestartsICE  atomic.Bool
 }
 
 const bufferThreshold = 1024 * 1024
@@ -40,25 +60,8 @@ func newBaseChannel(
 	onPeerDone func(),
 	logger golog.Logger,
 ) *webrtcBaseChannel {
-	ctx, cancel := context.WithCancel(ctx)
-	ch := &webrtcBaseChannel{
-		peerConn:    peerConn,
-		dataChannel: dataChannel,
-		ctx:         ctx,
-		cancel:      cancel,
-		ready:       make(chan struct{}),
-		logger:      logger.With("ch", dataChannel.ID()),
-	}
-	ch.bufferWriteCond = sync.NewCond(ch.bufferWriteMu.RLocker())
-	dataChannel.OnOpen(ch.onChannelOpen)
-	dataChannel.OnClose(ch.onChannelClose)
-	dataChannel.OnError(ch.onChannelError)
-	dataChannel.SetBufferedAmountLowThreshold(bufferThreshold)
-	dataChannel.OnBufferedAmountLow(func() {
-		ch.bufferWriteMu.Lock()
-		ch.bufferWriteCond.Broadcast()
-		ch.bufferWriteMu.Unlock()
-	})
+	ch := newDataChannelBase(ctx, peerConn, dataChannel, logger)
+	ch.ownsPeerConn = true
 
 	var connID string
 	var connIDMu sync.Mutex
@@ -69,6 +72,48 @@ func newBaseChannel(
 			onPeerDone()
 		}
 	}
+	// a peer that restarts ICE and whose ICE connection dropped is given some time to do so
+	// before it is done with; this must be called with ch.mu held.
+	var awaitingICERestart bool
+	awaitICERestart := func() {
+		if awaitingICERestart {
+			return
+		}
+		awaitingICERestart = true
+		ch.activeBackgroundWorkers.Add(1)
+		utils.PanicCapturingGo(func() {
+			defer ch.activeBackgroundWorkers.Done()
+			timer := time.NewTimer(WebRTCICERestartGracePeriod)
+			defer timer.Stop()
+			for {
+				ch.mu.Lock()
+				if ch.closed {
+					ch.mu.Unlock()
+					return
+				}
+				if ch.iceState == webrtc.ICEConnectionStateConnected ||
+					ch.iceState == webrtc.ICEConnectionStateCompleted {
+					awaitingICERestart = false
+					ch.mu.Unlock()
+					return
+				}
+				stateChanged := ch.stateChanged
+				ch.mu.Unlock()
+
+				select {
+				case <-ch.ctx.Done():
+					return
+				case <-stateChanged:
+					continue
+				case <-timer.C:
+				}
+				ch.mu.Lock()
+				doPeerDone()
+				ch.mu.Unlock()
+				return
+			}
+		})
+	}
 	connStateChanged := func(connectionState webrtc.ICEConnectionState) {
 		ch.mu.Lock()
 		if ch.closed {
@@ -88,6 +133,14 @@ func newBaseChannel(
 				doPeerDone()
 				return
 			}
+			ch.iceState = connectionState
+			ch.notifyStateChangeLocked()
+			if connectionState == webrtc.ICEConnectionStateConnected ||
+				connectionState == webrtc.ICEConnectionStateCompleted {
+				ch.iceConnectedOnce.Do(func() {
+					close(ch.iceConnected)
+				})
+			}
 
 			switch connectionState {
 			case webrtc.ICEConnectionStateDisconnected,
@@ -103,6 +156,10 @@ func newBaseChannel(
 					"conn_id", currConnID,
 					"conn_state", connectionState.String(),
 				)
+				if connectionState != webrtc.ICEConnectionStateClosed && onPeerDone != nil && ch.peerRestartsICE.Load() {
+					awaitICERestart()
+					return
+				}
 				doPeerDone()
 			case webrtc.ICEConnectionStateChecking, webrtc.ICEConnectionStateCompleted,
 				webrtc.ICEConnectionStateConnected, webrtc.ICEConnectionStateNew:
@@ -135,6 +192,46 @@ func newBaseChannel(
 	return ch
 }
 
+// newSecondaryBaseChannel wraps an additional data channel on the peer connection of the
+// given channel. It is closed along with that channel but never closes the peer connection
+// itself.
+func newSecondaryBaseChannel(primary *webrtcBaseChannel, dataChannel *webrtc.DataChannel) *webrtcBaseChannel {
+	ch := newDataChannelBase(primary.ctx, primary.peerConn, dataChannel, primary.logger)
+	ch.lastReceived = primary.lastReceived
+	return ch
+}
+
+func newDataChannelBase(
+	ctx context.Context,
+	peerConn *webrtc.PeerConnection,
+	dataChannel *webrtc.DataChannel,
+	logger golog.Logger,
+) *webrtcBaseChannel {
+	ctx, cancel := context.WithCancel(ctx)
+	ch := &webrtcBaseChannel{
+		peerConn:     peerConn,
+		dataChannel:  dataChannel,
+		ctx:          ctx,
+		cancel:       cancel,
+		ready:        make(chan struct{}),
+		iceConnected: make(chan struct{}),
+		stateChanged: make(chan struct{}),
+		logger:       logger.With("ch", dataChannel.ID()),
+		lastReceived: &atomic.Int64{},
+	}
+	ch.bufferWriteCond = sync.NewCond(ch.bufferWriteMu.RLocker())
+	dataChannel.OnOpen(ch.onChannelOpen)
+	dataChannel.OnClose(ch.onChannelClose)
+	dataChannel.OnError(ch.onChannelError)
+	dataChannel.SetBufferedAmountLowThreshold(bufferThreshold)
+	dataChannel.OnBufferedAmountLow(func() {
+		ch.bufferWriteMu.Lock()
+		ch.bufferWriteCond.Broadcast()
+		ch.bufferWriteMu.Unlock()
+	})
+	return ch
+}
+
 func (ch *webrtcBaseChannel) closeWithReason(err error) error {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
@@ -143,9 +240,17 @@ func (ch *webrtcBaseChannel) closeWithReason(err error) error {
 	}
 	ch.closed = true
 	ch.closedReason = err
+	ch.notifyStateChangeLocked()
 	ch.cancel()
 	ch.bufferWriteCond.Broadcast()
 
+	if !ch.ownsPeerConn {
+		if ch.dataChannel.ReadyState() == webrtc.DataChannelStateClosed {
+			return nil
+		}
+		return ch.dataChannel.Close()
+	}
+
 	// Underlying connection may already be closed; ignore "conn is closed"
 	// errors.
 	if err := ch.peerConn.Close(); !errors.Is(err, dtls.ErrConnClosed) {
@@ -169,8 +274,37 @@ func (ch *webrtcBaseChannel) Ready() <-chan struct{} {
 	return ch.ready
 }
 
+// ICEConnected returns a channel that is closed once ICE has connected.
+func (ch *webrtcBaseChannel) ICEConnected() <-chan struct{} {
+	return ch.iceConnected
+}
+
 func (ch *webrtcBaseChannel) onChannelOpen() {
 	close(ch.ready)
+	ch.mu.Lock()
+	ch.isReady = true
+	ch.notifyStateChangeLocked()
+	ch.mu.Unlock()
+}
+
+// stateLocked maps the channel and its peer connection onto a gRPC connectivity state.
+func (ch *webrtcBaseChannel) stateLocked() connectivity.State {
+	switch {
+	case ch.closed:
+		return connectivity.Shutdown
+	case ch.iceState == webrtc.ICEConnectionStateDisconnected || ch.iceState == webrtc.ICEConnectionStateFailed:
+		return connectivity.TransientFailure
+	case ch.isReady:
+		return connectivity.Ready
+	default:
+		return connectivity.Connecting
+	}
+}
+
+// notifyStateChangeLocked wakes up anyone waiting for the state to change.
+func (ch *webrtcBaseChannel) notifyStateChangeLocked() {
+	close(ch.stateChanged)
+	ch.stateChanged = make(chan struct{})
 }
 
 var errDataChannelClosed = errors.New("data channel closed")
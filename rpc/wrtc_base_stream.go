@@ -5,30 +5,68 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math"
 	"sync"
 	"sync/atomic"
 
 	"github.com/edaniels/golog"
 	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
 )
 
 type webrtcBaseStream struct {
-	mu            sync.RWMutex
-	ctx           context.Context
-	cancel        context.CancelFunc
-	stream        *webrtcpb.Stream
-	msgCh         chan []byte
-	onDone        func(id uint64)
-	err           error
-	recvClosed    atomic.Bool
-	closed        atomic.Bool
-	logger        golog.Logger
-	packetBuf     bytes.Buffer
-	activeSenders sync.WaitGroup
+	mu         sync.RWMutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	stream     *webrtcpb.Stream
+	onDone     func(id uint64)
+	err        error
+	recvClosed atomic.Bool
+	closed     atomic.Bool
+	logger     golog.Logger
+	packetBuf  bytes.Buffer
+
+	// recvMu guards the messages received but not yet read by RecvMsg. It is separate from mu
+	// so that queueing a message never waits on a sender.
+	recvMu     sync.Mutex
+	recvQueue  []webrtcReceivedMessage
+	recvNotify chan struct{}
+
+	// recvFlowControl is set once the other end is known to respect recvWindowSize, in which
+	// case received messages are queued without waiting and the window is updated as they
+	// are read. recvQueuedBytes is how much is queued and recvUnacked is how much has been
+	// read since the last update.
+	recvFlowControl   bool
+	recvWindowSize    uint32
+	recvQueuedBytes   int
+	recvUnacked       uint32
+	writeWindowUpdate func(stream *webrtcpb.Stream, update *webrtcpb.WindowUpdate) error
+
+	// sendWindow limits sending when the other end does flow control.
+	sendWindow *webrtcSendWindow
+
+	// sendCompressor and recvCompressor compress messages sent and received on the stream,
+	// if compression was negotiated. They are set before any messages are.
+	sendCompressor encoding.Compressor
+	recvCompressor encoding.Compressor
+
+	// maxRecvMsgSize and maxSendMsgSize are the largest messages that may be received and
+	// sent on the stream. They are set before any messages are.
+	maxRecvMsgSize int
+	maxSendMsgSize int
+}
+
+// A webrtcReceivedMessage is a message waiting to be read by RecvMsg.
+type webrtcReceivedMessage struct {
+	data []byte
+	// unreliable messages came over an unreliable data channel and are not flow controlled.
+	unreliable bool
 }
 
 // newWebRTCBaseStream makes a new webrtcBaseStream where the context should originate
@@ -43,13 +81,17 @@ func newWebRTCBaseStream(
 	logger golog.Logger,
 ) *webrtcBaseStream {
 	bs := webrtcBaseStream{
-		ctx:    ctx,
-		cancel: cancelCtx,
-		stream: stream,
-		onDone: onDone,
-		logger: logger,
+		ctx:        ctx,
+		cancel:     cancelCtx,
+		stream:     stream,
+		onDone:     onDone,
+		logger:     logger,
+		recvNotify: make(chan struct{}),
+		sendWindow: newWebRTCSendWindow(),
+		// same defaults as gRPC, except that more can be received
+		maxRecvMsgSize: MaxMessageSize,
+		maxSendMsgSize: math.MaxInt32,
 	}
-	bs.msgCh = make(chan []byte, 1)
 	return &bs
 }
 
@@ -67,50 +109,182 @@ func (s *webrtcBaseStream) Context() context.Context {
 // calling RecvMsg on the same stream at the same time, but it is not
 // safe to call RecvMsg on the same stream in different goroutines.
 func (s *webrtcBaseStream) RecvMsg(m interface{}) error {
-	if v1Msg, ok := m.(protov1.Message); ok {
-		m = protov1.MessageV2(v1Msg)
-	}
-
-	checkLastOrErr := func(origErr error) ([]byte, error) {
-		select {
-		case msgBytes, ok := <-s.msgCh:
-			if ok {
-				return msgBytes, nil
+	for {
+		s.recvMu.Lock()
+		if len(s.recvQueue) != 0 {
+			msg := s.recvQueue[0]
+			s.recvQueue[0] = webrtcReceivedMessage{}
+			s.recvQueue = s.recvQueue[1:]
+			var increment uint32
+			if !msg.unreliable {
+				s.recvQueuedBytes -= len(msg.data)
+				increment = s.consumedLocked(len(msg.data))
 			}
+			s.notifyRecvLocked()
+			s.recvMu.Unlock()
+			s.updateWindow(increment)
+			return s.unmarshal(msg.data, m)
+		}
+		if s.recvClosed.Load() {
+			s.recvMu.Unlock()
 			s.mu.Lock()
-			if s.err != nil {
-				s.mu.Unlock()
-				if errors.Is(s.err, errExpectedClosure) {
-					return nil, io.EOF
+			err := s.err
+			s.mu.Unlock()
+			if err != nil {
+				if errors.Is(err, errExpectedClosure) {
+					return io.EOF
 				}
-				return nil, s.err
+				return err
 			}
-			s.mu.Unlock()
-			if origErr == nil {
-				return nil, io.EOF
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				return toContextStatusError(ctxErr)
 			}
-			return nil, origErr
-		default:
-			return nil, nil
+			return io.EOF
 		}
-	}
-	select {
-	case <-s.ctx.Done():
-		msgBytes, err := checkLastOrErr(s.ctx.Err())
-		if err != nil {
-			return err
+		notify := s.recvNotify
+		s.recvMu.Unlock()
+
+		select {
+		case <-s.ctx.Done():
+			// anything received or closed just before being done is still reported
+			s.recvMu.Lock()
+			pending := len(s.recvQueue) != 0 || s.recvClosed.Load()
+			s.recvMu.Unlock()
+			if !pending {
+				return toContextStatusError(s.ctx.Err())
+			}
+		case <-notify:
 		}
-		if msgBytes != nil {
-			return proto.Unmarshal(msgBytes, m.(proto.Message))
+	}
+}
+
+// enqueueMessage hands a received message to RecvMsg. When the other end does not do flow
+// control, the only way to push back on it is to stop reading from the data channel, so this
+// waits for the previous message to be read first. When it does, it fails with
+// ResourceExhausted if the other end sends more once the window is full, after which the
+// stream must be failed. Like a sender, the message that fills the window may go past it.
+func (s *webrtcBaseStream) enqueueMessage(data []byte) error {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+	for !s.recvFlowControl && len(s.recvQueue) != 0 && !s.recvClosed.Load() {
+		notify := s.recvNotify
+		s.recvMu.Unlock()
+		select {
+		case <-s.ctx.Done():
+			s.recvMu.Lock()
+			return nil
+		case <-notify:
 		}
-		return s.ctx.Err()
-	case msgBytes, ok := <-s.msgCh:
-		if ok {
-			return proto.Unmarshal(msgBytes, m.(proto.Message))
+		s.recvMu.Lock()
+	}
+	if s.recvClosed.Load() {
+		return nil
+	}
+	if s.recvFlowControl && s.recvWindowSize != 0 && s.recvQueuedBytes >= int(s.recvWindowSize) {
+		return status.Errorf(codes.ResourceExhausted,
+			"grpc: received more than the flow control window of %d bytes", s.recvWindowSize)
+	}
+	s.recvQueuedBytes += len(data)
+	s.recvQueue = append(s.recvQueue, webrtcReceivedMessage{data: data})
+	s.notifyRecvLocked()
+	return nil
+}
+
+// enqueueUnreliableMessage hands a message received over an unreliable data channel to
+// RecvMsg. It never waits; the message is dropped instead if the previous one has not been
+// read yet.
+func (s *webrtcBaseStream) enqueueUnreliableMessage(data []byte) {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+	if s.recvClosed.Load() || len(s.recvQueue) != 0 {
+		return
+	}
+	s.recvQueue = append(s.recvQueue, webrtcReceivedMessage{data: data, unreliable: true})
+	s.notifyRecvLocked()
+}
+
+// enableRecvFlowControl records that the other end respects the receive window.
+func (s *webrtcBaseStream) enableRecvFlowControl() {
+	s.recvMu.Lock()
+	s.recvFlowControl = true
+	s.recvMu.Unlock()
+}
+
+// Must be called with the `webrtcBaseStream.recvMu` mutex held.
+func (s *webrtcBaseStream) notifyRecvLocked() {
+	close(s.recvNotify)
+	s.recvNotify = make(chan struct{})
+}
+
+// consumedLocked accounts for n bytes having been read and returns how much to grant the
+// other end, if it is time to. Updates are batched to half of the window.
+//
+// Must be called with the `webrtcBaseStream.recvMu` mutex held.
+func (s *webrtcBaseStream) consumedLocked(n int) uint32 {
+	if !s.recvFlowControl || n == 0 {
+		return 0
+	}
+	s.recvUnacked += uint32(n)
+	if s.recvUnacked < s.recvWindowSize/2 {
+		return 0
+	}
+	increment := s.recvUnacked
+	s.recvUnacked = 0
+	return increment
+}
+
+// updateWindow grants the other end increment more bytes.
+func (s *webrtcBaseStream) updateWindow(increment uint32) {
+	if increment == 0 || s.Closed() {
+		return
+	}
+	if err := s.writeWindowUpdate(s.stream, &webrtcpb.WindowUpdate{Increment: increment}); err != nil &&
+		!errors.Is(err, io.ErrClosedPipe) {
+		s.logger.Debugw("error updating window", "error", err)
+	}
+}
+
+// waitForSendWindow blocks until the other end allows another message to be sent.
+func (s *webrtcBaseStream) waitForSendWindow() error {
+	if err := s.sendWindow.wait(s.ctx); err != nil {
+		if s.Closed() {
+			return io.ErrClosedPipe
 		}
-		_, err := checkLastOrErr(nil)
+		return toContextStatusError(err)
+	}
+	return nil
+}
+
+func (s *webrtcBaseStream) unmarshal(msgBytes []byte, m interface{}) error {
+	if v1Msg, ok := m.(protov1.Message); ok {
+		m = protov1.MessageV2(v1Msg)
+	}
+	msgBytes, err := decompressMessage(s.recvCompressor, msgBytes, s.maxRecvMsgSize)
+	if err != nil {
 		return err
 	}
+	return proto.Unmarshal(msgBytes, m.(proto.Message))
+}
+
+// marshal marshals and, if negotiated, compresses a message to send. It fails with
+// ResourceExhausted if the message is larger than may be sent.
+func (s *webrtcBaseStream) marshal(m interface{}) ([]byte, error) {
+	if v1Msg, ok := m.(protov1.Message); ok {
+		m = protov1.MessageV2(v1Msg)
+	}
+	data, err := proto.Marshal(m.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	data, err = compressMessage(s.sendCompressor, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > s.maxSendMsgSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"grpc: trying to send message larger than max (%d vs. %d)", len(data), s.maxSendMsgSize)
+	}
+	return data, nil
 }
 
 // Must _not_ be holding the `webrtcBaseStream.mu` mutex.
@@ -122,11 +296,12 @@ func (s *webrtcBaseStream) CloseRecv() {
 
 // Must be called with the `webrtcBaseStream.mu` mutex held.
 func (s *webrtcBaseStream) closeRecv() {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
 	if !s.recvClosed.CompareAndSwap(false, true) {
 		return
 	}
-	s.activeSenders.Wait()
-	close(s.msgCh)
+	s.notifyRecvLocked()
 }
 
 // Must be called with the `webrtcBaseStream.mu` mutex held.
@@ -160,23 +335,27 @@ func (s *webrtcBaseStream) closeWithError(err error, expected bool) {
 	s.onDone(s.stream.Id)
 }
 
-func (s *webrtcBaseStream) processMessage(msg *webrtcpb.PacketMessage) ([]byte, bool) {
+// processMessage adds a packet to the message being received and returns the message once
+// it is whole. It fails with ResourceExhausted once the message is larger than may be
+// received, after which the stream must be failed since the rest of the message will
+// still arrive.
+func (s *webrtcBaseStream) processMessage(msg *webrtcpb.PacketMessage) ([]byte, bool, error) {
 	if len(msg.Data) == 0 && msg.Eom {
-		return []byte{}, true
+		return []byte{}, true, nil
 	}
-	if len(msg.Data)+s.packetBuf.Len() > MaxMessageSize {
+	if len(msg.Data)+s.packetBuf.Len() > s.maxRecvMsgSize {
 		s.packetBuf.Reset()
-		s.logger.Errorf("message size larger than max %d; discarding", MaxMessageSize)
-		return nil, false
+		return nil, false, status.Errorf(codes.ResourceExhausted,
+			"grpc: received message larger than max %d", s.maxRecvMsgSize)
 	}
 	s.packetBuf.Write(msg.Data)
 	if msg.Eom {
 		data := make([]byte, s.packetBuf.Len())
 		copy(data, s.packetBuf.Bytes())
 		s.packetBuf.Reset()
-		return data, true
+		return data, true, nil
 	}
-	return nil, false
+	return nil, false, nil
 }
 
 func metadataToProto(md metadata.MD) *webrtcpb.Metadata {
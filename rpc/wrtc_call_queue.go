@@ -9,7 +9,8 @@ import (
 	"github.com/pion/webrtc/v3"
 )
 
-// WARNING: do not change this unless the MongoDB TTL is also modified in advance.
+// The defaults for how long an offer has to be answered. Signaling servers and call queues can
+// be given their own with WithSignalingOfferDeadline and WithCallQueueOfferDeadline.
 var (
 	_defaultOfferDeadline                     = 10 * time.Second
 	_defaultOfferDeadlineCloseToExpiredFactor = .2
@@ -42,9 +43,11 @@ func setDefaultOfferDeadline(deafultOfferDeadline time.Duration) func() {
 // offer and subsequently respond to it.
 type WebRTCCallQueue interface {
 	// SendOfferInit initializes an offer associated with the given SDP to the given host.
+	// If iceRestartUUID is set, the offer restarts ICE on the peer connection established by
+	// the call with that UUID.
 	// It returns a UUID to track/authenticate the offer over time, a channel receive offer updates
 	// on over time, and a cancel func to inform the sender to stop.
-	SendOfferInit(ctx context.Context, host, sdp string, disableTrickle bool) (
+	SendOfferInit(ctx context.Context, host, sdp string, disableTrickle bool, iceRestartUUID string) (
 		uuid string, respCh <-chan WebRTCCallAnswer, respDone <-chan struct{}, cancel func(), err error)
 
 	// SendOfferUpdate updates the offer associated with the given UUID with a newly discovered
@@ -81,6 +84,10 @@ type WebRTCCallOffer interface {
 	// sides must both respect this setting.
 	DisableTrickleICE() bool
 
+	// ICERestartUUID is the UUID of the call whose peer connection this offer restarts
+	// ICE on, if any.
+	ICERestartUUID() string
+
 	// Deadline returns how long this offer has to live.
 	Deadline() time.Time
 }
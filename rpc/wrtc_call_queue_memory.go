@@ -22,6 +22,9 @@ type memoryWebRTCCallQueue struct {
 	activeBackgroundWorkers sync.WaitGroup
 	hostQueues              map[string]*singleWebRTCHostQueue
 
+	// operatorID identifies this queue in metrics.
+	operatorID string
+
 	cancelCtx  context.Context
 	cancelFunc func()
 
@@ -30,7 +33,8 @@ type memoryWebRTCCallQueue struct {
 	logger                   golog.Logger
 }
 
-// NewMemoryWebRTCCallQueue returns a new, empty in-memory call queue.
+// NewMemoryWebRTCCallQueue returns a new, empty in-memory call queue. Offers expire with the
+// context they are sent with, so there is no offer deadline to configure.
 func NewMemoryWebRTCCallQueue(logger golog.Logger) WebRTCCallQueue {
 	return newMemoryWebRTCCallQueue(false, logger)
 }
@@ -45,6 +49,7 @@ func newMemoryWebRTCCallQueue(uuidDeterministic bool, logger golog.Logger) *memo
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 	queue := &memoryWebRTCCallQueue{
 		hostQueues:        map[string]*singleWebRTCHostQueue{},
+		operatorID:        "memory-" + uuid.NewString(),
 		cancelCtx:         cancelCtx,
 		cancelFunc:        cancelFunc,
 		uuidDeterministic: uuidDeterministic,
@@ -63,17 +68,25 @@ func newMemoryWebRTCCallQueue(uuidDeterministic bool, logger golog.Logger) *memo
 			case <-ticker.C:
 			}
 			now := time.Now()
+			var pendingOffers, connectedAnswerers int64
 			queue.mu.Lock()
-			for _, hostQueue := range queue.hostQueues {
+			for _, hostQueue := range queue.uniqueHostQueues() {
 				hostQueue.mu.Lock()
 				for offerID, offer := range hostQueue.activeOffers {
 					if d, ok := offer.offer.answererDoneCtx.Deadline(); ok && d.Before(now) {
 						delete(hostQueue.activeOffers, offerID)
+						continue
+					}
+					if !offer.answered {
+						pendingOffers++
 					}
 				}
+				connectedAnswerers += int64(hostQueue.answerers)
 				hostQueue.mu.Unlock()
 			}
 			queue.mu.Unlock()
+			callQueuePendingOffers.Set(queue.operatorID, pendingOffers)
+			callQueueConnectedAnswerers.Set(queue.operatorID, connectedAnswerers)
 		}
 	}, func() {
 		defer queue.activeBackgroundWorkers.Done()
@@ -86,8 +99,11 @@ func newMemoryWebRTCCallQueue(uuidDeterministic bool, logger golog.Logger) *memo
 // and how it wishes to speak is contained in the SDP.
 type memoryWebRTCCallOfferInit struct {
 	uuid               string
+	host               string
 	sdp                string
 	disableTrickle     bool
+	iceRestartUUID     string
+	startedAt          time.Time
 	deadline           time.Time
 	callerCandidates   chan webrtc.ICECandidateInit
 	answererResponses  chan<- WebRTCCallAnswer
@@ -103,6 +119,7 @@ func (queue *memoryWebRTCCallQueue) SendOfferInit(
 	ctx context.Context,
 	host, sdp string,
 	disableTrickle bool,
+	iceRestartUUID string,
 ) (string, <-chan WebRTCCallAnswer, <-chan struct{}, func(), error) {
 	hostQueueForSend := queue.getOrMakeHostsQueue([]string{host})
 
@@ -113,12 +130,15 @@ func (queue *memoryWebRTCCallQueue) SendOfferInit(
 		newUUID = uuid.NewString()
 	}
 	answererResponses := make(chan WebRTCCallAnswer)
-	offerDeadline, _ := ctx.Deadline()
+	offerDeadline, hasDeadline := ctx.Deadline()
 	sendCtx, sendCtxCancel := context.WithDeadline(queue.cancelCtx, offerDeadline)
 	offer := memoryWebRTCCallOfferInit{
 		uuid:               newUUID,
+		host:               host,
 		sdp:                sdp,
 		disableTrickle:     disableTrickle,
+		iceRestartUUID:     iceRestartUUID,
+		startedAt:          time.Now(),
 		deadline:           offerDeadline,
 		callerCandidates:   make(chan webrtc.ICECandidateInit),
 		answererResponses:  answererResponses,
@@ -143,6 +163,10 @@ func (queue *memoryWebRTCCallQueue) SendOfferInit(
 		case <-sendCtx.Done():
 		case <-ctx.Done():
 		case hostQueueForSend.exchangeCh <- exchange:
+			return
+		}
+		if hasDeadline && !time.Now().Before(offerDeadline) {
+			callQueueOffersExpired.Inc(queue.operatorID)
 		}
 	})
 	return newUUID, answererResponses, sendCtx.Done(), func() { sendCtxCancel() }, nil
@@ -217,16 +241,72 @@ func (queue *memoryWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []strin
 	recvCtx, recvCtxCancel := context.WithCancel(queue.cancelCtx)
 	defer recvCtxCancel()
 
+	hostQueue.mu.Lock()
+	hostQueue.answerers++
+	hostQueue.mu.Unlock()
+	defer func() {
+		hostQueue.mu.Lock()
+		hostQueue.answerers--
+		hostQueue.mu.Unlock()
+	}()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	case <-recvCtx.Done():
 		return nil, recvCtx.Err()
 	case exchange := <-hostQueue.exchangeCh:
+		hostQueue.mu.Lock()
+		exchange.answered = true
+		hostQueue.mu.Unlock()
+		observeOfferWaitTime(queue.operatorID, exchange.offer.startedAt)
 		return exchange, nil
 	}
 }
 
+// Stats returns the hosts with offers pending or answerers waiting and the calls in flight.
+func (queue *memoryWebRTCCallQueue) Stats(ctx context.Context) (WebRTCCallQueueSnapshot, error) {
+	now := time.Now()
+	var snapshot WebRTCCallQueueSnapshot
+	queue.mu.Lock()
+	answerersByQueue := make(map[*singleWebRTCHostQueue]uint64, len(queue.hostQueues))
+	for _, hostQueue := range queue.uniqueHostQueues() {
+		hostQueue.mu.RLock()
+		answerersByQueue[hostQueue] = uint64(hostQueue.answerers)
+		for _, offer := range hostQueue.activeOffers {
+			if !offer.offer.deadline.After(now) {
+				continue
+			}
+			snapshot.Calls = append(snapshot.Calls, WebRTCCallQueueCallStats{
+				UUID:      offer.offer.uuid,
+				Host:      offer.offer.host,
+				StartedAt: offer.offer.startedAt,
+				Deadline:  offer.offer.deadline,
+				Answered:  offer.answered,
+			})
+		}
+		hostQueue.mu.RUnlock()
+	}
+	hostAnswerers := make(map[string]uint64, len(queue.hostQueues))
+	for host, hostQueue := range queue.hostQueues {
+		if answerers := answerersByQueue[hostQueue]; answerers != 0 {
+			hostAnswerers[host] = answerers
+		}
+	}
+	queue.mu.Unlock()
+
+	snapshot.Hosts = webrtcCallQueueHostStatsFromCalls(snapshot.Calls, nil)
+	for idx, hostStats := range snapshot.Hosts {
+		snapshot.Hosts[idx].ConnectedAnswerers = hostAnswerers[hostStats.Host]
+		delete(hostAnswerers, hostStats.Host)
+	}
+	for host, answerers := range hostAnswerers {
+		snapshot.Hosts = append(snapshot.Hosts, WebRTCCallQueueHostStats{Host: host, ConnectedAnswerers: answerers})
+	}
+	sortWebRTCCallQueueSnapshot(&snapshot)
+	return snapshot, nil
+}
+
 // Close cancels all active offers and waits to cleanly close all background workers.
 func (queue *memoryWebRTCCallQueue) Close() error {
 	queue.cancelFunc()
@@ -240,6 +320,10 @@ type memoryWebRTCCallOfferExchange struct {
 	callerDoneCancel func()
 	callerErr        error
 	answererDoneOnce sync.Once
+
+	// answered is whether an answerer has received the offer; it is guarded by the mutex
+	// of the host queue the offer is in.
+	answered bool
 }
 
 func (resp *memoryWebRTCCallOfferExchange) UUID() string {
@@ -254,6 +338,10 @@ func (resp *memoryWebRTCCallOfferExchange) DisableTrickleICE() bool {
 	return resp.offer.disableTrickle
 }
 
+func (resp *memoryWebRTCCallOfferExchange) ICERestartUUID() string {
+	return resp.offer.iceRestartUUID
+}
+
 func (resp *memoryWebRTCCallOfferExchange) Deadline() time.Time {
 	return resp.offer.deadline
 }
@@ -301,6 +389,22 @@ type singleWebRTCHostQueue struct {
 	mu           sync.RWMutex
 	exchangeCh   chan *memoryWebRTCCallOfferExchange
 	activeOffers map[string]*memoryWebRTCCallOfferExchange
+	answerers    int
+}
+
+// uniqueHostQueues returns each host queue once, even if it is shared by many hosts. The
+// queue's mutex must be held.
+func (queue *memoryWebRTCCallQueue) uniqueHostQueues() []*singleWebRTCHostQueue {
+	seen := make(map[*singleWebRTCHostQueue]struct{}, len(queue.hostQueues))
+	hostQueues := make([]*singleWebRTCHostQueue, 0, len(queue.hostQueues))
+	for _, hostQueue := range queue.hostQueues {
+		if _, ok := seen[hostQueue]; ok {
+			continue
+		}
+		seen[hostQueue] = struct{}{}
+		hostQueues = append(hostQueues, hostQueue)
+	}
+	return hostQueues
 }
 
 func (queue *memoryWebRTCCallQueue) getOrMakeHostsQueue(hosts []string) *singleWebRTCHostQueue {
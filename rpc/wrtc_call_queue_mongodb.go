@@ -68,6 +68,7 @@ var (
 // multi-node, distributed deployments.
 type mongoDBWebRTCCallQueue struct {
 	operatorID                         string
+	opts                               webrtcCallQueueOptions
 	hostCallerQueueSizeMatchAggStage   bson.D
 	hostAnswererQueueSizeMatchAggStage bson.D
 	activeBackgroundWorkers            sync.WaitGroup
@@ -87,6 +88,9 @@ type mongoDBWebRTCCallQueue struct {
 	csStateUpdates              chan changeStreamStateUpdate
 	csCtxCancel                 func()
 
+	// offers sent through this operator that have yet to be answered
+	pendingOffers atomic.Int64
+
 	// function to update access times on robot parts based on this call queue
 	activeAnswerersfunc *func(hostnames []string)
 	// 1 caller/answerer -> 1 caller id -> 1 event stream
@@ -120,7 +124,8 @@ const maxHostAnswerersSize = 2
 // that this code is run in an auto scaling environment that bounds how many incoming requests there can
 // be. The given max queue size specifies how many big a queue can be for a given host; the size is used
 // as an approximation and at times may exceed the max as a performance/consistency balance of being
-// a distributed queue.
+// a distributed queue. Calls expire by a TTL index on the offer deadline; if the deadline is
+// changed with WithCallQueueOfferDeadline, the index is migrated to the new deadline.
 func NewMongoDBWebRTCCallQueue(
 	ctx context.Context,
 	operatorID string,
@@ -128,14 +133,19 @@ func NewMongoDBWebRTCCallQueue(
 	client *mongo.Client,
 	logger golog.Logger,
 	activeAnswerersfunc func(hostnames []string),
+	opts ...WebRTCCallQueueOption,
 ) (WebRTCCallQueue, error) {
 	if operatorID == "" {
 		return nil, errors.New("expected non-empty operatorID")
 	}
+	var qOpts webrtcCallQueueOptions
+	for _, opt := range opts {
+		opt.apply(&qOpts)
+	}
 	callsColl := client.Database(mongodbWebRTCCallQueueDBName).Collection(mongodbWebRTCCallQueueCallsCollName)
 	operatorsColl := client.Database(mongodbWebRTCCallQueueDBName).Collection(mongodbWebRTCCallQueueOperatorsCollName)
 
-	mongodbWebRTCCallQueueExpireAfter := int32(getDefaultOfferDeadline().Seconds())
+	mongodbWebRTCCallQueueExpireAfter := int32(qOpts.getOfferDeadline().Seconds())
 	mongodbWebRTCCallQueueCallsIndexes := []mongo.IndexModel{
 		{
 			Keys: bson.D{
@@ -173,6 +183,9 @@ func NewMongoDBWebRTCCallQueue(
 		},
 	}
 
+	if err := migrateMongoDBWebRTCCallQueueExpireAfter(ctx, callsColl, mongodbWebRTCCallQueueExpireAfter); err != nil {
+		return nil, err
+	}
 	if err := mongoutils.EnsureIndexes(ctx, callsColl, mongodbWebRTCCallQueueCallsIndexes...); err != nil {
 		return nil, err
 	}
@@ -190,13 +203,12 @@ func NewMongoDBWebRTCCallQueue(
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 	queue := &mongoDBWebRTCCallQueue{
 		operatorID: operatorID,
+		opts:       qOpts,
 		hostCallerQueueSizeMatchAggStage: bson.D{{"$match", bson.D{
 			{"caller_size", bson.D{{"$gte", maxHostCallers}}},
 		}}},
-		// we use maxHostAnswerersSize * 2 to accommodate an answerer that
-		// immedeiately reconnects
 		hostAnswererQueueSizeMatchAggStage: bson.D{{"$match", bson.D{
-			{"answerer_size", bson.D{{"$gte", maxHostAnswerersSize * 2}}},
+			{"answerer_size", bson.D{{"$gte", qOpts.getMaxHostAnswerers()}}},
 		}}},
 		callsColl:     callsColl,
 		operatorsColl: operatorsColl,
@@ -262,6 +274,7 @@ type mongodbWebRTCCall struct {
 	CallerDone         bool                  `bson:"caller_done"`
 	CallerError        string                `bson:"caller_error,omitempty"`
 	DisableTrickle     bool                  `bson:"disable_trickle"`
+	ICERestartUUID     string                `bson:"ice_restart_uuid,omitempty"`
 	Answered           bool                  `bson:"answered"`
 	AnswererSDP        string                `bson:"answerer_sdp,omitempty"`
 	AnswererCandidates []mongodbICECandidate `bson:"answerer_candidates,omitempty"`
@@ -330,6 +343,40 @@ const (
 	operatorHeartbeatWindow     = time.Second * 10
 )
 
+// migrateMongoDBWebRTCCallQueueExpireAfter changes the TTL of the call expiration index, if it
+// exists, to the given number of seconds. Ensuring an index that exists with a different TTL
+// would otherwise fail. Operators sharing a queue should agree on the offer deadline or they
+// will keep changing it on each other when starting up.
+func migrateMongoDBWebRTCCallQueueExpireAfter(ctx context.Context, callsColl *mongo.Collection, expireAfter int32) error {
+	cursor, err := callsColl.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+	var indexes []struct {
+		Name               string `bson:"name"`
+		ExpireAfterSeconds *int64 `bson:"expireAfterSeconds"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index.Name != mongodbWebRTCCallQueueRPCCallExpireName {
+			continue
+		}
+		if index.ExpireAfterSeconds == nil || *index.ExpireAfterSeconds == int64(expireAfter) {
+			return nil
+		}
+		return callsColl.Database().RunCommand(ctx, bson.D{
+			{"collMod", callsColl.Name()},
+			{"index", bson.D{
+				{"name", mongodbWebRTCCallQueueRPCCallExpireName},
+				{"expireAfterSeconds", expireAfter},
+			}},
+		}).Err()
+	}
+	return nil
+}
+
 // The operatorLivenessLoop keeps the distributed queue aware of this operator's existence, in
 // addition to the hosts its listening to calls for, in order to keep track of eventually
 // consistent queue maximums.
@@ -346,10 +393,15 @@ func (queue *mongoDBWebRTCCallQueue) operatorLivenessLoop() {
 		}
 		queue.csStateMu.RLock()
 		hosts := make(map[string]callerAnswererQueueSizes, len(queue.waitingForNewCallSubs)+len(queue.callExchangeSubs))
+		// an answerer waits on all of its hosts at once
+		waitingAnswerers := map[*mongodbNewCallEventHandler]struct{}{}
 		for host, waiting := range queue.waitingForNewCallSubs {
 			sizes := hosts[host]
 			sizes.Answerer += uint64(len(waiting))
 			hosts[host] = sizes
+			for handler := range waiting {
+				waitingAnswerers[handler] = struct{}{}
+			}
 		}
 		for _, exchanges := range queue.callExchangeSubs {
 			for exchange := range exchanges {
@@ -365,6 +417,8 @@ func (queue *mongoDBWebRTCCallQueue) operatorLivenessLoop() {
 			}
 		}
 		queue.csStateMu.RUnlock()
+		callQueuePendingOffers.Set(queue.operatorID, queue.pendingOffers.Load())
+		callQueueConnectedAnswerers.Set(queue.operatorID, int64(len(waitingAnswerers)))
 		// put a time stamp in the operator to show when this was updated
 		// then when the operator goes offline, we should update the robot part collection
 
@@ -829,6 +883,7 @@ func (queue *mongoDBWebRTCCallQueue) SendOfferInit(
 	ctx context.Context,
 	host, sdp string,
 	disableTrickle bool,
+	iceRestartUUID string,
 ) (string, <-chan WebRTCCallAnswer, <-chan struct{}, func(), error) {
 	if err := queue.checkHostQueueSize(ctx, true, host); err != nil {
 		return "", nil, nil, nil, err
@@ -840,11 +895,12 @@ func (queue *mongoDBWebRTCCallQueue) SendOfferInit(
 		CallerOperatorID: queue.operatorID,
 		Host:             host,
 		CallerSDP:        sdp,
+		ICERestartUUID:   iceRestartUUID,
 	}
 
 	events, unsubscribe := queue.subscribeToCall(host, call.ID, "caller")
 
-	offerDeadline := time.Now().Add(getDefaultOfferDeadline())
+	offerDeadline := time.Now().Add(queue.opts.getOfferDeadline())
 	sendCtx, sendCtxCancel := context.WithDeadline(ctx, offerDeadline)
 
 	// need to watch before insertion to avoid a race
@@ -867,6 +923,7 @@ func (queue *mongoDBWebRTCCallQueue) SendOfferInit(
 	if _, err := queue.callsColl.InsertOne(sendAndQueueCtx, call); err != nil {
 		return "", nil, nil, nil, err
 	}
+	queue.pendingOffers.Add(1)
 
 	answererResponses := make(chan WebRTCCallAnswer, 1)
 	sendAnswer := func(answer WebRTCCallAnswer) bool {
@@ -888,6 +945,17 @@ func (queue *mongoDBWebRTCCallQueue) SendOfferInit(
 		defer cleanup()
 		defer close(answererResponses)
 
+		answered := false
+		defer func() {
+			if answered {
+				return
+			}
+			queue.pendingOffers.Add(-1)
+			if !time.Now().Before(offerDeadline) {
+				callQueueOffersExpired.Inc(queue.operatorID)
+			}
+		}()
+
 		haveInitSDP := false
 		candLen := len(call.AnswererCandidates)
 		for {
@@ -910,6 +978,11 @@ func (queue *mongoDBWebRTCCallQueue) SendOfferInit(
 
 			callResp := next.Call
 
+			if !answered && callResp.Answered {
+				answered = true
+				queue.pendingOffers.Add(-1)
+			}
+
 			if callResp.AnswererError != "" {
 				sendAnswer(WebRTCCallAnswer{Err: errors.New(callResp.AnswererError)})
 				return
@@ -1029,7 +1102,7 @@ func (queue *mongoDBWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []stri
 		//     Start    Window  |      Expire     Check from now window bound
 		//                      |
 		//             Window with estimated connect time
-		startedAtWindow := time.Now().Add(-getDefaultOfferDeadline()).Add(getDefaultOfferCloseToDeadline())
+		startedAtWindow := time.Now().Add(-queue.opts.getOfferDeadline()).Add(queue.opts.getOfferCloseToDeadline())
 
 		// but also check first if there is anything for us.
 		result := queue.callsColl.FindOneAndUpdate(
@@ -1134,10 +1207,11 @@ func (queue *mongoDBWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []stri
 		}
 		break
 	}
+	observeOfferWaitTime(queue.operatorID, callReq.StartedAt)
 
 	events, exchangeUnsubscribe := queue.subscribeToCall(callReq.Host, callReq.ID, "answerer")
 
-	offerDeadline := callReq.StartedAt.Add(getDefaultOfferDeadline())
+	offerDeadline := callReq.StartedAt.Add(queue.opts.getOfferDeadline())
 
 	recvCtx, recvCtxCancel := utils.MergeContextWithDeadline(ctx, queue.cancelCtx, offerDeadline)
 
@@ -1267,6 +1341,77 @@ func (queue *mongoDBWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []stri
 	return &exchange, nil
 }
 
+// Stats returns the hosts with offers pending or answerers connected, the operators sharing
+// the queue and the calls in flight, as stored in MongoDB.
+func (queue *mongoDBWebRTCCallQueue) Stats(ctx context.Context) (WebRTCCallQueueSnapshot, error) {
+	now := time.Now()
+	var operators []struct {
+		ID       string    `bson:"_id"`
+		ExpireAt time.Time `bson:"expire_at"`
+		Hosts    []struct {
+			Host         string `bson:"host"`
+			CallerSize   uint64 `bson:"caller_size"`
+			AnswererSize uint64 `bson:"answerer_size"`
+		} `bson:"hosts"`
+	}
+	cursor, err := queue.operatorsColl.Find(ctx, bson.D{{webrtcOperatorExpireAtField, bson.D{{"$gt", now}}}})
+	if err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	if err := cursor.All(ctx, &operators); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+
+	var calls []mongodbWebRTCCall
+	cursor, err = queue.callsColl.Find(
+		ctx,
+		bson.D{
+			{webrtcCallStartedAtField, bson.D{{"$gt", now.Add(-queue.opts.getOfferDeadline())}}},
+			{webrtcCallCallerErrorField, bson.D{{"$exists", false}}},
+		},
+		options.Find().SetProjection(bson.D{
+			{webrtcCallCallerOperatorIDField, 1},
+			{webrtcCallAnswererOperatorIDField, 1},
+			{webrtcCallHostField, 1},
+			{webrtcCallStartedAtField, 1},
+			{webrtcCallAnsweredField, 1},
+		}),
+	)
+	if err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	if err := cursor.All(ctx, &calls); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+
+	var snapshot WebRTCCallQueueSnapshot
+	for _, operator := range operators {
+		operatorStats := WebRTCCallQueueOperatorStats{ID: operator.ID, ExpiresAt: operator.ExpireAt}
+		for _, host := range operator.Hosts {
+			operatorStats.Hosts = append(operatorStats.Hosts, WebRTCCallQueueOperatorHostStats{
+				Host:      host.Host,
+				Callers:   host.CallerSize,
+				Answerers: host.AnswererSize,
+			})
+		}
+		snapshot.Operators = append(snapshot.Operators, operatorStats)
+	}
+	for _, call := range calls {
+		snapshot.Calls = append(snapshot.Calls, WebRTCCallQueueCallStats{
+			UUID:               call.ID,
+			Host:               call.Host,
+			StartedAt:          call.StartedAt,
+			Deadline:           call.StartedAt.Add(queue.opts.getOfferDeadline()),
+			Answered:           call.Answered,
+			CallerOperatorID:   call.CallerOperatorID,
+			AnswererOperatorID: call.AnswererOperatorID,
+		})
+	}
+	snapshot.Hosts = webrtcCallQueueHostStatsFromCalls(snapshot.Calls, snapshot.Operators)
+	sortWebRTCCallQueueSnapshot(&snapshot)
+	return snapshot, nil
+}
+
 func iceCandidateFromMongo(i mongodbICECandidate) webrtc.ICECandidateInit {
 	candidate := webrtc.ICECandidateInit{
 		Candidate: i.Candidate,
@@ -1333,6 +1478,10 @@ func (resp *mongoDBWebRTCCallOfferExchange) DisableTrickleICE() bool {
 	return resp.call.DisableTrickle
 }
 
+func (resp *mongoDBWebRTCCallOfferExchange) ICERestartUUID() string {
+	return resp.call.ICERestartUUID
+}
+
 func (resp *mongoDBWebRTCCallOfferExchange) Deadline() time.Time {
 	return resp.deadline
 }
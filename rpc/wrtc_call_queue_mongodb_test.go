@@ -89,7 +89,7 @@ func TestMongoDBWebRTCCallQueueMulti(t *testing.T) {
 
 		t.Logf("start up %d callers (the max)", maxCallerQueueSize)
 		for i := 0; i < maxCallerQueueSize; i++ {
-			callID, _, respDone, cancel, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false)
+			callID, _, respDone, cancel, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false, "")
 			test.That(t, err, test.ShouldBeNil)
 			t.Logf("sent offer %d=%s", i, callID)
 			offers = append(offers, offerState{CallID: callID, Done: respDone, Cancel: cancel})
@@ -97,9 +97,9 @@ func TestMongoDBWebRTCCallQueueMulti(t *testing.T) {
 		}
 
 		t.Log("the next caller should fail from either queue")
-		_, _, _, _, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false)
+		_, _, _, _, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false, "")
 		test.That(t, err, test.ShouldResemble, errTooManyConns)
-		_, _, _, _, err = answererQueue.SendOfferInit(ctx, host, "somesdp", false)
+		_, _, _, _, err = answererQueue.SendOfferInit(ctx, host, "somesdp", false, "")
 		test.That(t, err, test.ShouldResemble, errTooManyConns)
 
 		t.Logf("but canceling one (%s) should allow the next", offers[0].CallID)
@@ -109,7 +109,7 @@ func TestMongoDBWebRTCCallQueueMulti(t *testing.T) {
 
 		time.Sleep(2 * time.Second)
 
-		callID, _, respDone, cancel, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false)
+		callID, _, respDone, cancel, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false, "")
 		test.That(t, err, test.ShouldBeNil)
 		t.Logf("sent offer %d=%s", maxCallerQueueSize, callID)
 		offers[0] = offerState{CallID: callID, Done: respDone, Cancel: cancel}
@@ -145,7 +145,7 @@ func TestMongoDBWebRTCCallQueueMulti(t *testing.T) {
 
 		time.Sleep(2 * time.Second)
 
-		callID, _, respDone, cancel, err = callerQueue.SendOfferInit(ctx, host, "somesdp", false)
+		callID, _, respDone, cancel, err = callerQueue.SendOfferInit(ctx, host, "somesdp", false, "")
 		test.That(t, err, test.ShouldBeNil)
 		t.Logf("sent offer %d=%s", maxCallerQueueSize+1, callID)
 		offers[capOfferIdx] = offerState{CallID: callID, Done: respDone, Cancel: cancel}
@@ -184,3 +184,39 @@ func TestMongoDBWebRTCCallQueueMulti(t *testing.T) {
 		test.That(t, val, test.ShouldEqual, 2)
 	})
 }
+
+func TestMongoDBWebRTCCallQueueOfferDeadlineMigration(t *testing.T) {
+	client := testutils.BackingMongoDBClient(t)
+	test.That(t, client.Database(mongodbWebRTCCallQueueDBName).Drop(context.Background()), test.ShouldBeNil)
+	logger := golog.NewTestLogger(t)
+
+	callExpireAfter := func() int64 {
+		t.Helper()
+		callsColl := client.Database(mongodbWebRTCCallQueueDBName).Collection(mongodbWebRTCCallQueueCallsCollName)
+		cursor, err := callsColl.Indexes().List(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		var indexes []struct {
+			Name               string `bson:"name"`
+			ExpireAfterSeconds int64  `bson:"expireAfterSeconds"`
+		}
+		test.That(t, cursor.All(context.Background(), &indexes), test.ShouldBeNil)
+		for _, index := range indexes {
+			if index.Name == mongodbWebRTCCallQueueRPCCallExpireName {
+				return index.ExpireAfterSeconds
+			}
+		}
+		t.Fatal("call expiration index not found")
+		return 0
+	}
+
+	callQueue, err := NewMongoDBWebRTCCallQueue(context.Background(), uuid.NewString(), 50, client, logger, func(hosts []string) {})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, callQueue.Close(), test.ShouldBeNil)
+	test.That(t, callExpireAfter(), test.ShouldEqual, int64(getDefaultOfferDeadline().Seconds()))
+
+	callQueue, err = NewMongoDBWebRTCCallQueue(context.Background(), uuid.NewString(), 50, client, logger, func(hosts []string) {},
+		WithCallQueueOfferDeadline(time.Minute))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, callQueue.Close(), test.ShouldBeNil)
+	test.That(t, callExpireAfter(), test.ShouldEqual, int64(60))
+}
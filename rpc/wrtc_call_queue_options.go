@@ -0,0 +1,99 @@
+package rpc
+
+import "time"
+
+// webrtcCallQueueOptions change the runtime behavior of a distributed WebRTCCallQueue.
+type webrtcCallQueueOptions struct {
+	// offerDeadline, if set, is how long an offer has to be answered.
+	offerDeadline time.Duration
+
+	// maxHostAnswerers, if set, is how many answerers a host may have across all operators.
+	maxHostAnswerers uint64
+
+	// redisKeyPrefix, if set, is what every key and channel of a Redis queue starts with.
+	redisKeyPrefix string
+}
+
+// getOfferDeadline returns how long an offer has to be answered, falling back to the
+// package default if unset.
+func (o webrtcCallQueueOptions) getOfferDeadline() time.Duration {
+	if o.offerDeadline == 0 {
+		return getDefaultOfferDeadline()
+	}
+	return o.offerDeadline
+}
+
+// getOfferCloseToDeadline returns how close to its deadline an offer is no longer worth
+// answering.
+func (o webrtcCallQueueOptions) getOfferCloseToDeadline() time.Duration {
+	if o.offerDeadline == 0 {
+		return getDefaultOfferCloseToDeadline()
+	}
+	return calcDefaultOfferDeadlineClose(o.offerDeadline)
+}
+
+// getMaxHostAnswerers returns how many answerers a host may have across all operators.
+func (o webrtcCallQueueOptions) getMaxHostAnswerers() uint64 {
+	if o.maxHostAnswerers == 0 {
+		// we use maxHostAnswerersSize * 2 to accommodate an answerer that
+		// immediately reconnects
+		return maxHostAnswerersSize * 2
+	}
+	return o.maxHostAnswerers
+}
+
+// getRedisKeyPrefix returns what every key and channel of a Redis queue starts with.
+func (o webrtcCallQueueOptions) getRedisKeyPrefix() string {
+	if o.redisKeyPrefix == "" {
+		return defaultRedisWebRTCCallQueueKeyPrefix
+	}
+	return o.redisKeyPrefix
+}
+
+// A WebRTCCallQueueOption changes the runtime behavior of a distributed WebRTCCallQueue.
+type WebRTCCallQueueOption interface {
+	apply(*webrtcCallQueueOptions)
+}
+
+// funcWebRTCCallQueueOption wraps a function that modifies webrtcCallQueueOptions
+// into an implementation of the WebRTCCallQueueOption interface.
+type funcWebRTCCallQueueOption struct {
+	f func(*webrtcCallQueueOptions)
+}
+
+func (fo *funcWebRTCCallQueueOption) apply(o *webrtcCallQueueOptions) {
+	fo.f(o)
+}
+
+func newFuncWebRTCCallQueueOption(f func(*webrtcCallQueueOptions)) *funcWebRTCCallQueueOption {
+	return &funcWebRTCCallQueueOption{
+		f: f,
+	}
+}
+
+// WithCallQueueOfferDeadline returns a WebRTCCallQueueOption which sets how long an offer has
+// to be answered before it expires. Every operator sharing a queue should use the same
+// deadline, as should the WebRTCSignalingServer using the queue (see WithSignalingOfferDeadline).
+func WithCallQueueOfferDeadline(deadline time.Duration) WebRTCCallQueueOption {
+	return newFuncWebRTCCallQueueOption(func(o *webrtcCallQueueOptions) {
+		o.offerDeadline = deadline
+	})
+}
+
+// WithCallQueueMaxHostAnswerers returns a WebRTCCallQueueOption which sets how many answerers
+// a host may have waiting for or in calls across all operators at once. By default, this is
+// twice the number of answerers a host runs in order to accommodate answerers reconnecting.
+func WithCallQueueMaxHostAnswerers(maxHostAnswerers uint64) WebRTCCallQueueOption {
+	return newFuncWebRTCCallQueueOption(func(o *webrtcCallQueueOptions) {
+		o.maxHostAnswerers = maxHostAnswerers
+	})
+}
+
+// WithRedisCallQueueKeyPrefix returns a WebRTCCallQueueOption which sets what every key and
+// channel of a Redis queue starts with, so that separate queues can share a Redis database.
+// It has no effect on other queues.
+func WithRedisCallQueueKeyPrefix(prefix string) WebRTCCallQueueOption {
+	return newFuncWebRTCCallQueueOption(func(o *webrtcCallQueueOptions) {
+		o.redisKeyPrefix = prefix
+	})
+}
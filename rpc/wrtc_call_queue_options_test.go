@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestWebRTCCallQueueOptions(t *testing.T) {
+	var defaultOpts webrtcCallQueueOptions
+	test.That(t, defaultOpts.getOfferDeadline(), test.ShouldEqual, getDefaultOfferDeadline())
+	test.That(t, defaultOpts.getOfferCloseToDeadline(), test.ShouldEqual, getDefaultOfferCloseToDeadline())
+	test.That(t, defaultOpts.getMaxHostAnswerers(), test.ShouldEqual, uint64(maxHostAnswerersSize*2))
+	test.That(t, defaultOpts.getRedisKeyPrefix(), test.ShouldEqual, defaultRedisWebRTCCallQueueKeyPrefix)
+
+	var opts webrtcCallQueueOptions
+	for _, opt := range []WebRTCCallQueueOption{
+		WithCallQueueOfferDeadline(time.Minute),
+		WithCallQueueMaxHostAnswerers(10),
+		WithRedisCallQueueKeyPrefix("test:"),
+	} {
+		opt.apply(&opts)
+	}
+	test.That(t, opts.getOfferDeadline(), test.ShouldEqual, time.Minute)
+	test.That(t, opts.getOfferCloseToDeadline(), test.ShouldEqual, 12*time.Second)
+	test.That(t, opts.getMaxHostAnswerers(), test.ShouldEqual, uint64(10))
+	test.That(t, opts.getRedisKeyPrefix(), test.ShouldEqual, "test:")
+}
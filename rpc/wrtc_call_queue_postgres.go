@@ -0,0 +1,1037 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pion/webrtc/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/utils"
+)
+
+// A postgresWebRTCCallQueue is a PostgreSQL implementation of a call queue designed to be used for
+// multi-node, distributed deployments. Calls are rows that a trigger announces changes to with
+// NOTIFY; each operator LISTENs on one connection and routes the notifications to its callers and
+// answerers, who then read the latest state of their call.
+type postgresWebRTCCallQueue struct {
+	operatorID              string
+	opts                    webrtcCallQueueOptions
+	maxHostCallers          uint64
+	pool                    *pgxpool.Pool
+	activeBackgroundWorkers sync.WaitGroup
+	logger                  golog.Logger
+
+	cancelCtx  context.Context
+	cancelFunc func()
+
+	subsMu sync.Mutex
+	// 1 caller/answerer -> 1 caller id -> 1 notification
+	callExchangeSubs map[string]map[*postgresCallExchange]struct{}
+	// M answerer -> N hosts -> 1 notification
+	waitingForNewCallSubs map[string]map[chan struct{}]struct{}
+
+	// offers sent through this operator that have yet to be answered
+	pendingOffers atomic.Int64
+
+	// function to update access times on robot parts based on this call queue
+	activeAnswerersfunc func(hostnames []string)
+}
+
+type postgresCallExchange struct {
+	Host    string
+	Changed chan struct{} // expected buffered cap 1
+	Side    string        // "caller" or "answerer"
+}
+
+// Table and channel names used by the postgresWebRTCCallQueue.
+const (
+	postgresWebRTCCallQueueCallsTable         = "webrtc_calls"
+	postgresWebRTCCallQueueOperatorsTable     = "webrtc_operators"
+	postgresWebRTCCallQueueOperatorHostsTable = "webrtc_operator_hosts"
+	postgresWebRTCCallQueueChannel            = "webrtc_calls"
+
+	// taken while making sure the schema exists so that operators starting at once do not collide.
+	postgresWebRTCCallQueueSchemaLockID = 0x77727463
+)
+
+var postgresWebRTCCallQueueSchema = []string{
+	`CREATE TABLE IF NOT EXISTS ` + postgresWebRTCCallQueueCallsTable + ` (
+		id text PRIMARY KEY,
+		caller_operator_id text NOT NULL,
+		answerer_operator_id text NOT NULL DEFAULT '',
+		host text NOT NULL,
+		started_at timestamptz NOT NULL,
+		deadline timestamptz NOT NULL,
+		caller_sdp text NOT NULL,
+		caller_candidates jsonb NOT NULL DEFAULT '[]',
+		caller_done boolean NOT NULL DEFAULT false,
+		caller_error text NOT NULL DEFAULT '',
+		disable_trickle boolean NOT NULL DEFAULT false,
+		ice_restart_uuid text NOT NULL DEFAULT '',
+		answered boolean NOT NULL DEFAULT false,
+		answerer_sdp text NOT NULL DEFAULT '',
+		answerer_candidates jsonb NOT NULL DEFAULT '[]',
+		answerer_done boolean NOT NULL DEFAULT false,
+		answerer_error text NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS ` + postgresWebRTCCallQueueCallsTable + `_host_started_at
+		ON ` + postgresWebRTCCallQueueCallsTable + ` (host, started_at)`,
+	`CREATE INDEX IF NOT EXISTS ` + postgresWebRTCCallQueueCallsTable + `_deadline
+		ON ` + postgresWebRTCCallQueueCallsTable + ` (deadline)`,
+	`CREATE TABLE IF NOT EXISTS ` + postgresWebRTCCallQueueOperatorsTable + ` (
+		id text PRIMARY KEY,
+		expire_at timestamptz NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS ` + postgresWebRTCCallQueueOperatorHostsTable + ` (
+		operator_id text NOT NULL REFERENCES ` + postgresWebRTCCallQueueOperatorsTable + ` (id) ON DELETE CASCADE,
+		host text NOT NULL,
+		caller_size bigint NOT NULL,
+		answerer_size bigint NOT NULL,
+		PRIMARY KEY (operator_id, host)
+	)`,
+	`CREATE INDEX IF NOT EXISTS ` + postgresWebRTCCallQueueOperatorHostsTable + `_host
+		ON ` + postgresWebRTCCallQueueOperatorHostsTable + ` (host)`,
+	`CREATE OR REPLACE FUNCTION ` + postgresWebRTCCallQueueCallsTable + `_notify() RETURNS trigger AS $$
+	BEGIN
+		PERFORM pg_notify('` + postgresWebRTCCallQueueChannel + `', json_build_object(
+			'id', NEW.id,
+			'host', NEW.host,
+			'new', TG_OP = 'INSERT'
+		)::text);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`,
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (
+			SELECT 1 FROM pg_trigger
+			WHERE tgname = '` + postgresWebRTCCallQueueCallsTable + `_notify'
+			AND tgrelid = '` + postgresWebRTCCallQueueCallsTable + `'::regclass
+		) THEN
+			CREATE TRIGGER ` + postgresWebRTCCallQueueCallsTable + `_notify
+				AFTER INSERT OR UPDATE ON ` + postgresWebRTCCallQueueCallsTable + `
+				FOR EACH ROW EXECUTE FUNCTION ` + postgresWebRTCCallQueueCallsTable + `_notify();
+		END IF;
+	END;
+	$$`,
+}
+
+// NewPostgresWebRTCCallQueue returns a new PostgreSQL based call queue where calls are transferred
+// through the given pool, creating the tables it needs if they do not exist yet. It works the
+// same way the MongoDB queue does: the operator ID must be unique (e.g. a hostname, container
+// ID, UUID, etc.) and the max queue size for a host is an approximation shared by all
+// operators. One connection from the pool is held for as long as the queue is open in order to
+// LISTEN for changes to calls; if it cannot LISTEN at first, an error is returned. Calls are
+// deleted once their offer expires, which is what a TTL index does for the MongoDB queue. Each
+// call stores its own deadline so that operators with different offer deadlines still agree on
+// when a call expires.
+func NewPostgresWebRTCCallQueue(
+	ctx context.Context,
+	operatorID string,
+	maxHostCallers uint64,
+	pool *pgxpool.Pool,
+	logger golog.Logger,
+	activeAnswerersfunc func(hostnames []string),
+	opts ...WebRTCCallQueueOption,
+) (WebRTCCallQueue, error) {
+	if operatorID == "" {
+		return nil, errors.New("expected non-empty operatorID")
+	}
+	var qOpts webrtcCallQueueOptions
+	for _, opt := range opts {
+		opt.apply(&qOpts)
+	}
+
+	if err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresWebRTCCallQueueSchemaLockID); err != nil {
+			return err
+		}
+		for _, stmt := range postgresWebRTCCallQueueSchema {
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO `+postgresWebRTCCallQueueOperatorsTable+` (id, expire_at) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET expire_at = EXCLUDED.expire_at`,
+		operatorID, time.Now().Add(operatorHeartbeatWindow),
+	); err != nil {
+		return nil, err
+	}
+
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	queue := &postgresWebRTCCallQueue{
+		operatorID:     operatorID,
+		opts:           qOpts,
+		maxHostCallers: maxHostCallers,
+		pool:           pool,
+		cancelCtx:      cancelCtx,
+		cancelFunc:     cancelFunc,
+		logger:         logger.With("operator_id", operatorID),
+
+		callExchangeSubs:      map[string]map[*postgresCallExchange]struct{}{},
+		waitingForNewCallSubs: map[string]map[chan struct{}]struct{}{},
+		activeAnswerersfunc:   activeAnswerersfunc,
+	}
+
+	// wait to be listening once before we start processing anything so that no
+	// change to a call goes unnoticed.
+	listened := make(chan error, 1)
+	var listenedOnce sync.Once
+
+	queue.activeBackgroundWorkers.Add(3)
+	utils.ManagedGo(queue.operatorLivenessLoop, queue.activeBackgroundWorkers.Done)
+	utils.ManagedGo(queue.expirationLoop, queue.activeBackgroundWorkers.Done)
+	utils.ManagedGo(func() {
+		queue.notificationListener(func(err error) {
+			listenedOnce.Do(func() {
+				listened <- err
+			})
+		})
+	}, queue.activeBackgroundWorkers.Done)
+
+	select {
+	case <-ctx.Done():
+		return nil, multierr.Combine(queue.Close(), ctx.Err())
+	case err := <-listened:
+		if err != nil {
+			return nil, multierr.Combine(err, queue.Close())
+		}
+	}
+
+	return queue, nil
+}
+
+type postgresWebRTCCall struct {
+	ID                 string
+	CallerOperatorID   string
+	AnswererOperatorID string
+	Host               string
+	StartedAt          time.Time
+	Deadline           time.Time
+	CallerSDP          string
+	CallerCandidates   []webrtc.ICECandidateInit
+	CallerDone         bool
+	CallerError        string
+	DisableTrickle     bool
+	ICERestartUUID     string
+	Answered           bool
+	AnswererSDP        string
+	AnswererCandidates []webrtc.ICECandidateInit
+	AnswererDone       bool
+	AnswererError      string
+}
+
+const postgresWebRTCCallColumns = `id, caller_operator_id, answerer_operator_id, host, started_at, deadline,
+	caller_sdp, caller_candidates, caller_done, caller_error, disable_trickle, ice_restart_uuid,
+	answered, answerer_sdp, answerer_candidates, answerer_done, answerer_error`
+
+func scanPostgresWebRTCCall(row pgx.Row) (postgresWebRTCCall, error) {
+	var call postgresWebRTCCall
+	err := row.Scan(
+		&call.ID, &call.CallerOperatorID, &call.AnswererOperatorID, &call.Host, &call.StartedAt, &call.Deadline,
+		&call.CallerSDP, &call.CallerCandidates, &call.CallerDone, &call.CallerError, &call.DisableTrickle, &call.ICERestartUUID,
+		&call.Answered, &call.AnswererSDP, &call.AnswererCandidates, &call.AnswererDone, &call.AnswererError,
+	)
+	return call, err
+}
+
+func (queue *postgresWebRTCCallQueue) getCall(ctx context.Context, callID string) (postgresWebRTCCall, error) {
+	call, err := scanPostgresWebRTCCall(queue.pool.QueryRow(ctx,
+		`SELECT `+postgresWebRTCCallColumns+` FROM `+postgresWebRTCCallQueueCallsTable+` WHERE id = $1`,
+		callID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return postgresWebRTCCall{}, newInactiveOfferErr(callID)
+	}
+	return call, err
+}
+
+// The operatorLivenessLoop keeps the distributed queue aware of this operator's existence, in
+// addition to the hosts its listening to calls for, in order to keep track of eventually
+// consistent queue maximums.
+func (queue *postgresWebRTCCallQueue) operatorLivenessLoop() {
+	ticker := time.NewTicker(operatorStateUpdateInterval)
+	defer ticker.Stop()
+	for {
+		if !utils.SelectContextOrWaitChan(queue.cancelCtx, ticker.C) {
+			return
+		}
+		type callerAnswererQueueSizes struct {
+			Caller   int64
+			Answerer int64
+		}
+		queue.subsMu.Lock()
+		hosts := make(map[string]callerAnswererQueueSizes, len(queue.waitingForNewCallSubs)+len(queue.callExchangeSubs))
+		// an answerer waits on all of its hosts at once
+		waitingAnswerers := map[chan struct{}]struct{}{}
+		for host, waiting := range queue.waitingForNewCallSubs {
+			sizes := hosts[host]
+			sizes.Answerer += int64(len(waiting))
+			hosts[host] = sizes
+			for sub := range waiting {
+				waitingAnswerers[sub] = struct{}{}
+			}
+		}
+		for _, exchanges := range queue.callExchangeSubs {
+			for exchange := range exchanges {
+				sizes := hosts[exchange.Host]
+				if exchange.Side == "caller" {
+					sizes.Caller++
+				} else {
+					sizes.Answerer++
+				}
+				hosts[exchange.Host] = sizes
+			}
+		}
+		queue.subsMu.Unlock()
+		callQueuePendingOffers.Set(queue.operatorID, queue.pendingOffers.Load())
+		callQueueConnectedAnswerers.Set(queue.operatorID, int64(len(waitingAnswerers)))
+
+		hostNames := make([]string, 0, len(hosts))
+		callerSizes := make([]int64, 0, len(hosts))
+		answererSizes := make([]int64, 0, len(hosts))
+		hostsWithAnswerers := make([]string, 0)
+		for host, sizes := range hosts {
+			if sizes.Answerer >= 1 {
+				hostsWithAnswerers = append(hostsWithAnswerers, host)
+			}
+			hostNames = append(hostNames, host)
+			callerSizes = append(callerSizes, sizes.Caller)
+			answererSizes = append(answererSizes, sizes.Answerer)
+		}
+
+		if err := pgx.BeginFunc(queue.cancelCtx, queue.pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(queue.cancelCtx, `
+				INSERT INTO `+postgresWebRTCCallQueueOperatorsTable+` (id, expire_at) VALUES ($1, $2)
+				ON CONFLICT (id) DO UPDATE SET expire_at = EXCLUDED.expire_at`,
+				queue.operatorID, time.Now().Add(operatorHeartbeatWindow),
+			); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(queue.cancelCtx,
+				`DELETE FROM `+postgresWebRTCCallQueueOperatorHostsTable+` WHERE operator_id = $1`,
+				queue.operatorID,
+			); err != nil {
+				return err
+			}
+			_, err := tx.Exec(queue.cancelCtx, `
+				INSERT INTO `+postgresWebRTCCallQueueOperatorHostsTable+` (operator_id, host, caller_size, answerer_size)
+				SELECT $1, * FROM unnest($2::text[], $3::bigint[], $4::bigint[])`,
+				queue.operatorID, hostNames, callerSizes, answererSizes,
+			)
+			return err
+		}); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				queue.logger.Errorw("failed to update operator row for self", "error", err)
+			}
+		}
+
+		if queue.activeAnswerersfunc != nil && len(hostsWithAnswerers) > 0 {
+			queue.activeAnswerersfunc(hostsWithAnswerers)
+		}
+	}
+}
+
+// The expirationLoop deletes calls whose offers have expired and operators that are no longer
+// around. Any operator may do so.
+func (queue *postgresWebRTCCallQueue) expirationLoop() {
+	ticker := time.NewTicker(operatorHeartbeatWindow)
+	defer ticker.Stop()
+	for {
+		if !utils.SelectContextOrWaitChan(queue.cancelCtx, ticker.C) {
+			return
+		}
+		now := time.Now()
+		if _, err := queue.pool.Exec(queue.cancelCtx,
+			`DELETE FROM `+postgresWebRTCCallQueueCallsTable+` WHERE deadline < $1`,
+			now,
+		); err != nil && !errors.Is(err, context.Canceled) {
+			queue.logger.Errorw("failed to delete expired calls", "error", err)
+		}
+		if _, err := queue.pool.Exec(queue.cancelCtx,
+			`DELETE FROM `+postgresWebRTCCallQueueOperatorsTable+` WHERE expire_at < $1`,
+			now,
+		); err != nil && !errors.Is(err, context.Canceled) {
+			queue.logger.Errorw("failed to delete expired operators", "error", err)
+		}
+	}
+}
+
+// The notificationListener LISTENs for changes to calls on a dedicated connection and routes
+// them to subscribers. If the connection is lost, every subscriber is told to check on its
+// call since notifications may have been missed in the meantime. listened is called with the
+// result of every attempt to LISTEN.
+func (queue *postgresWebRTCCallQueue) notificationListener(listened func(err error)) {
+	for {
+		err := queue.listenForNotifications(func() {
+			listened(nil)
+		})
+		if err != nil && queue.cancelCtx.Err() == nil {
+			listened(err)
+			queue.logger.Errorw("error listening for call notifications; will retry", "error", err)
+		}
+		queue.notifyAllSubscribers()
+		if !utils.SelectContextOrWait(queue.cancelCtx, operatorStateUpdateInterval) {
+			return
+		}
+	}
+}
+
+func (queue *postgresWebRTCCallQueue) listenForNotifications(listening func()) error {
+	poolConn, err := queue.pool.Acquire(queue.cancelCtx)
+	if err != nil {
+		return err
+	}
+	// a listening connection must not go back to the pool
+	conn := poolConn.Hijack()
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		utils.UncheckedError(conn.Close(closeCtx))
+	}()
+
+	if _, err := conn.Exec(queue.cancelCtx, `LISTEN `+postgresWebRTCCallQueueChannel); err != nil {
+		return err
+	}
+	listening()
+
+	for {
+		notification, err := conn.WaitForNotification(queue.cancelCtx)
+		if err != nil {
+			return err
+		}
+		var changed struct {
+			ID   string `json:"id"`
+			Host string `json:"host"`
+			New  bool   `json:"new"`
+		}
+		if err := json.Unmarshal([]byte(notification.Payload), &changed); err != nil {
+			queue.logger.Errorw("failed to decode call notification", "error", err, "payload", notification.Payload)
+			continue
+		}
+		queue.subsMu.Lock()
+		if changed.New {
+			for sub := range queue.waitingForNewCallSubs[changed.Host] {
+				notifyChanged(sub)
+			}
+		} else {
+			for exchange := range queue.callExchangeSubs[changed.ID] {
+				notifyChanged(exchange.Changed)
+			}
+		}
+		queue.subsMu.Unlock()
+	}
+}
+
+func (queue *postgresWebRTCCallQueue) notifyAllSubscribers() {
+	queue.subsMu.Lock()
+	defer queue.subsMu.Unlock()
+	for _, subs := range queue.waitingForNewCallSubs {
+		for sub := range subs {
+			notifyChanged(sub)
+		}
+	}
+	for _, exchanges := range queue.callExchangeSubs {
+		for exchange := range exchanges {
+			notifyChanged(exchange.Changed)
+		}
+	}
+}
+
+// notifyChanged lets a subscriber know to check on what it is subscribed to, unless it is
+// already going to.
+func notifyChanged(changed chan<- struct{}) {
+	select {
+	case changed <- struct{}{}:
+	default:
+	}
+}
+
+// subscribeToCall subscribes to changes to the given call. Since all notifications go through
+// a single connection, once subscribed, reading the call and then waiting for a change will
+// always observe every update.
+func (queue *postgresWebRTCCallQueue) subscribeToCall(host, callID, side string) (<-chan struct{}, func()) {
+	queue.subsMu.Lock()
+	defer queue.subsMu.Unlock()
+
+	exchangeSubs, ok := queue.callExchangeSubs[callID]
+	if !ok {
+		exchangeSubs = map[*postgresCallExchange]struct{}{}
+		queue.callExchangeSubs[callID] = exchangeSubs
+	}
+	exchange := &postgresCallExchange{Host: host, Changed: make(chan struct{}, 1), Side: side}
+	exchangeSubs[exchange] = struct{}{}
+	return exchange.Changed, func() {
+		queue.subsMu.Lock()
+		defer queue.subsMu.Unlock()
+		delete(exchangeSubs, exchange)
+		if len(exchangeSubs) == 0 {
+			delete(queue.callExchangeSubs, callID)
+		}
+	}
+}
+
+// subscribeForNewCallOnHosts allows an answerer to subscribe for new calls on any of the given hosts.
+// The channel will have a value on it once any of the hosts may have a new call.
+func (queue *postgresWebRTCCallQueue) subscribeForNewCallOnHosts(hosts []string) (<-chan struct{}, func()) {
+	queue.subsMu.Lock()
+	defer queue.subsMu.Unlock()
+
+	newCall := make(chan struct{}, 1)
+	for _, host := range hosts {
+		hostSubs, ok := queue.waitingForNewCallSubs[host]
+		if !ok {
+			hostSubs = map[chan struct{}]struct{}{}
+			queue.waitingForNewCallSubs[host] = hostSubs
+		}
+		hostSubs[newCall] = struct{}{}
+	}
+	return newCall, func() {
+		queue.subsMu.Lock()
+		defer queue.subsMu.Unlock()
+		for _, host := range hosts {
+			delete(queue.waitingForNewCallSubs[host], newCall)
+			if len(queue.waitingForNewCallSubs[host]) == 0 {
+				delete(queue.waitingForNewCallSubs, host)
+			}
+		}
+	}
+}
+
+func (queue *postgresWebRTCCallQueue) checkHostQueueSize(ctx context.Context, forCaller bool, hosts ...string) error {
+	sizeColumn := "answerer_size"
+	maxSize := queue.opts.getMaxHostAnswerers()
+	if forCaller {
+		sizeColumn = "caller_size"
+		maxSize = queue.maxHostCallers
+	}
+	var tooMany bool
+	if err := queue.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM `+postgresWebRTCCallQueueOperatorHostsTable+` h
+			JOIN `+postgresWebRTCCallQueueOperatorsTable+` o ON o.id = h.operator_id
+			WHERE o.expire_at > $1 AND h.host = ANY($2)
+			GROUP BY h.host
+			HAVING sum(h.`+sizeColumn+`) >= $3
+		)`,
+		time.Now(), hosts, int64(maxSize),
+	).Scan(&tooMany); err != nil {
+		return err
+	}
+	if tooMany {
+		return errTooManyConns
+	}
+	return nil
+}
+
+// SendOfferInit initializes an offer associated with the given SDP to the given host.
+// It returns a UUID to track/authenticate the offer over time, the initial SDP for the
+// sender to start its peer connection with, as well as a channel to receive candidates on
+// over time.
+func (queue *postgresWebRTCCallQueue) SendOfferInit(
+	ctx context.Context,
+	host, sdp string,
+	disableTrickle bool,
+	iceRestartUUID string,
+) (string, <-chan WebRTCCallAnswer, <-chan struct{}, func(), error) {
+	if err := queue.checkHostQueueSize(ctx, true, host); err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	newUUID := uuid.NewString()
+	changed, unsubscribe := queue.subscribeToCall(host, newUUID, "caller")
+
+	startedAt := time.Now()
+	offerDeadline := startedAt.Add(queue.opts.getOfferDeadline())
+	sendCtx, sendCtxCancel := context.WithDeadline(ctx, offerDeadline)
+
+	// need to subscribe before insertion to avoid a race
+	sendAndQueueCtx, sendAndQueueCtxCancel := utils.MergeContext(sendCtx, queue.cancelCtx)
+
+	cleanup := func() {
+		sendAndQueueCtxCancel()
+		sendCtxCancel()
+		unsubscribe()
+	}
+	var successful bool
+	defer func() {
+		if successful {
+			return
+		}
+		cleanup()
+	}()
+
+	if _, err := queue.pool.Exec(sendAndQueueCtx, `
+		INSERT INTO `+postgresWebRTCCallQueueCallsTable+`
+		(id, caller_operator_id, host, started_at, deadline, caller_sdp, disable_trickle, ice_restart_uuid)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		newUUID, queue.operatorID, host, startedAt, offerDeadline, sdp, disableTrickle, iceRestartUUID,
+	); err != nil {
+		return "", nil, nil, nil, err
+	}
+	queue.pendingOffers.Add(1)
+
+	answererResponses := make(chan WebRTCCallAnswer, 1)
+	sendAnswer := func(answer WebRTCCallAnswer) bool {
+		select {
+		case <-sendAndQueueCtx.Done():
+			// try once more
+			select {
+			case answererResponses <- answer:
+			default:
+			}
+			return false
+		case answererResponses <- answer:
+			return true
+		}
+	}
+	queue.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer queue.activeBackgroundWorkers.Done()
+		defer cleanup()
+		defer close(answererResponses)
+
+		answered := false
+		defer func() {
+			if answered {
+				return
+			}
+			queue.pendingOffers.Add(-1)
+			if !time.Now().Before(offerDeadline) {
+				callQueueOffersExpired.Inc(queue.operatorID)
+			}
+		}()
+
+		haveInitSDP := false
+		candLen := 0
+		for {
+			callResp, err := queue.getCall(sendAndQueueCtx, newUUID)
+			if err != nil {
+				if sendAndQueueCtx.Err() != nil {
+					err = sendAndQueueCtx.Err()
+				}
+				sendAnswer(WebRTCCallAnswer{Err: err})
+				return
+			}
+
+			if !answered && callResp.Answered {
+				answered = true
+				queue.pendingOffers.Add(-1)
+			}
+
+			if callResp.AnswererError != "" {
+				sendAnswer(WebRTCCallAnswer{Err: errors.New(callResp.AnswererError)})
+				return
+			}
+
+			if !haveInitSDP && callResp.AnswererSDP != "" {
+				haveInitSDP = true
+				if !sendAnswer(WebRTCCallAnswer{InitialSDP: &callResp.AnswererSDP}) {
+					return
+				}
+			}
+
+			for ; candLen < len(callResp.AnswererCandidates); candLen++ {
+				cand := callResp.AnswererCandidates[candLen]
+				if !sendAnswer(WebRTCCallAnswer{Candidate: &cand}) {
+					return
+				}
+			}
+
+			if callResp.AnswererDone {
+				return
+			}
+
+			select {
+			case <-sendAndQueueCtx.Done():
+				sendAnswer(WebRTCCallAnswer{Err: sendAndQueueCtx.Err()})
+				return
+			case <-changed:
+			}
+		}
+	})
+	successful = true
+	return newUUID, answererResponses, sendAndQueueCtx.Done(), sendAndQueueCtxCancel, nil
+}
+
+// updatePostgresCall sets columns of the call with the given ID and host, returning an inactive
+// offer error if there is no such call or the condition does not hold. Arguments start at $3.
+func updatePostgresCall(ctx context.Context, pool *pgxpool.Pool, callID, host, set, condition string, args ...interface{}) error {
+	query := `UPDATE ` + postgresWebRTCCallQueueCallsTable + ` SET ` + set + ` WHERE id = $1 AND host = $2`
+	if condition != "" {
+		query += ` AND ` + condition
+	}
+	result, err := pool.Exec(ctx, query, append([]interface{}{callID, host}, args...)...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return newInactiveOfferErr(callID)
+	}
+	return nil
+}
+
+func postgresICECandidate(candidate *webrtc.ICECandidateInit) (string, error) {
+	candJSON, err := json.Marshal(candidate)
+	if err != nil {
+		return "", err
+	}
+	return string(candJSON), nil
+}
+
+// SendOfferUpdate updates the offer associated with the given UUID with a newly discovered
+// ICE candidate.
+func (queue *postgresWebRTCCallQueue) SendOfferUpdate(ctx context.Context, host, uuid string, candidate webrtc.ICECandidateInit) error {
+	cand, err := postgresICECandidate(&candidate)
+	if err != nil {
+		return err
+	}
+	return updatePostgresCall(ctx, queue.pool, uuid, host,
+		`caller_candidates = caller_candidates || jsonb_build_array($3::jsonb)`, "", cand)
+}
+
+// SendOfferDone informs the queue that the offer associated with the UUID is done sending any
+// more information.
+func (queue *postgresWebRTCCallQueue) SendOfferDone(ctx context.Context, host, uuid string) error {
+	return updatePostgresCall(ctx, queue.pool, uuid, host, `caller_done = true`, "")
+}
+
+// SendOfferError informs the queue that the offer associated with the UUID has encountered
+// an error from the sender side.
+func (queue *postgresWebRTCCallQueue) SendOfferError(ctx context.Context, host, uuid string, err error) error {
+	return updatePostgresCall(ctx, queue.pool, uuid, host, `caller_error = $3`, `NOT caller_done`, err.Error())
+}
+
+// RecvOffer receives the next offer for the given host. It should respond with an answer
+// once a decision is made.
+func (queue *postgresWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []string) (WebRTCCallOfferExchange, error) {
+	if err := queue.checkHostQueueSize(ctx, false, hosts...); err != nil {
+		return nil, err
+	}
+
+	recvOfferCtx, recvOfferCtxCancel := utils.MergeContext(ctx, queue.cancelCtx)
+	callReq, err := queue.waitForNewCall(recvOfferCtx, hosts)
+	recvOfferCtxCancel()
+	if err != nil {
+		return nil, err
+	}
+	observeOfferWaitTime(queue.operatorID, callReq.StartedAt)
+
+	changed, exchangeUnsubscribe := queue.subscribeToCall(callReq.Host, callReq.ID, "answerer")
+
+	// the caller decided the deadline, which may differ from ours
+	offerDeadline := callReq.Deadline
+
+	recvCtx, recvCtxCancel := utils.MergeContextWithDeadline(ctx, queue.cancelCtx, offerDeadline)
+
+	cleanup := func() {
+		recvCtxCancel()
+		exchangeUnsubscribe()
+	}
+
+	callerDoneCtx, callerDoneCancel := context.WithCancel(context.Background())
+	exchange := postgresWebRTCCallOfferExchange{
+		call:             callReq,
+		pool:             queue.pool,
+		callerCandidates: make(chan webrtc.ICECandidateInit),
+		callerDoneCtx:    callerDoneCtx,
+		deadline:         offerDeadline,
+	}
+	setErr := func(errToSet error) {
+		if !(errors.Is(errToSet, context.Canceled) || errors.Is(errToSet, context.DeadlineExceeded)) {
+			queue.logger.Errorw("error in RecvOffer", "error", errToSet, "id", callReq.ID)
+		}
+		// we assume the number of goroutines is bounded by the gRPC server invoking this method.
+		queue.activeBackgroundWorkers.Add(1)
+		utils.PanicCapturingGo(func() {
+			defer queue.activeBackgroundWorkers.Done()
+
+			// we need a dedicated timeout since even if the server is shutting down,
+			// we want to notify other servers immediately, instead of waiting for a timeout.
+			updateCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			err := updatePostgresCall(updateCtx, queue.pool, callReq.ID, callReq.Host, `answerer_error = $3`, "", errToSet.Error())
+			if err == nil {
+				return
+			}
+			var errInactive inactiveOfferError
+			if !errors.As(err, &errInactive) {
+				queue.logger.Errorw("error updating error for RecvOffer", "error", errToSet, "id", callReq.ID)
+			}
+		})
+	}
+	sendCandidate := func(cand webrtc.ICECandidateInit) bool {
+		select {
+		case <-recvCtx.Done():
+			// try once more
+			select {
+			case exchange.callerCandidates <- cand:
+			default:
+			}
+			return false
+		case exchange.callerCandidates <- cand:
+			return true
+		}
+	}
+	queue.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer queue.activeBackgroundWorkers.Done()
+		defer callerDoneCancel()
+		defer cleanup()
+
+		candLen := 0
+		latestReq := callReq
+		for {
+			if latestReq.CallerError != "" {
+				exchange.callerErr = errors.New(latestReq.CallerError)
+				return
+			}
+
+			for ; candLen < len(latestReq.CallerCandidates); candLen++ {
+				if !sendCandidate(latestReq.CallerCandidates[candLen]) {
+					return
+				}
+			}
+
+			if latestReq.CallerDone {
+				return
+			}
+
+			select {
+			case <-recvCtx.Done():
+				setErr(recvCtx.Err())
+				return
+			case <-changed:
+			}
+
+			var err error
+			latestReq, err = queue.getCall(recvCtx, callReq.ID)
+			if err != nil {
+				var errInactive inactiveOfferError
+				if errors.As(err, &errInactive) {
+					exchange.callerErr = errors.New("offer expired")
+					return
+				}
+				if recvCtx.Err() != nil {
+					err = recvCtx.Err()
+				}
+				setErr(err)
+				return
+			}
+		}
+	})
+	return &exchange, nil
+}
+
+// waitForNewCall answers the next call on any of the given hosts.
+func (queue *postgresWebRTCCallQueue) waitForNewCall(ctx context.Context, hosts []string) (postgresWebRTCCall, error) {
+	newCall, unsubscribe := queue.subscribeForNewCallOnHosts(hosts)
+	defer unsubscribe()
+
+	for {
+		// See RecvOffer on the MongoDB queue for why the window is smaller than the deadline.
+		deadlineWindow := time.Now().Add(queue.opts.getOfferCloseToDeadline())
+
+		// rows being taken by another answerer are skipped rather than waited on.
+		callReq, err := scanPostgresWebRTCCall(queue.pool.QueryRow(ctx, `
+			UPDATE `+postgresWebRTCCallQueueCallsTable+` SET answerer_operator_id = $1, answered = true
+			WHERE id = (
+				SELECT id FROM `+postgresWebRTCCallQueueCallsTable+`
+				WHERE host = ANY($2) AND caller_error = '' AND NOT answered AND deadline > $3
+				ORDER BY started_at
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+postgresWebRTCCallColumns,
+			queue.operatorID, hosts, deadlineWindow,
+		))
+		if err == nil {
+			return callReq, nil
+		}
+		if ctx.Err() != nil {
+			return postgresWebRTCCall{}, ctx.Err()
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return postgresWebRTCCall{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return postgresWebRTCCall{}, ctx.Err()
+		case <-newCall:
+		}
+	}
+}
+
+// Stats returns the hosts with offers pending or answerers connected, the operators sharing
+// the queue and the calls in flight, as stored in PostgreSQL.
+func (queue *postgresWebRTCCallQueue) Stats(ctx context.Context) (WebRTCCallQueueSnapshot, error) {
+	now := time.Now()
+	operatorRows, err := queue.pool.Query(ctx, `
+		SELECT o.id, o.expire_at, h.host, h.caller_size, h.answerer_size
+		FROM `+postgresWebRTCCallQueueOperatorsTable+` o
+		LEFT JOIN `+postgresWebRTCCallQueueOperatorHostsTable+` h ON h.operator_id = o.id
+		WHERE o.expire_at > $1
+		ORDER BY o.id`,
+		now,
+	)
+	if err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	var snapshot WebRTCCallQueueSnapshot
+	for operatorRows.Next() {
+		var (
+			operatorID   string
+			expireAt     time.Time
+			host         *string
+			callerSize   *int64
+			answererSize *int64
+		)
+		if err := operatorRows.Scan(&operatorID, &expireAt, &host, &callerSize, &answererSize); err != nil {
+			operatorRows.Close()
+			return WebRTCCallQueueSnapshot{}, err
+		}
+		if len(snapshot.Operators) == 0 || snapshot.Operators[len(snapshot.Operators)-1].ID != operatorID {
+			snapshot.Operators = append(snapshot.Operators, WebRTCCallQueueOperatorStats{ID: operatorID, ExpiresAt: expireAt})
+		}
+		if host == nil {
+			continue
+		}
+		operatorStats := &snapshot.Operators[len(snapshot.Operators)-1]
+		operatorStats.Hosts = append(operatorStats.Hosts, WebRTCCallQueueOperatorHostStats{
+			Host:      *host,
+			Callers:   uint64(*callerSize),
+			Answerers: uint64(*answererSize),
+		})
+	}
+	if err := operatorRows.Err(); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+
+	callRows, err := queue.pool.Query(ctx, `
+		SELECT id, host, started_at, deadline, answered, caller_operator_id, answerer_operator_id
+		FROM `+postgresWebRTCCallQueueCallsTable+`
+		WHERE deadline > $1 AND caller_error = ''`,
+		now,
+	)
+	if err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	for callRows.Next() {
+		var call WebRTCCallQueueCallStats
+		if err := callRows.Scan(
+			&call.UUID, &call.Host, &call.StartedAt, &call.Deadline, &call.Answered, &call.CallerOperatorID, &call.AnswererOperatorID,
+		); err != nil {
+			callRows.Close()
+			return WebRTCCallQueueSnapshot{}, err
+		}
+		snapshot.Calls = append(snapshot.Calls, call)
+	}
+	if err := callRows.Err(); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+
+	snapshot.Hosts = webrtcCallQueueHostStatsFromCalls(snapshot.Calls, snapshot.Operators)
+	sortWebRTCCallQueueSnapshot(&snapshot)
+	return snapshot, nil
+}
+
+// Close cancels all active offers and waits to cleanly close all background workers.
+func (queue *postgresWebRTCCallQueue) Close() error {
+	queue.cancelFunc()
+	queue.activeBackgroundWorkers.Wait()
+
+	// let other operators know right away that this one is gone
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := queue.pool.Exec(ctx, `DELETE FROM `+postgresWebRTCCallQueueOperatorsTable+` WHERE id = $1`, queue.operatorID)
+	return err
+}
+
+type postgresWebRTCCallOfferExchange struct {
+	call             postgresWebRTCCall
+	pool             *pgxpool.Pool
+	callerCandidates chan webrtc.ICECandidateInit
+	callerDoneCtx    context.Context
+	callerErr        error
+	deadline         time.Time
+}
+
+func (resp *postgresWebRTCCallOfferExchange) UUID() string {
+	return resp.call.ID
+}
+
+func (resp *postgresWebRTCCallOfferExchange) SDP() string {
+	return resp.call.CallerSDP
+}
+
+func (resp *postgresWebRTCCallOfferExchange) DisableTrickleICE() bool {
+	return resp.call.DisableTrickle
+}
+
+func (resp *postgresWebRTCCallOfferExchange) ICERestartUUID() string {
+	return resp.call.ICERestartUUID
+}
+
+func (resp *postgresWebRTCCallOfferExchange) Deadline() time.Time {
+	return resp.deadline
+}
+
+func (resp *postgresWebRTCCallOfferExchange) CallerCandidates() <-chan webrtc.ICECandidateInit {
+	return resp.callerCandidates
+}
+
+func (resp *postgresWebRTCCallOfferExchange) CallerDone() <-chan struct{} {
+	return resp.callerDoneCtx.Done()
+}
+
+func (resp *postgresWebRTCCallOfferExchange) CallerErr() error {
+	if resp.callerDoneCtx.Err() == nil {
+		return nil
+	}
+	if resp.callerErr != nil {
+		return resp.callerErr
+	}
+	if errors.Is(resp.callerDoneCtx.Err(), context.Canceled) {
+		return nil
+	}
+	return resp.callerDoneCtx.Err()
+}
+
+func (resp *postgresWebRTCCallOfferExchange) AnswererRespond(ctx context.Context, ans WebRTCCallAnswer) error {
+	switch {
+	case ans.InitialSDP != nil:
+		return updatePostgresCall(ctx, resp.pool, resp.call.ID, resp.call.Host, `answerer_sdp = $3`, "", *ans.InitialSDP)
+	case ans.Candidate != nil:
+		cand, err := postgresICECandidate(ans.Candidate)
+		if err != nil {
+			return err
+		}
+		return updatePostgresCall(ctx, resp.pool, resp.call.ID, resp.call.Host,
+			`answerer_candidates = answerer_candidates || jsonb_build_array($3::jsonb)`, "", cand)
+	case ans.Err != nil:
+		return updatePostgresCall(ctx, resp.pool, resp.call.ID, resp.call.Host, `answerer_error = $3`, "", ans.Err.Error())
+	default:
+		return errors.New("expected either SDP, ICE candidate, or error to be set")
+	}
+}
+
+func (resp *postgresWebRTCCallOfferExchange) AnswererDone(ctx context.Context) error {
+	return updatePostgresCall(ctx, resp.pool, resp.call.ID, resp.call.Host, `answerer_done = true`, `NOT answerer_done`)
+}
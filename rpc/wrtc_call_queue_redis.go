@@ -0,0 +1,1155 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/google/uuid"
+	"github.com/pion/webrtc/v3"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/multierr"
+
+	"go.viam.com/utils"
+)
+
+// A redisWebRTCCallQueue is a Redis implementation of a call queue designed to be used for
+// multi-node, distributed deployments. Calls are hashes that expire along with their offer, each
+// with a stream of the events both sides add to it. Offers waiting to be answered are kept in a
+// sorted set per host and answerers are told about new ones over pub/sub.
+type redisWebRTCCallQueue struct {
+	operatorID              string
+	opts                    webrtcCallQueueOptions
+	maxHostCallers          uint64
+	client                  redis.UniversalClient
+	keys                    redisWebRTCCallQueueKeys
+	pubSub                  *redis.PubSub
+	activeBackgroundWorkers sync.WaitGroup
+	logger                  golog.Logger
+
+	cancelCtx  context.Context
+	cancelFunc func()
+
+	mu sync.Mutex
+	// host -> number of callers and answerers in an exchange on this operator
+	callExchanges map[string]redisHostQueueSizes
+	// M answerer -> N hosts -> 1 subscription
+	waitingForNewCallSubs map[string]map[chan struct{}]struct{}
+	// call -> callers and answerers reading its events
+	callEventSubs map[string]map[chan struct{}]struct{}
+
+	// offers sent through this operator that have yet to be answered
+	pendingOffers atomic.Int64
+
+	// function to update access times on robot parts based on this call queue
+	activeAnswerersfunc func(hostnames []string)
+}
+
+type redisHostQueueSizes struct {
+	Caller   uint64
+	Answerer uint64
+}
+
+// The key prefix used by the redisWebRTCCallQueue unless one is given with
+// WithRedisCallQueueKeyPrefix.
+const defaultRedisWebRTCCallQueueKeyPrefix = "rpc:webrtc:"
+
+// redisWebRTCCallQueueKeys is the prefix that every key and channel of a redisWebRTCCallQueue
+// starts with.
+type redisWebRTCCallQueueKeys string
+
+func (prefix redisWebRTCCallQueueKeys) call(callID string) string {
+	return string(prefix) + "calls:" + callID
+}
+
+func (prefix redisWebRTCCallQueueKeys) callEvents(callID string) string {
+	return prefix.call(callID) + ":events"
+}
+
+func (prefix redisWebRTCCallQueueKeys) callChangedChannel(callID string) string {
+	return prefix.call(callID) + ":changed"
+}
+
+func (prefix redisWebRTCCallQueueKeys) hostOffers(host string) string {
+	return string(prefix) + "hosts:" + host + ":offers"
+}
+
+func (prefix redisWebRTCCallQueueKeys) hostNewCallsChannel(host string) string {
+	return string(prefix) + "hosts:" + host + ":new_calls"
+}
+
+func (prefix redisWebRTCCallQueueKeys) operators() string {
+	return string(prefix) + "operators"
+}
+
+func (prefix redisWebRTCCallQueueKeys) operatorHosts(operatorID string) string {
+	return prefix.operators() + ":" + operatorID + ":hosts"
+}
+
+func redisHostCallerSizeField(host string) string {
+	return webrtcOperatorHostsCallerSizeField + ":" + host
+}
+
+func redisHostAnswererSizeField(host string) string {
+	return webrtcOperatorHostsAnswererSizeField + ":" + host
+}
+
+const (
+	redisCallSideCaller   = "caller"
+	redisCallSideAnswerer = "answerer"
+
+	redisCallEventSideField      = "side"
+	redisCallEventSDPField       = "sdp"
+	redisCallEventCandidateField = "candidate"
+	redisCallEventDoneField      = "done"
+	redisCallEventErrorField     = "error"
+)
+
+// NewRedisWebRTCCallQueue returns a new Redis based call queue where calls are transferred
+// through the given client. It works the same way the MongoDB queue does: the operator ID must
+// be unique (e.g. a hostname, container ID, UUID, etc.) and the max queue size for a host is
+// an approximation shared by all operators. The client must talk to a single Redis server
+// (or a primary) since calls are looked up by scripts; a cluster is not supported. Callers
+// and answerers hear about each other through one pub/sub connection per queue, so waiting on
+// a call does not hold a connection from the client's pool. Operators sharing a Redis
+// database must use the same key prefix (see WithRedisCallQueueKeyPrefix).
+func NewRedisWebRTCCallQueue(
+	ctx context.Context,
+	operatorID string,
+	maxHostCallers uint64,
+	client redis.UniversalClient,
+	logger golog.Logger,
+	activeAnswerersfunc func(hostnames []string),
+	opts ...WebRTCCallQueueOption,
+) (WebRTCCallQueue, error) {
+	if operatorID == "" {
+		return nil, errors.New("expected non-empty operatorID")
+	}
+	var qOpts webrtcCallQueueOptions
+	for _, opt := range opts {
+		opt.apply(&qOpts)
+	}
+
+	keys := redisWebRTCCallQueueKeys(qOpts.getRedisKeyPrefix())
+	if err := client.ZAdd(ctx, keys.operators(), redis.Z{
+		Score:  float64(time.Now().Add(operatorHeartbeatWindow).UnixMilli()),
+		Member: operatorID,
+	}).Err(); err != nil {
+		return nil, err
+	}
+
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	queue := &redisWebRTCCallQueue{
+		operatorID:     operatorID,
+		opts:           qOpts,
+		maxHostCallers: maxHostCallers,
+		client:         client,
+		keys:           keys,
+		pubSub:         client.Subscribe(cancelCtx),
+		cancelCtx:      cancelCtx,
+		cancelFunc:     cancelFunc,
+		logger:         logger.With("operator_id", operatorID),
+
+		callExchanges:         map[string]redisHostQueueSizes{},
+		waitingForNewCallSubs: map[string]map[chan struct{}]struct{}{},
+		callEventSubs:         map[string]map[chan struct{}]struct{}{},
+		activeAnswerersfunc:   activeAnswerersfunc,
+	}
+
+	queue.activeBackgroundWorkers.Add(2)
+	utils.ManagedGo(queue.operatorLivenessLoop, queue.activeBackgroundWorkers.Done)
+	utils.ManagedGo(queue.pubSubDispatcher, queue.activeBackgroundWorkers.Done)
+
+	return queue, nil
+}
+
+// The operatorLivenessLoop keeps the distributed queue aware of this operator's existence, in
+// addition to the hosts its listening to calls for, in order to keep track of eventually
+// consistent queue maximums.
+func (queue *redisWebRTCCallQueue) operatorLivenessLoop() {
+	ticker := time.NewTicker(operatorStateUpdateInterval)
+	defer ticker.Stop()
+	for {
+		if !utils.SelectContextOrWaitChan(queue.cancelCtx, ticker.C) {
+			return
+		}
+
+		hostSizes := map[string]interface{}{}
+		hostsWithAnswerers := make([]string, 0)
+		queueSizes, waitingAnswerers := queue.hostQueueSizes()
+		callQueuePendingOffers.Set(queue.operatorID, queue.pendingOffers.Load())
+		callQueueConnectedAnswerers.Set(queue.operatorID, int64(waitingAnswerers))
+		for host, sizes := range queueSizes {
+			if sizes.Answerer >= 1 {
+				hostsWithAnswerers = append(hostsWithAnswerers, host)
+			}
+			hostSizes[redisHostCallerSizeField(host)] = sizes.Caller
+			hostSizes[redisHostAnswererSizeField(host)] = sizes.Answerer
+		}
+
+		now := time.Now()
+		expireAt := now.Add(operatorHeartbeatWindow)
+		hostsKey := queue.keys.operatorHosts(queue.operatorID)
+		if _, err := queue.client.TxPipelined(queue.cancelCtx, func(pipe redis.Pipeliner) error {
+			pipe.Del(queue.cancelCtx, hostsKey)
+			if len(hostSizes) != 0 {
+				pipe.HSet(queue.cancelCtx, hostsKey, hostSizes)
+				pipe.PExpireAt(queue.cancelCtx, hostsKey, expireAt)
+			}
+			pipe.ZAdd(queue.cancelCtx, queue.keys.operators(), redis.Z{
+				Score:  float64(expireAt.UnixMilli()),
+				Member: queue.operatorID,
+			})
+			// operators that stopped updating themselves are gone
+			pipe.ZRemRangeByScore(queue.cancelCtx, queue.keys.operators(), "-inf", strconv.FormatInt(now.UnixMilli(), 10))
+			return nil
+		}); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				queue.logger.Errorw("failed to update operator state for self", "error", err)
+			}
+		}
+
+		if queue.activeAnswerersfunc != nil && len(hostsWithAnswerers) > 0 {
+			queue.activeAnswerersfunc(hostsWithAnswerers)
+		}
+	}
+}
+
+// hostQueueSizes returns how many callers and answerers there are on this operator per host,
+// as well as how many answerers are waiting for a call. Answerers waiting for a call count
+// towards every host they are waiting on.
+func (queue *redisWebRTCCallQueue) hostQueueSizes() (map[string]redisHostQueueSizes, int) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	hosts := make(map[string]redisHostQueueSizes, len(queue.waitingForNewCallSubs)+len(queue.callExchanges))
+	waitingAnswerers := map[chan struct{}]struct{}{}
+	for host, waiting := range queue.waitingForNewCallSubs {
+		sizes := hosts[host]
+		sizes.Answerer += uint64(len(waiting))
+		hosts[host] = sizes
+		for sub := range waiting {
+			waitingAnswerers[sub] = struct{}{}
+		}
+	}
+	for host, exchangeSizes := range queue.callExchanges {
+		sizes := hosts[host]
+		sizes.Caller += exchangeSizes.Caller
+		sizes.Answerer += exchangeSizes.Answerer
+		hosts[host] = sizes
+	}
+	return hosts, len(waitingAnswerers)
+}
+
+// trackCallExchange counts a side of a call towards the host's queue size until the returned
+// function is called.
+func (queue *redisWebRTCCallQueue) trackCallExchange(host, side string) func() {
+	update := func(add bool) {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		sizes := queue.callExchanges[host]
+		size := &sizes.Answerer
+		if side == redisCallSideCaller {
+			size = &sizes.Caller
+		}
+		if add {
+			*size++
+		} else {
+			*size--
+		}
+		if sizes == (redisHostQueueSizes{}) {
+			delete(queue.callExchanges, host)
+			return
+		}
+		queue.callExchanges[host] = sizes
+	}
+	update(true)
+	var untrackOnce sync.Once
+	return func() {
+		untrackOnce.Do(func() {
+			update(false)
+		})
+	}
+}
+
+// pubSubDispatcher tells answerers waiting on a host that it has a new call and callers and
+// answerers in a call that the other side added events to it.
+func (queue *redisWebRTCCallQueue) pubSubDispatcher() {
+	msgs := queue.pubSub.Channel()
+	for {
+		var msg *redis.Message
+		select {
+		case <-queue.cancelCtx.Done():
+			return
+		case next, ok := <-msgs:
+			if !ok {
+				return
+			}
+			msg = next
+		}
+		var subs map[chan struct{}]struct{}
+		queue.mu.Lock()
+		if strings.HasPrefix(msg.Channel, queue.keys.call("")) {
+			callID := strings.TrimSuffix(strings.TrimPrefix(msg.Channel, queue.keys.call("")), ":changed")
+			subs = queue.callEventSubs[callID]
+		} else {
+			host := strings.TrimSuffix(strings.TrimPrefix(msg.Channel, string(queue.keys)+"hosts:"), ":new_calls")
+			subs = queue.waitingForNewCallSubs[host]
+		}
+		for sub := range subs {
+			select {
+			case sub <- struct{}{}:
+			default:
+			}
+		}
+		queue.mu.Unlock()
+	}
+}
+
+// subscribeForNewCallOnHosts allows an answerer to subscribe for new calls on any of the given
+// hosts. The channel will have a value on it once any of the hosts may have a new call.
+func (queue *redisWebRTCCallQueue) subscribeForNewCallOnHosts(
+	ctx context.Context,
+	hosts []string,
+) (<-chan struct{}, func(), error) {
+	return queue.subscribe(ctx, queue.waitingForNewCallSubs, hosts, queue.keys.hostNewCallsChannel)
+}
+
+// subscribeToCallEvents allows a caller or answerer to subscribe for events added to a call.
+// The channel will have a value on it once the call may have new events.
+func (queue *redisWebRTCCallQueue) subscribeToCallEvents(
+	ctx context.Context,
+	callID string,
+) (<-chan struct{}, func(), error) {
+	return queue.subscribe(ctx, queue.callEventSubs, []string{callID}, queue.keys.callChangedChannel)
+}
+
+// subscribe adds a subscription to subs for each of the given names, making sure that the
+// queue's pub/sub connection is subscribed to the channel of each name for as long as it has
+// any subscriptions.
+func (queue *redisWebRTCCallQueue) subscribe(
+	ctx context.Context,
+	subs map[string]map[chan struct{}]struct{},
+	names []string,
+	channelName func(name string) string,
+) (<-chan struct{}, func(), error) {
+	sub := make(chan struct{}, 1)
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	var channels []string
+	for _, name := range names {
+		nameSubs, ok := subs[name]
+		if !ok {
+			nameSubs = map[chan struct{}]struct{}{}
+			subs[name] = nameSubs
+			channels = append(channels, channelName(name))
+		}
+		nameSubs[sub] = struct{}{}
+	}
+
+	unsub := func() {
+		var channels []string
+		for _, name := range names {
+			delete(subs[name], sub)
+			if len(subs[name]) == 0 {
+				delete(subs, name)
+				channels = append(channels, channelName(name))
+			}
+		}
+		if len(channels) == 0 {
+			return
+		}
+		if err := queue.pubSub.Unsubscribe(queue.cancelCtx, channels...); err != nil && !errors.Is(err, context.Canceled) {
+			queue.logger.Debugw("failed to unsubscribe", "channels", channels, "error", err)
+		}
+	}
+
+	if len(channels) != 0 {
+		if err := queue.pubSub.Subscribe(ctx, channels...); err != nil {
+			unsub()
+			return nil, nil, err
+		}
+	}
+
+	return sub, func() {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		unsub()
+	}, nil
+}
+
+func (queue *redisWebRTCCallQueue) checkHostQueueSize(ctx context.Context, forCaller bool, hosts ...string) error {
+	maxSize := queue.opts.getMaxHostAnswerers()
+	sizeField := redisHostAnswererSizeField
+	if forCaller {
+		maxSize = queue.maxHostCallers
+		sizeField = redisHostCallerSizeField
+	}
+
+	operatorIDs, err := queue.client.ZRangeByScore(ctx, queue.keys.operators(), &redis.ZRangeBy{
+		Min: strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return err
+	}
+	if len(operatorIDs) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		fields = append(fields, sizeField(host))
+	}
+	cmds := make([]*redis.SliceCmd, 0, len(operatorIDs))
+	if _, err := queue.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, operatorID := range operatorIDs {
+			cmds = append(cmds, pipe.HMGet(ctx, queue.keys.operatorHosts(operatorID), fields...))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	sizes := make([]uint64, len(hosts))
+	found := make([]bool, len(hosts))
+	for _, cmd := range cmds {
+		for idx, val := range cmd.Val() {
+			sizeStr, ok := val.(string)
+			if !ok {
+				continue
+			}
+			size, err := strconv.ParseUint(sizeStr, 10, 64)
+			if err != nil {
+				return err
+			}
+			sizes[idx] += size
+			found[idx] = true
+		}
+	}
+	for idx, size := range sizes {
+		if found[idx] && size >= maxSize {
+			return errTooManyConns
+		}
+	}
+	return nil
+}
+
+type redisWebRTCCall struct {
+	ID             string
+	Host           string
+	StartedAt      time.Time
+	CallerSDP      string
+	DisableTrickle bool
+	ICERestartUUID string
+}
+
+const (
+	webrtcCallCallerSDPField      = "caller_sdp"
+	webrtcCallDisableTrickleField = "disable_trickle"
+	webrtcCallICERestartUUIDField = "ice_restart_uuid"
+)
+
+func (queue *redisWebRTCCallQueue) getCall(ctx context.Context, callID string) (redisWebRTCCall, error) {
+	fields, err := queue.client.HGetAll(ctx, queue.keys.call(callID)).Result()
+	if err != nil {
+		return redisWebRTCCall{}, err
+	}
+	if len(fields) == 0 {
+		return redisWebRTCCall{}, newInactiveOfferErr(callID)
+	}
+	startedAt, err := strconv.ParseInt(fields[webrtcCallStartedAtField], 10, 64)
+	if err != nil {
+		return redisWebRTCCall{}, err
+	}
+	disableTrickle, err := strconv.ParseBool(fields[webrtcCallDisableTrickleField])
+	if err != nil {
+		return redisWebRTCCall{}, err
+	}
+	return redisWebRTCCall{
+		ID:             callID,
+		Host:           fields[webrtcCallHostField],
+		StartedAt:      time.Unix(0, startedAt),
+		CallerSDP:      fields[webrtcCallCallerSDPField],
+		DisableTrickle: disableTrickle,
+		ICERestartUUID: fields[webrtcCallICERestartUUIDField],
+	}, nil
+}
+
+// A redisCallUpdate adds an event from one side of a call to its event stream.
+type redisCallUpdate struct {
+	Side  string
+	Event string
+	Value string
+	// If set, the update is only made if the call does not have this field set yet.
+	Unless string
+	// If set, this field of the call is set to the value.
+	Set string
+}
+
+// redisUpdateCallScript makes a redisCallUpdate so long as the call is still around for the
+// given host, returning whether or not it was made. Readers of the call's events are told
+// about it on the call's changed channel.
+//
+// KEYS: call, call events
+// ARGV: host, side, event, value, unless, set, call changed channel.
+var redisUpdateCallScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'host') ~= ARGV[1] then
+	return 0
+end
+if ARGV[5] ~= '' and redis.call('HEXISTS', KEYS[1], ARGV[5]) == 1 then
+	return 0
+end
+if ARGV[6] ~= '' then
+	redis.call('HSET', KEYS[1], ARGV[6], ARGV[4])
+end
+redis.call('XADD', KEYS[2], '*', 'side', ARGV[2], ARGV[3], ARGV[4])
+redis.call('PEXPIRE', KEYS[2], redis.call('PTTL', KEYS[1]))
+redis.call('PUBLISH', ARGV[7], ARGV[2])
+return 1
+`)
+
+func updateRedisCall(
+	ctx context.Context,
+	client redis.UniversalClient,
+	keys redisWebRTCCallQueueKeys,
+	host, callID string,
+	update redisCallUpdate,
+) error {
+	updated, err := redisUpdateCallScript.Run(
+		ctx,
+		client,
+		[]string{keys.call(callID), keys.callEvents(callID)},
+		host, update.Side, update.Event, update.Value, update.Unless, update.Set, keys.callChangedChannel(callID),
+	).Int()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return newInactiveOfferErr(callID)
+	}
+	return nil
+}
+
+// readCallEvents calls f with each event the given side adds to the call, in order, until f
+// returns false or the context is done. Rather than blocking on a connection of its own, it
+// reads whatever events there are each time the queue hears that the call changed.
+func (queue *redisWebRTCCallQueue) readCallEvents(
+	ctx context.Context,
+	callID, side string,
+	f func(event map[string]interface{}) bool,
+) error {
+	// subscribe before reading so that no event goes unnoticed
+	changed, unsubscribe, err := queue.subscribeToCallEvents(ctx, callID)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	// a subscription is not immediately in effect, so read every so often regardless
+	ticker := time.NewTicker(operatorStateUpdateInterval)
+	defer ticker.Stop()
+	lastID := "0"
+	for {
+		streams, err := queue.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{queue.keys.callEvents(callID), lastID},
+			Block:   -1,
+		}).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+				if msg.Values[redisCallEventSideField] != side {
+					continue
+				}
+				if !f(msg.Values) {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+func redisCallEventValue(event map[string]interface{}, field string) (string, bool) {
+	val, ok := event[field].(string)
+	return val, ok
+}
+
+// SendOfferInit initializes an offer associated with the given SDP to the given host.
+// It returns a UUID to track/authenticate the offer over time, the initial SDP for the
+// sender to start its peer connection with, as well as a channel to receive candidates on
+// over time.
+func (queue *redisWebRTCCallQueue) SendOfferInit(
+	ctx context.Context,
+	host, sdp string,
+	disableTrickle bool,
+	iceRestartUUID string,
+) (string, <-chan WebRTCCallAnswer, <-chan struct{}, func(), error) {
+	if err := queue.checkHostQueueSize(ctx, true, host); err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	newUUID := uuid.NewString()
+	untrack := queue.trackCallExchange(host, redisCallSideCaller)
+
+	startedAt := time.Now()
+	offerDeadline := startedAt.Add(queue.opts.getOfferDeadline())
+	sendCtx, sendCtxCancel := context.WithDeadline(ctx, offerDeadline)
+	sendAndQueueCtx, sendAndQueueCtxCancel := utils.MergeContext(sendCtx, queue.cancelCtx)
+
+	cleanup := func() {
+		sendAndQueueCtxCancel()
+		sendCtxCancel()
+		untrack()
+	}
+	var successful bool
+	defer func() {
+		if successful {
+			return
+		}
+		cleanup()
+	}()
+
+	callKey := queue.keys.call(newUUID)
+	offersKey := queue.keys.hostOffers(host)
+	if _, err := queue.client.TxPipelined(sendAndQueueCtx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(sendAndQueueCtx, callKey, map[string]interface{}{
+			webrtcCallHostField:               host,
+			webrtcCallCallerOperatorIDField:   queue.operatorID,
+			webrtcCallStartedAtField:          startedAt.UnixNano(),
+			webrtcCallCallerSDPField:          sdp,
+			webrtcCallDisableTrickleField:     strconv.FormatBool(disableTrickle),
+			webrtcCallICERestartUUIDField:     iceRestartUUID,
+			webrtcCallAnsweredField:           "0",
+			webrtcCallAnswererOperatorIDField: "",
+		})
+		pipe.PExpireAt(sendAndQueueCtx, callKey, offerDeadline)
+		pipe.ZAdd(sendAndQueueCtx, offersKey, redis.Z{Score: float64(startedAt.UnixMilli()), Member: newUUID})
+		pipe.PExpireAt(sendAndQueueCtx, offersKey, offerDeadline)
+		pipe.Publish(sendAndQueueCtx, queue.keys.hostNewCallsChannel(host), newUUID)
+		return nil
+	}); err != nil {
+		return "", nil, nil, nil, err
+	}
+	queue.pendingOffers.Add(1)
+
+	answererResponses := make(chan WebRTCCallAnswer, 1)
+	sendAnswer := func(answer WebRTCCallAnswer) bool {
+		select {
+		case <-sendAndQueueCtx.Done():
+			// try once more
+			select {
+			case answererResponses <- answer:
+			default:
+			}
+			return false
+		case answererResponses <- answer:
+			return true
+		}
+	}
+	queue.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer queue.activeBackgroundWorkers.Done()
+		defer cleanup()
+		defer close(answererResponses)
+
+		// only an answerer that picked up the offer adds events to it
+		answered := false
+		defer func() {
+			if answered {
+				return
+			}
+			queue.pendingOffers.Add(-1)
+			if !time.Now().Before(offerDeadline) {
+				callQueueOffersExpired.Inc(queue.operatorID)
+			}
+		}()
+
+		haveInitSDP := false
+		err := queue.readCallEvents(sendAndQueueCtx, newUUID, redisCallSideAnswerer, func(event map[string]interface{}) bool {
+			if !answered {
+				answered = true
+				queue.pendingOffers.Add(-1)
+			}
+			if answererErr, ok := redisCallEventValue(event, redisCallEventErrorField); ok {
+				sendAnswer(WebRTCCallAnswer{Err: errors.New(answererErr)})
+				return false
+			}
+			if answererSDP, ok := redisCallEventValue(event, redisCallEventSDPField); ok && !haveInitSDP {
+				haveInitSDP = true
+				return sendAnswer(WebRTCCallAnswer{InitialSDP: &answererSDP})
+			}
+			if candJSON, ok := redisCallEventValue(event, redisCallEventCandidateField); ok {
+				var cand webrtc.ICECandidateInit
+				if err := json.Unmarshal([]byte(candJSON), &cand); err != nil {
+					sendAnswer(WebRTCCallAnswer{Err: err})
+					return false
+				}
+				return sendAnswer(WebRTCCallAnswer{Candidate: &cand})
+			}
+			_, done := redisCallEventValue(event, redisCallEventDoneField)
+			return !done
+		})
+		if err != nil {
+			sendAnswer(WebRTCCallAnswer{Err: err})
+		}
+	})
+	successful = true
+	return newUUID, answererResponses, sendAndQueueCtx.Done(), sendAndQueueCtxCancel, nil
+}
+
+// SendOfferUpdate updates the offer associated with the given UUID with a newly discovered
+// ICE candidate.
+func (queue *redisWebRTCCallQueue) SendOfferUpdate(ctx context.Context, host, uuid string, candidate webrtc.ICECandidateInit) error {
+	candJSON, err := json.Marshal(candidate)
+	if err != nil {
+		return err
+	}
+	return updateRedisCall(ctx, queue.client, queue.keys, host, uuid, redisCallUpdate{
+		Side:  redisCallSideCaller,
+		Event: redisCallEventCandidateField,
+		Value: string(candJSON),
+	})
+}
+
+// SendOfferDone informs the queue that the offer associated with the UUID is done sending any
+// more information.
+func (queue *redisWebRTCCallQueue) SendOfferDone(ctx context.Context, host, uuid string) error {
+	return updateRedisCall(ctx, queue.client, queue.keys, host, uuid, redisCallUpdate{
+		Side:  redisCallSideCaller,
+		Event: redisCallEventDoneField,
+		Value: "1",
+		Set:   webrtcCallCallerDoneField,
+	})
+}
+
+// SendOfferError informs the queue that the offer associated with the UUID has encountered
+// an error from the sender side.
+func (queue *redisWebRTCCallQueue) SendOfferError(ctx context.Context, host, uuid string, err error) error {
+	return updateRedisCall(ctx, queue.client, queue.keys, host, uuid, redisCallUpdate{
+		Side:   redisCallSideCaller,
+		Event:  redisCallEventErrorField,
+		Value:  err.Error(),
+		Unless: webrtcCallCallerDoneField,
+		Set:    webrtcCallCallerErrorField,
+	})
+}
+
+// redisClaimCallScript answers the oldest call still worth answering on any of the given
+// hosts, dropping the ones that are not along the way. It returns the ID of the call, if any.
+//
+// KEYS: host offers...
+// ARGV: window start (ms), operator ID, call key prefix.
+var redisClaimCallScript = redis.NewScript(`
+for _, offersKey in ipairs(KEYS) do
+	redis.call('ZREMRANGEBYSCORE', offersKey, '-inf', '(' .. ARGV[1])
+	for _, callID in ipairs(redis.call('ZRANGE', offersKey, 0, -1)) do
+		redis.call('ZREM', offersKey, callID)
+		local callKey = ARGV[3] .. callID
+		if redis.call('HGET', callKey, 'answered') == '0' and redis.call('HEXISTS', callKey, 'caller_error') == 0 then
+			redis.call('HSET', callKey, 'answered', '1', 'answerer_operator_id', ARGV[2])
+			return callID
+		end
+	end
+end
+return false
+`)
+
+// waitForNewCall answers the next call on any of the given hosts.
+func (queue *redisWebRTCCallQueue) waitForNewCall(ctx context.Context, hosts []string) (redisWebRTCCall, error) {
+	// subscribe before looking so that no new call goes unnoticed
+	newCall, unsubscribe, err := queue.subscribeForNewCallOnHosts(ctx, hosts)
+	if err != nil {
+		return redisWebRTCCall{}, err
+	}
+	defer unsubscribe()
+
+	offersKeys := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		offersKeys = append(offersKeys, queue.keys.hostOffers(host))
+	}
+
+	// a subscription is not immediately in effect, so look every so often regardless
+	ticker := time.NewTicker(operatorStateUpdateInterval)
+	defer ticker.Stop()
+	for {
+		// See RecvOffer on the MongoDB queue for why the window is smaller than the deadline.
+		startedAtWindow := time.Now().Add(-queue.opts.getOfferDeadline()).Add(queue.opts.getOfferCloseToDeadline())
+		callID, err := redisClaimCallScript.Run(
+			ctx,
+			queue.client,
+			offersKeys,
+			startedAtWindow.UnixMilli(), queue.operatorID, queue.keys.call(""),
+		).Text()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			if ctx.Err() != nil {
+				return redisWebRTCCall{}, ctx.Err()
+			}
+			return redisWebRTCCall{}, err
+		}
+		if err == nil {
+			call, err := queue.getCall(ctx, callID)
+			if err == nil {
+				return call, nil
+			}
+			var errInactive inactiveOfferError
+			if !errors.As(err, &errInactive) {
+				return redisWebRTCCall{}, err
+			}
+			// it expired in the meantime; look for another
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return redisWebRTCCall{}, ctx.Err()
+		case <-newCall:
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecvOffer receives the next offer for the given host. It should respond with an answer
+// once a decision is made.
+func (queue *redisWebRTCCallQueue) RecvOffer(ctx context.Context, hosts []string) (WebRTCCallOfferExchange, error) {
+	if err := queue.checkHostQueueSize(ctx, false, hosts...); err != nil {
+		return nil, err
+	}
+
+	recvOfferCtx, recvOfferCtxCancel := utils.MergeContext(ctx, queue.cancelCtx)
+	call, err := queue.waitForNewCall(recvOfferCtx, hosts)
+	recvOfferCtxCancel()
+	if err != nil {
+		return nil, err
+	}
+	observeOfferWaitTime(queue.operatorID, call.StartedAt)
+
+	untrack := queue.trackCallExchange(call.Host, redisCallSideAnswerer)
+
+	offerDeadline := call.StartedAt.Add(queue.opts.getOfferDeadline())
+
+	recvCtx, recvCtxCancel := utils.MergeContextWithDeadline(ctx, queue.cancelCtx, offerDeadline)
+
+	cleanup := func() {
+		recvCtxCancel()
+		untrack()
+	}
+
+	callerDoneCtx, callerDoneCancel := context.WithCancel(context.Background())
+	exchange := redisWebRTCCallOfferExchange{
+		call:             call,
+		client:           queue.client,
+		keys:             queue.keys,
+		callerCandidates: make(chan webrtc.ICECandidateInit),
+		callerDoneCtx:    callerDoneCtx,
+		deadline:         offerDeadline,
+	}
+	setErr := func(errToSet error) {
+		if !(errors.Is(errToSet, context.Canceled) || errors.Is(errToSet, context.DeadlineExceeded)) {
+			queue.logger.Errorw("error in RecvOffer", "error", errToSet, "id", call.ID)
+		}
+		// we assume the number of goroutines is bounded by the gRPC server invoking this method.
+		queue.activeBackgroundWorkers.Add(1)
+		utils.PanicCapturingGo(func() {
+			defer queue.activeBackgroundWorkers.Done()
+
+			// we need a dedicated timeout since even if the server is shutting down,
+			// we want to notify other servers immediately, instead of waiting for a timeout.
+			updateCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			err := updateRedisCall(updateCtx, queue.client, queue.keys, call.Host, call.ID, redisCallUpdate{
+				Side:  redisCallSideAnswerer,
+				Event: redisCallEventErrorField,
+				Value: errToSet.Error(),
+				Set:   webrtcCallAnswererErrorField,
+			})
+			if err == nil {
+				return
+			}
+			var errInactive inactiveOfferError
+			if !errors.As(err, &errInactive) {
+				queue.logger.Errorw("error updating error for RecvOffer", "error", errToSet, "id", call.ID)
+			}
+		})
+	}
+	sendCandidate := func(cand webrtc.ICECandidateInit) bool {
+		select {
+		case <-recvCtx.Done():
+			// try once more
+			select {
+			case exchange.callerCandidates <- cand:
+			default:
+			}
+			return false
+		case exchange.callerCandidates <- cand:
+			return true
+		}
+	}
+	queue.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer queue.activeBackgroundWorkers.Done()
+		defer callerDoneCancel()
+		defer cleanup()
+
+		err := queue.readCallEvents(recvCtx, call.ID, redisCallSideCaller, func(event map[string]interface{}) bool {
+			if callerErr, ok := redisCallEventValue(event, redisCallEventErrorField); ok {
+				exchange.callerErr = errors.New(callerErr)
+				return false
+			}
+			if candJSON, ok := redisCallEventValue(event, redisCallEventCandidateField); ok {
+				var cand webrtc.ICECandidateInit
+				if err := json.Unmarshal([]byte(candJSON), &cand); err != nil {
+					exchange.callerErr = err
+					return false
+				}
+				return sendCandidate(cand)
+			}
+			_, done := redisCallEventValue(event, redisCallEventDoneField)
+			return !done
+		})
+		if err != nil {
+			setErr(err)
+		}
+	})
+	return &exchange, nil
+}
+
+// Stats returns the hosts with offers pending or answerers connected, the operators sharing
+// the queue and the calls in flight, as stored in Redis.
+func (queue *redisWebRTCCallQueue) Stats(ctx context.Context) (WebRTCCallQueueSnapshot, error) {
+	now := time.Now()
+	operators, err := queue.client.ZRangeByScoreWithScores(ctx, queue.keys.operators(), &redis.ZRangeBy{
+		Min: strconv.FormatInt(now.UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	operatorHostsCmds := make([]*redis.MapStringStringCmd, 0, len(operators))
+	if _, err := queue.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, operator := range operators {
+			operatorHostsCmds = append(operatorHostsCmds, pipe.HGetAll(ctx, queue.keys.operatorHosts(operator.Member.(string))))
+		}
+		return nil
+	}); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+
+	var snapshot WebRTCCallQueueSnapshot
+	for idx, operator := range operators {
+		operatorStats := WebRTCCallQueueOperatorStats{
+			ID:        operator.Member.(string),
+			ExpiresAt: time.UnixMilli(int64(operator.Score)),
+		}
+		hostSizes := map[string]*WebRTCCallQueueOperatorHostStats{}
+		for field, sizeStr := range operatorHostsCmds[idx].Val() {
+			sizeField, host, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			size, err := strconv.ParseUint(sizeStr, 10, 64)
+			if err != nil {
+				return WebRTCCallQueueSnapshot{}, err
+			}
+			hostStats, ok := hostSizes[host]
+			if !ok {
+				hostStats = &WebRTCCallQueueOperatorHostStats{Host: host}
+				hostSizes[host] = hostStats
+			}
+			switch sizeField {
+			case webrtcOperatorHostsCallerSizeField:
+				hostStats.Callers = size
+			case webrtcOperatorHostsAnswererSizeField:
+				hostStats.Answerers = size
+			}
+		}
+		for _, hostStats := range hostSizes {
+			operatorStats.Hosts = append(operatorStats.Hosts, *hostStats)
+		}
+		snapshot.Operators = append(snapshot.Operators, operatorStats)
+	}
+
+	// calls expire along with their offer, so every call still around is in flight
+	var callIDs []string
+	iter := queue.client.Scan(ctx, 0, queue.keys.call("*"), 0).Iterator()
+	for iter.Next(ctx) {
+		callKey := iter.Val()
+		if strings.HasSuffix(callKey, ":events") {
+			continue
+		}
+		callIDs = append(callIDs, strings.TrimPrefix(callKey, queue.keys.call("")))
+	}
+	if err := iter.Err(); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	callCmds := make([]*redis.SliceCmd, 0, len(callIDs))
+	if _, err := queue.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, callID := range callIDs {
+			callCmds = append(callCmds, pipe.HMGet(
+				ctx,
+				queue.keys.call(callID),
+				webrtcCallHostField,
+				webrtcCallStartedAtField,
+				webrtcCallAnsweredField,
+				webrtcCallCallerOperatorIDField,
+				webrtcCallAnswererOperatorIDField,
+				webrtcCallCallerErrorField,
+			))
+		}
+		return nil
+	}); err != nil {
+		return WebRTCCallQueueSnapshot{}, err
+	}
+	for idx, cmd := range callCmds {
+		vals := cmd.Val()
+		host, ok := vals[0].(string)
+		if !ok {
+			// expired in the meantime
+			continue
+		}
+		if _, hasCallerErr := vals[5].(string); hasCallerErr {
+			continue
+		}
+		startedAtStr, _ := vals[1].(string)
+		startedAtNanos, err := strconv.ParseInt(startedAtStr, 10, 64)
+		if err != nil {
+			return WebRTCCallQueueSnapshot{}, err
+		}
+		startedAt := time.Unix(0, startedAtNanos)
+		answered, _ := vals[2].(string)
+		callerOperatorID, _ := vals[3].(string)
+		answererOperatorID, _ := vals[4].(string)
+		snapshot.Calls = append(snapshot.Calls, WebRTCCallQueueCallStats{
+			UUID:               callIDs[idx],
+			Host:               host,
+			StartedAt:          startedAt,
+			Deadline:           startedAt.Add(queue.opts.getOfferDeadline()),
+			Answered:           answered == "1",
+			CallerOperatorID:   callerOperatorID,
+			AnswererOperatorID: answererOperatorID,
+		})
+	}
+	snapshot.Hosts = webrtcCallQueueHostStatsFromCalls(snapshot.Calls, snapshot.Operators)
+	sortWebRTCCallQueueSnapshot(&snapshot)
+	return snapshot, nil
+}
+
+// Close cancels all active offers and waits to cleanly close all background workers.
+func (queue *redisWebRTCCallQueue) Close() error {
+	queue.cancelFunc()
+	queue.activeBackgroundWorkers.Wait()
+
+	// let other operators know right away that this one is gone
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := queue.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, queue.keys.operators(), queue.operatorID)
+		pipe.Del(ctx, queue.keys.operatorHosts(queue.operatorID))
+		return nil
+	})
+	return multierr.Combine(err, queue.pubSub.Close())
+}
+
+type redisWebRTCCallOfferExchange struct {
+	call             redisWebRTCCall
+	client           redis.UniversalClient
+	keys             redisWebRTCCallQueueKeys
+	callerCandidates chan webrtc.ICECandidateInit
+	callerDoneCtx    context.Context
+	callerErr        error
+	deadline         time.Time
+}
+
+func (resp *redisWebRTCCallOfferExchange) UUID() string {
+	return resp.call.ID
+}
+
+func (resp *redisWebRTCCallOfferExchange) SDP() string {
+	return resp.call.CallerSDP
+}
+
+func (resp *redisWebRTCCallOfferExchange) DisableTrickleICE() bool {
+	return resp.call.DisableTrickle
+}
+
+func (resp *redisWebRTCCallOfferExchange) ICERestartUUID() string {
+	return resp.call.ICERestartUUID
+}
+
+func (resp *redisWebRTCCallOfferExchange) Deadline() time.Time {
+	return resp.deadline
+}
+
+func (resp *redisWebRTCCallOfferExchange) CallerCandidates() <-chan webrtc.ICECandidateInit {
+	return resp.callerCandidates
+}
+
+func (resp *redisWebRTCCallOfferExchange) CallerDone() <-chan struct{} {
+	return resp.callerDoneCtx.Done()
+}
+
+func (resp *redisWebRTCCallOfferExchange) CallerErr() error {
+	if resp.callerDoneCtx.Err() == nil {
+		return nil
+	}
+	if resp.callerErr != nil {
+		return resp.callerErr
+	}
+	if errors.Is(resp.callerDoneCtx.Err(), context.Canceled) {
+		return nil
+	}
+	return resp.callerDoneCtx.Err()
+}
+
+func (resp *redisWebRTCCallOfferExchange) AnswererRespond(ctx context.Context, ans WebRTCCallAnswer) error {
+	update := redisCallUpdate{Side: redisCallSideAnswerer}
+	switch {
+	case ans.InitialSDP != nil:
+		update.Event = redisCallEventSDPField
+		update.Value = *ans.InitialSDP
+	case ans.Candidate != nil:
+		candJSON, err := json.Marshal(ans.Candidate)
+		if err != nil {
+			return err
+		}
+		update.Event = redisCallEventCandidateField
+		update.Value = string(candJSON)
+	case ans.Err != nil:
+		update.Event = redisCallEventErrorField
+		update.Value = ans.Err.Error()
+		update.Set = webrtcCallAnswererErrorField
+	default:
+		return errors.New("expected either SDP, ICE candidate, or error to be set")
+	}
+	return updateRedisCall(ctx, resp.client, resp.keys, resp.call.Host, resp.call.ID, update)
+}
+
+func (resp *redisWebRTCCallOfferExchange) AnswererDone(ctx context.Context) error {
+	return updateRedisCall(ctx, resp.client, resp.keys, resp.call.Host, resp.call.ID, redisCallUpdate{
+		Side:   redisCallSideAnswerer,
+		Event:  redisCallEventDoneField,
+		Value:  "1",
+		Unless: webrtcCallAnswererDoneField,
+		Set:    webrtcCallAnswererDoneField,
+	})
+}
@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.viam.com/utils/perf/statz"
+	"go.viam.com/utils/perf/statz/units"
+)
+
+// A WebRTCCallQueueStats is a call queue that can report what it knows of, in order to
+// diagnose hosts that cannot be reached.
+type WebRTCCallQueueStats interface {
+	// Stats returns the hosts with offers pending or answerers connected, the operators
+	// sharing the queue and the calls in flight.
+	Stats(ctx context.Context) (WebRTCCallQueueSnapshot, error)
+}
+
+// A WebRTCCallQueueSnapshot is what a call queue knows of at some point in time.
+type WebRTCCallQueueSnapshot struct {
+	Hosts []WebRTCCallQueueHostStats
+	// Operators is empty for call queues that are not distributed.
+	Operators []WebRTCCallQueueOperatorStats
+	Calls     []WebRTCCallQueueCallStats
+}
+
+// WebRTCCallQueueHostStats are the offers pending and answerers connected for a host.
+type WebRTCCallQueueHostStats struct {
+	Host               string
+	PendingOffers      uint64
+	ConnectedAnswerers uint64
+}
+
+// WebRTCCallQueueOperatorStats is an operator sharing a distributed call queue.
+type WebRTCCallQueueOperatorStats struct {
+	ID        string
+	ExpiresAt time.Time
+	Hosts     []WebRTCCallQueueOperatorHostStats
+}
+
+// WebRTCCallQueueOperatorHostStats are the callers and answerers for a host connected to
+// an operator.
+type WebRTCCallQueueOperatorHostStats struct {
+	Host      string
+	Callers   uint64
+	Answerers uint64
+}
+
+// WebRTCCallQueueCallStats is a call in flight.
+type WebRTCCallQueueCallStats struct {
+	UUID               string
+	Host               string
+	StartedAt          time.Time
+	Deadline           time.Time
+	Answered           bool
+	CallerOperatorID   string
+	AnswererOperatorID string
+}
+
+var (
+	callQueuePendingOffers = statz.NewGauge1[string]("rpc.webrtc/call_queue_pending_offers", statz.MetricConfig{
+		Description: "The number of offers sent through an operator that have yet to be picked up by an answerer.",
+		Unit:        units.Dimensionless,
+		Labels: []statz.Label{
+			{Name: "operator_id", Description: "The queue operator ID."},
+		},
+	})
+
+	callQueueConnectedAnswerers = statz.NewGauge1[string]("rpc.webrtc/call_queue_connected_answerers", statz.MetricConfig{
+		Description: "The number of answerers connected to an operator waiting for calls.",
+		Unit:        units.Dimensionless,
+		Labels: []statz.Label{
+			{Name: "operator_id", Description: "The queue operator ID."},
+		},
+	})
+
+	callQueueOfferWaitTime = statz.NewDistribution1[string]("rpc.webrtc/call_queue_offer_wait_time", statz.MetricConfig{
+		Description: "How long offers wait before being picked up by an answerer.",
+		Unit:        units.Milliseconds,
+		Labels: []statz.Label{
+			{Name: "operator_id", Description: "The queue operator ID of the answerer."},
+		},
+	}, statz.DistributionFromBounds(0, 25, 50, 100, 250, 500, 1000, 2000, 4000, 6000, 8000, 10000, 20000, 40000, 60000))
+
+	callQueueOffersExpired = statz.NewCounter1[string]("rpc.webrtc/call_queue_offers_expired", statz.MetricConfig{
+		Description: "The number of offers that reached their deadline without being picked up by an answerer.",
+		Unit:        units.Dimensionless,
+		Labels: []statz.Label{
+			{Name: "operator_id", Description: "The queue operator ID of the caller."},
+		},
+	})
+)
+
+// observeOfferWaitTime records how long an offer started at the given time waited to be
+// picked up.
+func observeOfferWaitTime(operatorID string, startedAt time.Time) {
+	callQueueOfferWaitTime.Observe(float64(time.Since(startedAt).Milliseconds()), operatorID)
+}
+
+// webrtcCallQueueHostStatsFromCalls adds up the pending offers of the given calls and the
+// connected answerers of the given operators by host. Only hosts with either are returned.
+func webrtcCallQueueHostStatsFromCalls(
+	calls []WebRTCCallQueueCallStats,
+	operators []WebRTCCallQueueOperatorStats,
+) []WebRTCCallQueueHostStats {
+	byHost := map[string]*WebRTCCallQueueHostStats{}
+	getHost := func(host string) *WebRTCCallQueueHostStats {
+		hostStats, ok := byHost[host]
+		if !ok {
+			hostStats = &WebRTCCallQueueHostStats{Host: host}
+			byHost[host] = hostStats
+		}
+		return hostStats
+	}
+	for _, call := range calls {
+		if !call.Answered {
+			getHost(call.Host).PendingOffers++
+		}
+	}
+	for _, operator := range operators {
+		for _, host := range operator.Hosts {
+			if host.Answerers != 0 {
+				getHost(host.Host).ConnectedAnswerers += host.Answerers
+			}
+		}
+	}
+	hosts := make([]WebRTCCallQueueHostStats, 0, len(byHost))
+	for _, hostStats := range byHost {
+		hosts = append(hosts, *hostStats)
+	}
+	return hosts
+}
+
+// sortWebRTCCallQueueSnapshot orders hosts and operators by name and calls by when they
+// started.
+func sortWebRTCCallQueueSnapshot(snapshot *WebRTCCallQueueSnapshot) {
+	sort.Slice(snapshot.Hosts, func(i, j int) bool {
+		return snapshot.Hosts[i].Host < snapshot.Hosts[j].Host
+	})
+	sort.Slice(snapshot.Operators, func(i, j int) bool {
+		return snapshot.Operators[i].ID < snapshot.Operators[j].ID
+	})
+	for _, operator := range snapshot.Operators {
+		sort.Slice(operator.Hosts, func(i, j int) bool {
+			return operator.Hosts[i].Host < operator.Hosts[j].Host
+		})
+	}
+	sort.Slice(snapshot.Calls, func(i, j int) bool {
+		return snapshot.Calls[i].StartedAt.Before(snapshot.Calls[j].StartedAt)
+	})
+}
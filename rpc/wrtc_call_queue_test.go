@@ -10,6 +10,8 @@ import (
 	"github.com/pion/webrtc/v3"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.viam.com/test"
+
+	"go.viam.com/utils/testutils"
 )
 
 func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCallQueue, WebRTCCallQueue, func())) {
@@ -21,7 +23,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 		defer undo()
 
 		host := primitive.NewObjectID().Hex()
-		_, _, ansCtx, _, err := callerQueue.SendOfferInit(context.Background(), host, "somesdp", false)
+		_, _, ansCtx, _, err := callerQueue.SendOfferInit(context.Background(), host, "somesdp", false, "")
 		test.That(t, err, test.ShouldBeNil)
 		<-ansCtx
 	})
@@ -73,7 +75,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 			close(done)
 		}()
 
-		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false)
+		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false, "")
 		defer cancel()
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, newUUID, test.ShouldNotBeEmpty)
@@ -142,7 +144,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 			close(done)
 		}()
 
-		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false)
+		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false, "")
 		defer cancel()
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, newUUID, test.ShouldNotBeEmpty)
@@ -169,7 +171,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 		defer teardown()
 
 		host := primitive.NewObjectID().Hex()
-		newUUID, _, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false)
+		newUUID, _, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false, "")
 		cancel()
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, newUUID, test.ShouldNotBeEmpty)
@@ -209,7 +211,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 					close(done)
 				}()
 
-				newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false)
+				newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false, "")
 				defer cancel()
 				test.That(t, err, test.ShouldBeNil)
 				test.That(t, newUUID, test.ShouldNotBeEmpty)
@@ -247,7 +249,7 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 			close(done)
 		}()
 
-		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false)
+		newUUID, answers, answersDone, cancel, err := callerQueue.SendOfferInit(context.Background(), host, "hello", false, "")
 		defer cancel()
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, newUUID, test.ShouldNotBeEmpty)
@@ -278,11 +280,65 @@ func testWebRTCCallQueue(t *testing.T, setupQueues func(t *testing.T) (WebRTCCal
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		_, _, ansCtx, _, err := callerQueue.SendOfferInit(ctx, primitive.NewObjectID().Hex(), "hello", false)
+		_, _, ansCtx, _, err := callerQueue.SendOfferInit(ctx, primitive.NewObjectID().Hex(), "hello", false, "")
 		test.That(t, err, test.ShouldBeNil)
 		<-ansCtx
 		recvErr := <-recvErrCh
 		test.That(t, recvErr, test.ShouldNotBeNil)
 		test.That(t, recvErr, test.ShouldWrap, context.DeadlineExceeded)
 	})
+
+	t.Run("stats should report pending and answered offers", func(t *testing.T) {
+		callerQueue, answererQueue, teardown := setupQueues(t)
+		defer teardown()
+
+		undo := setDefaultOfferDeadline(10 * time.Second)
+		defer undo()
+
+		statsQueue, ok := callerQueue.(WebRTCCallQueueStats)
+		test.That(t, ok, test.ShouldBeTrue)
+
+		findCall := func(tb testing.TB, host string) (WebRTCCallQueueSnapshot, WebRTCCallQueueCallStats) {
+			tb.Helper()
+			snapshot, err := statsQueue.Stats(context.Background())
+			test.That(tb, err, test.ShouldBeNil)
+			for _, call := range snapshot.Calls {
+				if call.Host == host {
+					return snapshot, call
+				}
+			}
+			tb.Fatalf("no call for host %q", host)
+			return WebRTCCallQueueSnapshot{}, WebRTCCallQueueCallStats{}
+		}
+
+		host := primitive.NewObjectID().Hex()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		uuid, _, _, _, err := callerQueue.SendOfferInit(ctx, host, "somesdp", false, "")
+		test.That(t, err, test.ShouldBeNil)
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			snapshot, call := findCall(tb, host)
+			test.That(tb, call.UUID, test.ShouldEqual, uuid)
+			test.That(tb, call.Answered, test.ShouldBeFalse)
+			test.That(tb, call.Deadline.After(call.StartedAt), test.ShouldBeTrue)
+			test.That(tb, snapshot.Hosts, test.ShouldContain, WebRTCCallQueueHostStats{Host: host, PendingOffers: 1})
+		})
+
+		offer, err := answererQueue.RecvOffer(ctx, []string{host})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, offer.UUID(), test.ShouldEqual, uuid)
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			snapshot, call := findCall(tb, host)
+			test.That(tb, call.Answered, test.ShouldBeTrue)
+			for _, hostStats := range snapshot.Hosts {
+				if hostStats.Host == host {
+					test.That(tb, hostStats.PendingOffers, test.ShouldBeZeroValue)
+				}
+			}
+		})
+	})
 }
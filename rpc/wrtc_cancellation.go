@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/utils"
+)
+
+// WebRTCHandlerGracePeriod is how long a handler has to return once its call is over, such as
+// after the client cancelled it or its deadline passed, before it is reported as leaked.
+var WebRTCHandlerGracePeriod = 5 * time.Second
+
+// A contextStatusError is what a call fails with when its context is done. Like with gRPC,
+// it is a status error with codes.Canceled or codes.DeadlineExceeded, but it is also still
+// the context error to errors.Is.
+type contextStatusError struct {
+	err error
+}
+
+// toContextStatusError turns a context error into a contextStatusError.
+func toContextStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return contextStatusError{err}
+}
+
+func (err contextStatusError) Error() string {
+	return err.GRPCStatus().Err().Error()
+}
+
+func (err contextStatusError) GRPCStatus() *status.Status {
+	return status.FromContextError(err.err)
+}
+
+func (err contextStatusError) Unwrap() error {
+	return err.err
+}
+
+// A callCancellation records why a call handled over WebRTC was cancelled by the client.
+type callCancellation struct {
+	mu     sync.Mutex
+	reason error
+}
+
+func contextWithCallCancellation(ctx context.Context) (context.Context, *callCancellation) {
+	cancellation := &callCancellation{}
+	return context.WithValue(ctx, ctxKeyCallCancellation, cancellation), cancellation
+}
+
+// set records the reason the client gave for cancelling the call, if it is one a client may
+// give, and returns the status to end the call with.
+func (cancellation *callCancellation) set(reason *status.Status) error {
+	if reason == nil || (reason.Code() != codes.Canceled && reason.Code() != codes.DeadlineExceeded) {
+		reason = status.New(codes.Canceled, "request cancelled")
+	}
+	cancellation.mu.Lock()
+	defer cancellation.mu.Unlock()
+	if cancellation.reason == nil {
+		cancellation.reason = reason.Err()
+	}
+	return cancellation.reason
+}
+
+// CallCancellationReason returns why the call being handled with the given context is over
+// as a gRPC status error: codes.Canceled if the client cancelled it or went away and
+// codes.DeadlineExceeded if its deadline passed. For calls over WebRTC, the client says which
+// of the two it was; for direct gRPC calls, this is the server's view of the context. It
+// returns nil while the call is still going.
+func CallCancellationReason(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	if cancellation, ok := ctx.Value(ctxKeyCallCancellation).(*callCancellation); ok {
+		cancellation.mu.Lock()
+		reason := cancellation.reason
+		cancellation.mu.Unlock()
+		if reason != nil {
+			return reason
+		}
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+// watchHandler reports the handler of the stream as leaked if it has not returned, which is
+// signaled by handlerDone being closed, within WebRTCHandlerGracePeriod of its call being
+// over.
+func (s *webrtcServerStream) watchHandler(handlerDone <-chan struct{}) {
+	s.ch.server.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer s.ch.server.activeBackgroundWorkers.Done()
+		select {
+		case <-handlerDone:
+			return
+		case <-s.ctx.Done():
+		}
+		timer := time.NewTimer(WebRTCHandlerGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-handlerDone:
+		case <-timer.C:
+			s.logger.Warnw(
+				"handler still running after its call is over; it should return once its context is done",
+				"grace_period", WebRTCHandlerGracePeriod,
+				"reason", CallCancellationReason(s.ctx),
+			)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	"go.viam.com/utils/testutils"
+)
+
+// blockingEchoServer handles calls by waiting for them to be over and reporting how they
+// ended.
+type blockingEchoServer struct {
+	pb.UnimplementedEchoServiceServer
+	started chan struct{}
+	ended   chan endedCall
+}
+
+type endedCall struct {
+	hadDeadline bool
+	reason      error
+}
+
+func (srv *blockingEchoServer) block(ctx context.Context) error {
+	srv.started <- struct{}{}
+	_, hadDeadline := ctx.Deadline()
+	<-ctx.Done()
+	srv.ended <- endedCall{hadDeadline: hadDeadline, reason: CallCancellationReason(ctx)}
+	return ctx.Err()
+}
+
+func (srv *blockingEchoServer) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	return nil, srv.block(ctx)
+}
+
+func (srv *blockingEchoServer) EchoBiDi(server pb.EchoService_EchoBiDiServer) error {
+	if _, err := server.Recv(); err != nil {
+		return err
+	}
+	return srv.block(server.Context())
+}
+
+// TestCallCancellationConformance checks that calls end the same way over direct gRPC and
+// over WebRTC when they are cancelled or their deadline passes.
+func TestCallCancellationConformance(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	echoServer := &blockingEchoServer{
+		started: make(chan struct{}, 1),
+		ended:   make(chan endedCall, 1),
+	}
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		echoServer,
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	grpcConn, err := DialDirectGRPC(context.Background(), httpListener.Addr().String(), logger, WithInsecure())
+	test.That(t, err, test.ShouldBeNil)
+	webrtcConn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingInsecure: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, tc := range []struct {
+		transport string
+		conn      ClientConn
+	}{
+		{"grpc", grpcConn},
+		{"webrtc", webrtcConn},
+	} {
+		client := pb.NewEchoServiceClient(tc.conn)
+
+		t.Run(tc.transport+"/unary deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			_, err := client.Echo(ctx, &pb.EchoRequest{Message: "hello"})
+			test.That(t, status.Code(err), test.ShouldEqual, codes.DeadlineExceeded)
+
+			<-echoServer.started
+			ended := <-echoServer.ended
+			test.That(t, ended.hadDeadline, test.ShouldBeTrue)
+			// the server's own timer races the client telling it that the deadline passed,
+			// which direct gRPC only reports as a cancellation.
+			test.That(t, status.Code(ended.reason), test.ShouldBeIn, codes.DeadlineExceeded, codes.Canceled)
+			if tc.transport == "webrtc" {
+				test.That(t, status.Code(ended.reason), test.ShouldEqual, codes.DeadlineExceeded)
+			}
+		})
+
+		t.Run(tc.transport+"/unary cancel", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				<-echoServer.started
+				cancel()
+			}()
+			_, err := client.Echo(ctx, &pb.EchoRequest{Message: "hello"})
+			test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+
+			ended := <-echoServer.ended
+			test.That(t, ended.hadDeadline, test.ShouldBeFalse)
+			test.That(t, status.Code(ended.reason), test.ShouldEqual, codes.Canceled)
+		})
+
+		t.Run(tc.transport+"/stream deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			stream, err := client.EchoBiDi(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, stream.Send(&pb.EchoBiDiRequest{Message: "hello"}), test.ShouldBeNil)
+			_, err = stream.Recv()
+			test.That(t, status.Code(err), test.ShouldEqual, codes.DeadlineExceeded)
+
+			<-echoServer.started
+			ended := <-echoServer.ended
+			test.That(t, ended.hadDeadline, test.ShouldBeTrue)
+			test.That(t, status.Code(ended.reason), test.ShouldBeIn, codes.DeadlineExceeded, codes.Canceled)
+		})
+
+		t.Run(tc.transport+"/stream cancel", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream, err := client.EchoBiDi(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, stream.Send(&pb.EchoBiDiRequest{Message: "hello"}), test.ShouldBeNil)
+			<-echoServer.started
+			cancel()
+			_, err = stream.Recv()
+			test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+
+			ended := <-echoServer.ended
+			test.That(t, ended.hadDeadline, test.ShouldBeFalse)
+			test.That(t, status.Code(ended.reason), test.ShouldEqual, codes.Canceled)
+		})
+	}
+
+	test.That(t, grpcConn.Close(), test.ShouldBeNil)
+	test.That(t, webrtcConn.Close(), test.ShouldBeNil)
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestCallCancellationReason(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancellation := contextWithCallCancellation(ctx)
+	test.That(t, CallCancellationReason(ctx), test.ShouldBeNil)
+
+	reason := cancellation.set(status.New(codes.DeadlineExceeded, "too late"))
+	test.That(t, status.Code(reason), test.ShouldEqual, codes.DeadlineExceeded)
+	// only the first reason counts
+	test.That(t, status.Code(cancellation.set(nil)), test.ShouldEqual, codes.DeadlineExceeded)
+	test.That(t, CallCancellationReason(ctx), test.ShouldBeNil)
+	cancel()
+	test.That(t, status.Code(CallCancellationReason(ctx)), test.ShouldEqual, codes.DeadlineExceeded)
+
+	// clients may only cancel calls
+	ctx, cancel = context.WithCancel(context.Background())
+	ctx, cancellation = contextWithCallCancellation(ctx)
+	cancel()
+	test.That(t, status.Code(cancellation.set(status.New(codes.NotFound, "nope"))), test.ShouldEqual, codes.Canceled)
+	test.That(t, status.Code(CallCancellationReason(ctx)), test.ShouldEqual, codes.Canceled)
+
+	// without a reason from a client, the context says what happened
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	test.That(t, status.Code(CallCancellationReason(ctx)), test.ShouldEqual, codes.DeadlineExceeded)
+
+	err := toContextStatusError(context.Canceled)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
+	test.That(t, ErrorToStatus(err).Message(), test.ShouldEqual, context.Canceled.Error())
+}
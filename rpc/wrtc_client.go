@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/pion/webrtc/v3"
@@ -73,6 +74,43 @@ type DialWebRTCOptions struct {
 	// AllowAutoDetectAuthOptions allows authentication options to be automatically
 	// detected. Only use this if you trust the signaling server.
 	AllowAutoDetectAuthOptions bool
+
+	// PeerConnections is how many peer connections to establish to the host. When more
+	// than one, calls are spread across them according to LoadBalancing. This is useful
+	// when several answerers serve the same host, though which answerer each peer
+	// connection lands on is up to the signaling server. Defaults to 1.
+	PeerConnections int
+
+	// LoadBalancing decides which peer connection a call is made over when there are
+	// several. Defaults to round robin.
+	LoadBalancing WebRTCLoadBalancing
+
+	// DataChannels are additional data channels to open on each peer connection so that
+	// streams do not all queue up behind each other on a single one.
+	DataChannels []DataChannelOptions
+
+	// Keepalive controls how a host that has gone away is noticed so that calls fail
+	// promptly instead of waiting out their deadlines.
+	Keepalive WebRTCKeepaliveParameters
+
+	// EnableICERestart restarts ICE through the signaling server whenever the ICE connection
+	// drops, such as when switching networks, keeping the connection and its calls alive
+	// instead of failing. The host's answerer must support ICE restarts.
+	EnableICERestart bool
+
+	// OfferDeadline is how long connecting, and each ICE restart, may take before giving up.
+	// It should match the offer deadline of the signaling server (see WithSignalingOfferDeadline)
+	// for hosts that take long to answer, such as over high latency links. Defaults to 10s.
+	OfferDeadline time.Duration
+}
+
+// getOfferDeadline returns how long connecting may take, falling back to the package default
+// if unset.
+func (opts DialWebRTCOptions) getOfferDeadline() time.Duration {
+	if opts.OfferDeadline == 0 {
+		return getDefaultOfferDeadline()
+	}
+	return opts.OfferDeadline
 }
 
 // DialWebRTC connects to the signaling service at the given address and attempts to establish
@@ -103,7 +141,7 @@ func dialWebRTC(
 	logger golog.Logger,
 ) (ch *webrtcClientChannel, err error) {
 	logger = logger.Named("webrtc")
-	dialCtx, timeoutCancel := context.WithTimeout(ctx, getDefaultOfferDeadline())
+	dialCtx, timeoutCancel := context.WithTimeout(ctx, dOpts.webrtcOpts.getOfferDeadline())
 	defer timeoutCancel()
 
 	logger.Debugw(
@@ -113,6 +151,8 @@ func dialWebRTC(
 	)
 
 	dOptsCopy := *dOpts
+	// retries are for calls made over the WebRTC connection, not signaling for it
+	dOptsCopy.retryPolicy = nil
 	if dOpts.webrtcOpts.SignalingInsecure {
 		dOptsCopy.insecure = true
 	} else {
@@ -144,9 +184,12 @@ func dialWebRTC(
 		}
 	}
 
-	conn, _, err := dialDirectGRPC(dialCtx, signalingServer, &dOptsCopy, logger)
+	connectCtx, connectErr, connectCancel := withDialStageTimeout(
+		dialCtx, DialStageSignalingConnect, dOpts.timeouts.SignalingConnect)
+	defer connectCancel()
+	conn, _, err := dialDirectGRPC(connectCtx, signalingServer, &dOptsCopy, logger)
 	if err != nil {
-		return nil, err
+		return nil, connectErr(err)
 	}
 	defer func() {
 		err = multierr.Combine(err, conn.Close())
@@ -158,24 +201,28 @@ func dialWebRTC(
 	signalCtx := metadata.NewOutgoingContext(dialCtx, md)
 
 	signalingClient := webrtcpb.NewSignalingServiceClient(conn)
-	configResp, err := signalingClient.OptionalWebRTCConfig(signalCtx, &webrtcpb.OptionalWebRTCConfigRequest{})
+	configResp, err := signalingClient.OptionalWebRTCConfig(
+		metadata.NewOutgoingContext(connectCtx, md), &webrtcpb.OptionalWebRTCConfigRequest{})
 	if err != nil {
 		// this would be where we would hit an unimplemented signaler error first.
 		if s, ok := status.FromError(err); ok && (s.Code() == codes.Unimplemented ||
 			(s.Code() == codes.InvalidArgument && s.Message() == hostNotAllowedMsg)) {
 			return nil, ErrNoWebRTCSignaler
 		}
-		return nil, err
+		return nil, connectErr(err)
 	}
+	connectCancel()
 
 	config := DefaultWebRTCConfiguration
 	if dOptsCopy.webrtcOpts.Config != nil {
 		config = *dOptsCopy.webrtcOpts.Config
 	}
 	extendedConfig := extendWebRTCConfig(&config, configResp.Config)
-	pc, dc, err := newPeerConnectionForClient(ctx, extendedConfig, dOptsCopy.webrtcOpts.DisableTrickleICE, logger)
+	gatherCtx, gatherErr, gatherCancel := withDialStageTimeout(ctx, DialStageICEGathering, dOpts.timeouts.ICEGathering)
+	defer gatherCancel()
+	pc, dc, err := newPeerConnectionForClient(gatherCtx, extendedConfig, dOptsCopy.webrtcOpts.DisableTrickleICE, logger)
 	if err != nil {
-		return nil, err
+		return nil, gatherErr(err)
 	}
 	var successful bool
 	defer func() {
@@ -269,10 +316,13 @@ func dialWebRTC(
 			return nil, err
 		}
 
+		waitCtx, waitErr, waitCancel := withDialStageTimeout(exchangeCtx, DialStageICEGathering, dOpts.timeouts.ICEGathering)
 		select {
-		case <-exchangeCtx.Done():
-			return nil, exchangeCtx.Err()
+		case <-waitCtx.Done():
+			waitCancel()
+			return nil, waitErr(waitCtx.Err())
 		case <-waitOneHost:
+			waitCancel()
 		}
 	}
 
@@ -296,7 +346,15 @@ func dialWebRTC(
 	}
 
 	//nolint:contextcheck
-	clientCh := newWebRTCClientChannel(pc, dc, logger, dOpts.unaryInterceptor, dOpts.streamInterceptor)
+	clientCh := newWebRTCClientChannel(pc, dc, logger, dOpts.clientUnaryInterceptor(), dOpts.streamInterceptor)
+	clientCh.transportInfo = TransportInfo{
+		MulticastDNS:    dOpts.usingMDNS,
+		SignalingServer: signalingServer,
+	}
+	clientCh.compressor = dOpts.compressor
+	clientCh.maxRecvMsgSize = dOpts.maxRecvMsgSize
+	clientCh.maxSendMsgSize = dOpts.maxSendMsgSize
+	pc.OnTrack(clientCh.tracks.onTrack)
 
 	exchangeCandidates := func() error {
 		haveInit := false
@@ -361,17 +419,29 @@ func dialWebRTC(
 		sendErr(fmt.Errorf("%v", err))
 	})
 
-	doCall := func() error {
+	// waitStage waits for the given channel to close within the stage's timeout.
+	waitStage := func(stage DialStage, timeout time.Duration, done <-chan struct{}) error {
+		stageCtx, stageErr, cancel := withDialStageTimeout(exchangeCtx, stage, timeout)
+		defer cancel()
 		select {
-		case <-exchangeCtx.Done():
-			return multierr.Combine(exchangeCtx.Err(), clientCh.Close())
+		case <-stageCtx.Done():
+			return multierr.Combine(stageErr(stageCtx.Err()), clientCh.Close())
 		case <-clientCh.Ready():
 			return nil
+		case <-done:
+			return nil
 		case err := <-errCh:
 			return multierr.Combine(err, clientCh.Close())
 		}
 	}
 
+	doCall := func() error {
+		if err := waitStage(DialStageICEConnect, dOpts.timeouts.ICEConnect, clientCh.ICEConnected()); err != nil {
+			return err
+		}
+		return waitStage(DialStageDataChannelOpen, dOpts.timeouts.DataChannelOpen, clientCh.Ready())
+	}
+
 	if callErr := doCall(); callErr != nil {
 		var err error
 		sendDoneErrorOnce.Do(func() {
@@ -387,6 +457,32 @@ func dialWebRTC(
 	if err := sendDone(); err != nil {
 		return nil, err
 	}
+
+	openCtx, openErr, openCancel := withDialStageTimeout(dialCtx, DialStageDataChannelOpen, dOpts.timeouts.DataChannelOpen)
+	defer openCancel()
+	if err := clientCh.openDataChannels(openCtx, dOpts.webrtcOpts.DataChannels); err != nil {
+		return nil, multierr.Combine(openErr(err), clientCh.Close())
+	}
+
+	var hasStreams func() bool
+	if !dOpts.webrtcOpts.Keepalive.PermitWithoutStream {
+		hasStreams = clientCh.hasStreams
+	}
+	// let the server know to wait for ICE restarts instead of giving up on a dropped connection
+	var hello *webrtcpb.Ping
+	if dOpts.webrtcOpts.EnableICERestart {
+		hello = &webrtcpb.Ping{IceRestart: true}
+	}
+	clientCh.startKeepalive(dOpts.webrtcOpts.Keepalive, clientCh.writePing, hasStreams, hello)
+	if dOpts.webrtcOpts.EnableICERestart {
+		clientCh.restartICEWhenDisconnected(&webrtcICERestarter{
+			signalingServer: signalingServer,
+			host:            host,
+			dOpts:           dOptsCopy,
+			callUUID:        uuid,
+			logger:          logger,
+		})
+	}
 	successful = true
 	return clientCh, nil
 }
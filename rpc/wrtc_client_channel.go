@@ -13,13 +13,16 @@ import (
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/pion/webrtc/v3"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"go.viam.com/utils"
 	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
 )
 
@@ -38,6 +41,28 @@ type webrtcClientChannel struct {
 	streams           map[uint64]activeWebRTCClientStream
 	unaryInterceptor  grpc.UnaryClientInterceptor
 	streamInterceptor grpc.StreamClientInterceptor
+
+	// transportInfo holds what is known about the connection at dial time.
+	transportInfo TransportInfo
+
+	// compressor is the encoding calls are compressed with unless they ask for another.
+	compressor string
+
+	// maxRecvMsgSize and maxSendMsgSize are the largest messages calls may receive and send
+	// unless they ask for other limits, if not the defaults.
+	maxRecvMsgSize int
+	maxSendMsgSize int
+
+	// tracks are the media tracks added to the peer connection by the server. They are only
+	// received on the channel of the default data channel.
+	tracks webrtcTrackReceiver
+
+	// lanes are the data channels calls are spread across, starting with this one, and
+	// namedLanes the ones calls ask for by name. unreliable are the unreliable data channels
+	// by name. These are only set on the channel of the default data channel.
+	lanes      []*webrtcClientChannel
+	namedLanes map[string]*webrtcClientChannel
+	unreliable map[string]*webrtc.DataChannel
 }
 
 type activeWebRTCClientStream struct {
@@ -67,11 +92,54 @@ func newWebRTCClientChannel(
 		streamInterceptor: streamInterceptor,
 	}
 	dataChannel.OnMessage(ch.onChannelMessage)
+	ch.failStreamsOnClose()
 	return ch
 }
 
+// failStreamsOnClose fails all active streams once the channel closes for any reason other
+// than Close, since streams are otherwise only bound by the contexts of their calls.
+func (ch *webrtcClientChannel) failStreamsOnClose() {
+	ch.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer ch.activeBackgroundWorkers.Done()
+		<-ch.webrtcBaseChannel.ctx.Done()
+		_, reason := ch.webrtcBaseChannel.Closed()
+		if reason == nil {
+			return
+		}
+		ch.mu.Lock()
+		streamsToFail := make([]activeWebRTCClientStream, 0, len(ch.streams))
+		for _, s := range ch.streams {
+			streamsToFail = append(streamsToFail, s)
+		}
+		ch.mu.Unlock()
+		for _, s := range streamsToFail {
+			s.cs.webrtcBaseStream.mu.Lock()
+			s.cs.webrtcBaseStream.closeWithError(reason, false)
+			s.cs.webrtcBaseStream.mu.Unlock()
+		}
+	})
+}
+
+// hasStreams returns whether any calls are in progress on the peer connection.
+func (ch *webrtcClientChannel) hasStreams() bool {
+	for _, lane := range append([]*webrtcClientChannel{ch}, ch.additionalLanes()...) {
+		lane.mu.Lock()
+		active := len(lane.streams) != 0
+		lane.mu.Unlock()
+		if active {
+			return true
+		}
+	}
+	return false
+}
+
 // Close closes all streams and the underlying channel.
 func (ch *webrtcClientChannel) Close() error {
+	var err error
+	for _, lane := range ch.additionalLanes() {
+		err = multierr.Combine(err, lane.Close())
+	}
 	ch.mu.Lock()
 	streamsToClose := make(map[uint64]activeWebRTCClientStream, len(ch.streams))
 	for k, v := range ch.streams {
@@ -81,7 +149,7 @@ func (ch *webrtcClientChannel) Close() error {
 	for _, s := range streamsToClose {
 		s.cs.Close()
 	}
-	return ch.webrtcBaseChannel.Close()
+	return multierr.Combine(err, ch.webrtcBaseChannel.Close())
 }
 
 // Invoke sends the RPC request on the wire and returns after response is
@@ -126,10 +194,19 @@ func (ch *webrtcClientChannel) invoke(
 	args, reply interface{},
 	opts ...grpc.CallOption,
 ) error {
-	clientStream, err := ch.newStream(ctx, ch.nextStreamID())
+	lane, respDataChannel, err := ch.laneFor(opts)
+	if err != nil {
+		return err
+	}
+	clientStream, err := lane.newStream(ctx, lane.nextStreamID())
 	if err != nil {
 		return err
 	}
+	reqEncoding, err := clientStream.useCompressor(ch.requestEncoding(opts))
+	if err != nil {
+		clientStream.Close()
+		return err
+	}
 	defer func() {
 		clientStream.mu.Lock()
 		defer clientStream.mu.Unlock()
@@ -144,13 +221,19 @@ func (ch *webrtcClientChannel) invoke(
 				if clientStream.trailers != nil {
 					*optV.TrailerAddr = clientStream.trailers.Copy()
 				}
+			case grpc.CompressorCallOption, grpc.MaxRecvMsgSizeCallOption, grpc.MaxSendMsgSizeCallOption,
+				dataChannelCallOption:
+				// handled before the call
 			default:
 				clientStream.webrtcBaseStream.logger.Errorf("do not know how to handle call option %T", opt)
 			}
 		}
 	}()
 
-	if err := clientStream.writeHeaders(makeRequestHeaders(ctx, method)); err != nil {
+	headers := makeRequestHeaders(ctx, method, reqEncoding)
+	headers.ResponseDataChannel = respDataChannel
+	headers.MaxResponseMessageSize = clientStream.limitMessageSizes(ch.messageSizeLimits(opts))
+	if err := clientStream.writeHeaders(headers); err != nil {
 		return err
 	}
 
@@ -185,15 +268,19 @@ func (ch *webrtcClientChannel) NewStream(
 ) (grpc.ClientStream, error) {
 	fields := newClientLoggerFields(method)
 	startTime := time.Now()
-	clientStream, err := ch.streamWithInterceptor(ctx, method)
+	clientStream, err := ch.streamWithInterceptor(ctx, method, opts...)
 	newCtx := ctxzap.ToContext(ctx, ch.webrtcBaseChannel.logger.Desugar().With(fields...))
 	logFinalClientLine(newCtx, startTime, err, "finished client streaming call")
 	return clientStream, err
 }
 
-func (ch *webrtcClientChannel) streamWithInterceptor(ctx context.Context, method string) (grpc.ClientStream, error) {
+func (ch *webrtcClientChannel) streamWithInterceptor(
+	ctx context.Context,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
 	if ch.streamInterceptor == nil {
-		return ch.newClientStream(ctx, method)
+		return ch.newClientStream(ctx, method, opts...)
 	}
 
 	// change signature of streamer to be compatible with grpc stream interceptor
@@ -204,26 +291,76 @@ func (ch *webrtcClientChannel) streamWithInterceptor(ctx context.Context, method
 		method string,
 		opts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
-		return ch.newClientStream(ctx, method)
+		return ch.newClientStream(ctx, method, opts...)
 	}
-	return ch.streamInterceptor(ctx, nil, nil, method, streamer)
+	return ch.streamInterceptor(ctx, nil, nil, method, streamer, opts...)
 }
 
-func (ch *webrtcClientChannel) newClientStream(ctx context.Context, method string) (grpc.ClientStream, error) {
-	clientStream, err := ch.newStream(ctx, ch.nextStreamID())
+func (ch *webrtcClientChannel) newClientStream(
+	ctx context.Context,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	lane, respDataChannel, err := ch.laneFor(opts)
 	if err != nil {
 		return nil, err
 	}
+	clientStream, err := lane.newStream(ctx, lane.nextStreamID())
+	if err != nil {
+		return nil, err
+	}
+	reqEncoding, err := clientStream.useCompressor(ch.requestEncoding(opts))
+	if err != nil {
+		clientStream.Close()
+		return nil, err
+	}
 
-	if err := clientStream.writeHeaders(makeRequestHeaders(ctx, method)); err != nil {
+	headers := makeRequestHeaders(ctx, method, reqEncoding)
+	headers.ResponseDataChannel = respDataChannel
+	headers.MaxResponseMessageSize = clientStream.limitMessageSizes(ch.messageSizeLimits(opts))
+	if err := clientStream.writeHeaders(headers); err != nil {
 		return nil, err
 	}
 
 	return clientStream, nil
 }
 
-func makeRequestHeaders(ctx context.Context, method string) *webrtcpb.RequestHeaders {
+// requestEncoding returns the encoding to compress a call with, where the last
+// grpc.UseCompressor call option takes precedence over the channel's default.
+func (ch *webrtcClientChannel) requestEncoding(opts []grpc.CallOption) string {
+	name := ch.compressor
+	for _, opt := range opts {
+		if compOpt, ok := opt.(grpc.CompressorCallOption); ok {
+			name = compOpt.CompressorType
+		}
+	}
+	return name
+}
+
+// messageSizeLimits returns the largest messages a call may receive and send, where
+// grpc.MaxCallRecvMsgSize and grpc.MaxCallSendMsgSize call options take precedence over the
+// channel's limits. Zero means the default.
+func (ch *webrtcClientChannel) messageSizeLimits(opts []grpc.CallOption) (int, int) {
+	recv, send := ch.maxRecvMsgSize, ch.maxSendMsgSize
+	for _, opt := range opts {
+		switch optV := opt.(type) {
+		case grpc.MaxRecvMsgSizeCallOption:
+			recv = optV.MaxRecvMsgSize
+		case grpc.MaxSendMsgSizeCallOption:
+			send = optV.MaxSendMsgSize
+		}
+	}
+	return recv, send
+}
+
+func makeRequestHeaders(ctx context.Context, method, reqEncoding string) *webrtcpb.RequestHeaders {
 	headersMD, _ := metadata.FromOutgoingContext(ctx)
+	if reqEncoding != "" {
+		if headersMD == nil {
+			headersMD = metadata.MD{}
+		}
+		headersMD.Set(headerGRPCEncoding, reqEncoding)
+	}
 	var timeout time.Duration
 	if deadline, ok := ctx.Deadline(); ok {
 		timeout = time.Until(deadline)
@@ -281,6 +418,12 @@ func (ch *webrtcClientChannel) onChannelMessage(msg webrtc.DataChannelMessage) {
 		ch.webrtcBaseChannel.logger.Errorw("error unmarshaling message; discarding", "error", err)
 		return
 	}
+	ch.webrtcBaseChannel.onReceived()
+
+	if ping := resp.GetPing(); ping != nil {
+		ch.webrtcBaseChannel.onPing(ping, ch.writePing)
+		return
+	}
 
 	stream := resp.Stream
 	if stream == nil {
@@ -319,13 +462,34 @@ func (ch *webrtcClientChannel) writeMessage(stream *webrtcpb.Stream, msg *webrtc
 	})
 }
 
-func (ch *webrtcClientChannel) writeReset(stream *webrtcpb.Stream) error {
+func (ch *webrtcClientChannel) writeWindowUpdate(stream *webrtcpb.Stream, update *webrtcpb.WindowUpdate) error {
 	return ch.webrtcBaseChannel.write(&webrtcpb.Request{
+		Stream: stream,
+		Type: &webrtcpb.Request_WindowUpdate{
+			WindowUpdate: update,
+		},
+	})
+}
+
+func (ch *webrtcClientChannel) writePing(ping *webrtcpb.Ping) error {
+	return ch.webrtcBaseChannel.write(&webrtcpb.Request{
+		Type: &webrtcpb.Request_Ping{
+			Ping: ping,
+		},
+	})
+}
+
+func (ch *webrtcClientChannel) writeReset(stream *webrtcpb.Stream, reason error) error {
+	req := &webrtcpb.Request{
 		Stream: stream,
 		Type: &webrtcpb.Request_RstStream{
 			RstStream: true,
 		},
-	})
+	}
+	if reason != nil {
+		req.RstStreamStatus = status.FromContextError(reason).Proto()
+	}
+	return ch.webrtcBaseChannel.write(req)
 }
 
 // taken from
@@ -55,8 +55,9 @@ func TestWebRTCClientChannel(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 1},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method:  "thing",
-					Timeout: durationpb.New(0),
+					Method:     "thing",
+					Timeout:    durationpb.New(0),
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -179,7 +180,8 @@ func TestWebRTCClientChannel(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 2},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method: "thing",
+					Method:     "thing",
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -213,8 +215,9 @@ func TestWebRTCClientChannel(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 3},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method:  "thing",
-					Timeout: durationpb.New(0),
+					Method:     "thing",
+					Timeout:    durationpb.New(0),
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -246,8 +249,9 @@ func TestWebRTCClientChannel(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 4},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method:  "thing",
-					Timeout: durationpb.New(0),
+					Method:     "thing",
+					Timeout:    durationpb.New(0),
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -280,8 +284,9 @@ func TestWebRTCClientChannel(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 5},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method:  "thing",
-					Timeout: durationpb.New(0),
+					Method:     "thing",
+					Timeout:    durationpb.New(0),
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -330,7 +335,9 @@ func TestWebRTCClientChannel(t *testing.T) {
 	// client channel cancellation will send a RST_STREAM signal for non-closed streams
 	rejected.Add(1)
 	test.That(t, clientCh.Close(), test.ShouldBeNil)
-	test.That(t, <-clientErr, test.ShouldEqual, context.Canceled)
+	err = <-clientErr
+	test.That(t, errors.Is(err, context.Canceled), test.ShouldBeTrue)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
 }
 
 func TestWebRTCClientChannelResetStream(t *testing.T) {
@@ -361,8 +368,9 @@ func TestWebRTCClientChannelResetStream(t *testing.T) {
 			Stream: &webrtcpb.Stream{Id: 1},
 			Type: &webrtcpb.Request_Headers{
 				Headers: &webrtcpb.RequestHeaders{
-					Method:  "thing",
-					Timeout: durationpb.New(0),
+					Method:     "thing",
+					Timeout:    durationpb.New(0),
+					WindowSize: WebRTCStreamWindowSize,
 				},
 			},
 		},
@@ -384,6 +392,7 @@ func TestWebRTCClientChannelResetStream(t *testing.T) {
 			Type: &webrtcpb.Request_RstStream{
 				RstStream: true,
 			},
+			RstStreamStatus: status.New(codes.Canceled, context.Canceled.Error()).Proto(),
 		},
 	}
 
@@ -422,7 +431,8 @@ func TestWebRTCClientChannelResetStream(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, clientStream.SendMsg(someStatus.Proto()), test.ShouldBeNil)
 	err = clientStream.RecvMsg(&respStatus)
-	test.That(t, err, test.ShouldBeError, context.Canceled)
+	test.That(t, errors.Is(err, context.Canceled), test.ShouldBeTrue)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Canceled)
 	test.That(t, &respStatus, test.ShouldResemble, &pbstatus.Status{})
 
 	<-resetCh
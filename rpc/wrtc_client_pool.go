@@ -0,0 +1,259 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edaniels/golog"
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"go.viam.com/utils"
+)
+
+// WebRTCLoadBalancing decides which of several peer connections a call is made over.
+type WebRTCLoadBalancing int
+
+// Known load balancing strategies.
+const (
+	// WebRTCLoadBalancingRoundRobin makes calls over each peer connection in turn.
+	WebRTCLoadBalancingRoundRobin WebRTCLoadBalancing = iota
+
+	// WebRTCLoadBalancingLeastOutstanding makes calls over the peer connection with the
+	// fewest calls and streams in progress.
+	WebRTCLoadBalancingLeastOutstanding
+)
+
+// dialWebRTCPool establishes the given number of peer connections to the host and returns
+// them as one connection. It only fails if none of them can be established.
+func dialWebRTCPool(
+	ctx context.Context,
+	signalingServer string,
+	host string,
+	dOpts *dialOptions,
+	logger golog.Logger,
+) (ClientConn, error) {
+	size := dOpts.webrtcOpts.PeerConnections
+	type result struct {
+		ch  *webrtcClientChannel
+		err error
+	}
+	results := make(chan result, size)
+	for i := 0; i < size; i++ {
+		utils.PanicCapturingGo(func() {
+			ch, err := dialWebRTC(ctx, signalingServer, host, dOpts, logger)
+			results <- result{ch, err}
+		})
+	}
+
+	var members []*webrtcClientChannel
+	var errs error
+	for i := 0; i < size; i++ {
+		res := <-results
+		if res.err != nil {
+			errs = multierr.Combine(errs, res.err)
+			continue
+		}
+		members = append(members, res.ch)
+	}
+	if len(members) == 0 {
+		// keep the first error as is so that callers can tell what kind of failure it was
+		return nil, multierr.Errors(errs)[0]
+	}
+	if errs != nil {
+		logger.Warnw("could not establish all peer connections",
+			"established", len(members), "requested", size, "error", errs)
+	}
+	return newWebRTCClientPool(members, dOpts.webrtcOpts.LoadBalancing), nil
+}
+
+// A webrtcClientPool spreads calls out across several WebRTC peer connections to the same
+// host. Peer connections that close are no longer used; the pool is considered failed once
+// all of them have closed.
+type webrtcClientPool struct {
+	balancing WebRTCLoadBalancing
+
+	mu      sync.Mutex
+	members []*webrtcPoolMember
+	next    int
+	live    int
+	// done is closed once no members are left to make calls on.
+	done chan struct{}
+}
+
+type webrtcPoolMember struct {
+	ch          *webrtcClientChannel
+	outstanding int
+	closed      bool
+}
+
+func newWebRTCClientPool(channels []*webrtcClientChannel, balancing WebRTCLoadBalancing) *webrtcClientPool {
+	pool := &webrtcClientPool{
+		balancing: balancing,
+		live:      len(channels),
+		done:      make(chan struct{}),
+	}
+	for _, ch := range channels {
+		member := &webrtcPoolMember{ch: ch}
+		pool.members = append(pool.members, member)
+		utils.PanicCapturingGo(func() {
+			<-member.ch.ctx.Done()
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			member.closed = true
+			if pool.live--; pool.live == 0 {
+				close(pool.done)
+			}
+		})
+	}
+	return pool
+}
+
+// pick chooses a member to make a call over and counts the call as outstanding on it.
+// It returns nil if there are no members left.
+func (pool *webrtcClientPool) pick() *webrtcPoolMember {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	var picked *webrtcPoolMember
+	pickedIdx := -1
+	// start after the last pick so that ties are broken in turn
+	for i := 0; i < len(pool.members); i++ {
+		idx := (pool.next + i) % len(pool.members)
+		member := pool.members[idx]
+		if member.closed {
+			continue
+		}
+		if picked == nil || (pool.balancing == WebRTCLoadBalancingLeastOutstanding &&
+			member.outstanding < picked.outstanding) {
+			picked, pickedIdx = member, idx
+		}
+		if pool.balancing == WebRTCLoadBalancingRoundRobin {
+			break
+		}
+	}
+	if picked == nil {
+		return nil
+	}
+	pool.next = (pickedIdx + 1) % len(pool.members)
+	picked.outstanding++
+	return picked
+}
+
+func (pool *webrtcClientPool) release(member *webrtcPoolMember) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	member.outstanding--
+}
+
+// hasLiveMembers returns whether or not any calls can still be made.
+func (pool *webrtcClientPool) hasLiveMembers() bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return pool.live > 0
+}
+
+func (pool *webrtcClientPool) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	member := pool.pick()
+	if member == nil {
+		return errDataChannelClosed
+	}
+	defer pool.release(member)
+	return member.ch.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (pool *webrtcClientPool) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	member := pool.pick()
+	if member == nil {
+		return nil, errDataChannelClosed
+	}
+	stream, err := member.ch.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		pool.release(member)
+		return nil, err
+	}
+	// the stream's context is done once the stream is
+	utils.PanicCapturingGo(func() {
+		<-stream.Context().Done()
+		pool.release(member)
+	})
+	return stream, nil
+}
+
+func (pool *webrtcClientPool) Close() error {
+	var err error
+	for _, member := range pool.members {
+		err = multierr.Combine(err, member.ch.Close())
+	}
+	return err
+}
+
+// State is Ready if any peer connection is ready.
+func (pool *webrtcClientPool) State() connectivity.State {
+	state := connectivity.Shutdown
+	for _, member := range pool.members {
+		switch memberState := member.ch.State(); {
+		case memberState == connectivity.Ready:
+			return connectivity.Ready
+		case memberState == connectivity.Connecting || memberState == connectivity.Idle:
+			state = connectivity.Connecting
+		case memberState == connectivity.TransientFailure && state == connectivity.Shutdown:
+			state = connectivity.TransientFailure
+		}
+	}
+	return state
+}
+
+// WaitForStateChange waits until the state of the pool as a whole leaves sourceState.
+func (pool *webrtcClientPool) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	for {
+		if pool.State() != sourceState {
+			return true
+		}
+		waitCtx, cancel := context.WithCancel(ctx)
+		changed := make(chan struct{}, len(pool.members))
+		for _, member := range pool.members {
+			member := member
+			memberState := member.ch.State()
+			utils.PanicCapturingGo(func() {
+				if member.ch.WaitForStateChange(waitCtx, memberState) {
+					changed <- struct{}{}
+				}
+			})
+		}
+		select {
+		case <-ctx.Done():
+			cancel()
+			return false
+		case <-changed:
+			cancel()
+		}
+	}
+}
+
+// TransportInfo describes the first peer connection that is still open.
+func (pool *webrtcClientPool) TransportInfo() TransportInfo {
+	pool.mu.Lock()
+	var ch *webrtcClientChannel
+	for _, member := range pool.members {
+		if !member.closed {
+			ch = member.ch
+			break
+		}
+	}
+	pool.mu.Unlock()
+	if ch == nil {
+		return TransportInfo{Type: TransportTypeWebRTC}
+	}
+	return ch.TransportInfo()
+}
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc/connectivity"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestWebRTCClientPoolPick(t *testing.T) {
+	newPool := func(balancing WebRTCLoadBalancing) *webrtcClientPool {
+		return &webrtcClientPool{
+			balancing: balancing,
+			members:   []*webrtcPoolMember{{}, {}, {}},
+			live:      3,
+			done:      make(chan struct{}),
+		}
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		pool := newPool(WebRTCLoadBalancingRoundRobin)
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[0])
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[1])
+		pool.members[2].closed = true
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[0])
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[1])
+		test.That(t, pool.members[0].outstanding, test.ShouldEqual, 2)
+		pool.release(pool.members[0])
+		test.That(t, pool.members[0].outstanding, test.ShouldEqual, 1)
+
+		pool.members[0].closed = true
+		pool.members[1].closed = true
+		test.That(t, pool.pick(), test.ShouldBeNil)
+	})
+
+	t.Run("least outstanding", func(t *testing.T) {
+		pool := newPool(WebRTCLoadBalancingLeastOutstanding)
+		first := pool.pick()
+		second := pool.pick()
+		third := pool.pick()
+		test.That(t, []*webrtcPoolMember{first, second, third}, test.ShouldResemble, pool.members)
+
+		// everything is tied so it goes around again
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[0])
+
+		// the least busy wins
+		pool.release(pool.members[2])
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[2])
+		pool.release(pool.members[1])
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[1])
+
+		pool.members[1].closed = true
+		pool.release(pool.members[0])
+		pool.release(pool.members[0])
+		test.That(t, pool.pick(), test.ShouldEqual, pool.members[0])
+	})
+}
+
+func TestDialWebRTCPeerConnections(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingInsecure: true,
+			PeerConnections:   3,
+			LoadBalancing:     WebRTCLoadBalancingLeastOutstanding,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	pool, ok := unwrapClientConn(conn).(*webrtcClientPool)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, pool.members, test.ShouldHaveLength, 3)
+	test.That(t, conn.(ClientConnStateReporter).State(), test.ShouldEqual, connectivity.Ready)
+
+	client := pb.NewEchoServiceClient(conn)
+	for i := 0; i < 6; i++ {
+		resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldEqual, "hello")
+	}
+
+	// losing one peer connection leaves the others
+	test.That(t, pool.members[0].ch.Close(), test.ShouldBeNil)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		pool.mu.Lock()
+		closed := pool.members[0].closed
+		pool.mu.Unlock()
+		if closed {
+			break
+		}
+		test.That(t, time.Now().Before(deadline), test.ShouldBeTrue)
+		time.Sleep(10 * time.Millisecond)
+	}
+	test.That(t, pool.hasLiveMembers(), test.ShouldBeTrue)
+	test.That(t, isTransportFailure(conn, errDataChannelClosed), test.ShouldBeFalse)
+	for i := 0; i < 4; i++ {
+		resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "still here"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldEqual, "still here")
+	}
+	for _, member := range pool.members {
+		pool.mu.Lock()
+		test.That(t, member.outstanding, test.ShouldEqual, 0)
+		pool.mu.Unlock()
+	}
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	<-pool.done
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
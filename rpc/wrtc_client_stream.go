@@ -7,8 +7,8 @@ import (
 	"math"
 
 	"github.com/edaniels/golog"
-	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -46,6 +46,8 @@ func newWebRTCClientStream(
 ) *webrtcClientStream {
 	ctx, cancel := context.WithCancel(ctx)
 	bs := newWebRTCBaseStream(ctx, cancel, stream, onDone, logger)
+	bs.recvWindowSize = WebRTCStreamWindowSize
+	bs.writeWindowUpdate = channel.writeWindowUpdate
 	s := &webrtcClientStream{
 		webrtcBaseStream: bs,
 		ctx:              ctx,
@@ -58,7 +60,7 @@ func newWebRTCClientStream(
 		defer channel.activeBackgroundWorkers.Done()
 		<-ctx.Done()
 		if !s.webrtcBaseStream.Closed() {
-			if err := s.resetStream(); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			if err := s.resetStream(ctx.Err()); err != nil && !errors.Is(err, io.ErrClosedPipe) {
 				s.webrtcBaseStream.logger.Errorw("error resetting stream", "error", err)
 			}
 		}
@@ -112,7 +114,7 @@ func (s *webrtcClientStream) Header() (metadata.MD, error) {
 
 	select {
 	case <-s.ctx.Done():
-		return nil, s.ctx.Err()
+		return nil, toContextStatusError(s.ctx.Err())
 	case <-s.headersReceived:
 		return s.headers, nil
 	}
@@ -144,9 +146,10 @@ func checkWriteErrForStreamClose(err error) error {
 	return err
 }
 
-// resetStream cancels the stream and sends a reset signal.
+// resetStream cancels the stream and sends a reset signal telling the server why, which is
+// the context error the stream was cancelled with.
 // It is also not safe to call concurrently with SendMsg.
-func (s *webrtcClientStream) resetStream() (err error) {
+func (s *webrtcClientStream) resetStream(reason error) (err error) {
 	s.webrtcBaseStream.mu.Lock()
 	defer s.webrtcBaseStream.mu.Unlock()
 
@@ -159,7 +162,18 @@ func (s *webrtcClientStream) resetStream() (err error) {
 	defer func() {
 		s.webrtcBaseStream.closeWithError(checkWriteErrForStreamClose(err), false)
 	}()
-	return s.ch.writeReset(s.webrtcBaseStream.stream)
+	return s.ch.writeReset(s.webrtcBaseStream.stream, reason)
+}
+
+// fail ends the stream with an error found on the client's end and resets the stream so that
+// the server stops working on the call too.
+func (s *webrtcClientStream) fail(err error) {
+	s.webrtcBaseStream.mu.Lock()
+	s.webrtcBaseStream.closeWithError(err, false)
+	s.webrtcBaseStream.mu.Unlock()
+	if err := s.resetStream(nil); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		s.webrtcBaseStream.logger.Errorw("error resetting stream", "error", err)
+	}
 }
 
 func (s *webrtcClientStream) Close() {
@@ -173,6 +187,36 @@ func (s *webrtcClientStream) close() {
 	s.webrtcBaseStream.close()
 }
 
+// useCompressor compresses messages sent on the stream with the given encoding, if any, and
+// returns the encoding to tell the server about. It must be called before anything is sent.
+func (s *webrtcClientStream) useCompressor(name string) (string, error) {
+	comp, ok := compressorForEncoding(name)
+	if !ok {
+		return "", status.Errorf(codes.Internal, "grpc: Compressor is not installed for requested grpc-encoding %q", name)
+	}
+	s.webrtcBaseStream.sendCompressor = comp
+	if comp == nil {
+		return "", nil
+	}
+	return name, nil
+}
+
+// limitMessageSizes sets the largest messages that may be received and sent on the stream,
+// where zero keeps the default, and returns the receive limit to tell the server about. It
+// must be called before anything is sent.
+func (s *webrtcClientStream) limitMessageSizes(recv, send int) uint32 {
+	if recv > 0 {
+		s.webrtcBaseStream.maxRecvMsgSize = recv
+	}
+	if send > 0 {
+		s.webrtcBaseStream.maxSendMsgSize = send
+	}
+	if uint64(s.webrtcBaseStream.maxRecvMsgSize) > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(s.webrtcBaseStream.maxRecvMsgSize)
+}
+
 // writeHeaders is assumed to be called by the client channel in a single goroutine not
 // overlapping with any other write.
 func (s *webrtcClientStream) writeHeaders(headers *webrtcpb.RequestHeaders) (err error) {
@@ -184,6 +228,7 @@ func (s *webrtcClientStream) writeHeaders(headers *webrtcpb.RequestHeaders) (err
 			s.webrtcBaseStream.closeWithError(err, false)
 		}
 	}()
+	headers.WindowSize = s.webrtcBaseStream.recvWindowSize
 	return s.ch.writeHeaders(s.webrtcBaseStream.stream, headers)
 }
 
@@ -213,6 +258,19 @@ func init() {
 }
 
 func (s *webrtcClientStream) writeMessage(m interface{}, eos bool) (err error) {
+	var data []byte
+	if m != nil {
+		if data, err = s.webrtcBaseStream.marshal(m); err != nil {
+			// the server would otherwise keep waiting for the message
+			s.fail(err)
+			return err
+		}
+		// not while holding the lock since the update comes in with everything else
+		if err := s.webrtcBaseStream.waitForSendWindow(); err != nil {
+			return err
+		}
+	}
+
 	s.webrtcBaseStream.mu.RLock()
 	if s.sendClosed {
 		s.webrtcBaseStream.mu.RUnlock()
@@ -238,15 +296,8 @@ func (s *webrtcClientStream) writeMessage(m interface{}, eos bool) (err error) {
 		}
 	}()
 
-	var data []byte
 	if m != nil {
-		if v1Msg, ok := m.(protov1.Message); ok {
-			m = protov1.MessageV2(v1Msg)
-		}
-		data, err = proto.Marshal(m.(proto.Message))
-		if err != nil {
-			return
-		}
+		s.webrtcBaseStream.sendWindow.sent(len(data))
 	}
 
 	if len(data) == 0 {
@@ -310,6 +361,10 @@ func (s *webrtcClientStream) onResponse(resp *webrtcpb.Response) {
 		s.processMessage(r.Message)
 	case *webrtcpb.Response_Trailers:
 		s.processTrailers(r.Trailers)
+	case *webrtcpb.Response_WindowUpdate:
+		// the server does flow control, so it respects our window too
+		s.webrtcBaseStream.enableRecvFlowControl()
+		s.webrtcBaseStream.sendWindow.grant(r.WindowUpdate.GetIncrement())
 	default:
 		s.webrtcBaseStream.logger.Errorf("unknown response type %T", r)
 	}
@@ -318,6 +373,17 @@ func (s *webrtcClientStream) onResponse(resp *webrtcpb.Response) {
 func (s *webrtcClientStream) processHeaders(headers *webrtcpb.ResponseHeaders) {
 	s.webrtcBaseStream.mu.Lock()
 	s.headers = metadataFromProto(headers.Metadata)
+	if respEncoding := encodingFromMetadata(s.headers); respEncoding != "" {
+		comp, ok := compressorForEncoding(respEncoding)
+		if !ok {
+			s.webrtcBaseStream.closeWithError(status.Errorf(codes.Internal,
+				"grpc: Decompressor is not installed for grpc-encoding %q", respEncoding), false)
+			s.webrtcBaseStream.mu.Unlock()
+			return
+		}
+		s.webrtcBaseStream.recvCompressor = comp
+		delete(s.headers, headerGRPCEncoding)
+	}
 	s.userCtx = metadata.NewIncomingContext(s.ctx, s.headers)
 	s.webrtcBaseStream.mu.Unlock()
 	close(s.headersReceived)
@@ -328,26 +394,28 @@ func (s *webrtcClientStream) processMessage(msg *webrtcpb.ResponseMessage) {
 		s.webrtcBaseStream.logger.Error("message received after trailers")
 		return
 	}
-	data, eop := s.webrtcBaseStream.processMessage(msg.PacketMessage)
-	if !eop {
+	data, eop, err := s.webrtcBaseStream.processMessage(msg.PacketMessage)
+	if err != nil {
+		s.fail(err)
 		return
 	}
-	s.webrtcBaseStream.mu.Lock()
-	if s.webrtcBaseStream.recvClosed.Load() {
-		s.webrtcBaseStream.mu.Unlock()
+	if !eop {
 		return
 	}
-	msgCh := s.webrtcBaseStream.msgCh
-	s.webrtcBaseStream.activeSenders.Add(1)
-	s.webrtcBaseStream.mu.Unlock()
+	if err := s.webrtcBaseStream.enqueueMessage(data); err != nil {
+		s.fail(err)
+	}
+}
 
-	func() {
-		defer s.webrtcBaseStream.activeSenders.Done()
-		select {
-		case msgCh <- data:
-		case <-s.ctx.Done():
-		}
-	}()
+// onUnreliableMessage handles a whole message that came over an unreliable data channel.
+// It may have overtaken the headers, in which case it is dropped.
+func (s *webrtcClientStream) onUnreliableMessage(data []byte) {
+	select {
+	case <-s.headersReceived:
+	default:
+		return
+	}
+	s.webrtcBaseStream.enqueueUnreliableMessage(data)
 }
 
 func (s *webrtcClientStream) processTrailers(trailers *webrtcpb.ResponseTrailers) {
@@ -7,6 +7,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/google/uuid"
@@ -72,6 +73,7 @@ func testWebRTCClientServer(t *testing.T, signalingCallQueue WebRTCCallQueue, lo
 		webrtcServer,
 		[]DialOption{WithInsecure()},
 		webrtc.Configuration{},
+		0,
 		logger,
 	)
 	answerer.Start()
@@ -116,6 +118,78 @@ func testWebRTCClientServer(t *testing.T, signalingCallQueue WebRTCCallQueue, lo
 	test.That(t, <-serveDone, test.ShouldBeNil)
 }
 
+func TestWebRTCClientServerLongOfferDeadline(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	signalingCallQueue := NewMemoryWebRTCCallQueue(logger)
+	defer func() {
+		test.That(t, signalingCallQueue.Close(), test.ShouldBeNil)
+	}()
+
+	offerDeadline := getDefaultOfferDeadline() * 2
+	signalingServer := NewWebRTCSignalingServerWithOptions(
+		signalingCallQueue, nil, logger, WithSignalingOfferDeadline(offerDeadline))
+	defer signalingServer.Close()
+
+	grpcListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(&webrtcpb.SignalingService_ServiceDesc, signalingServer)
+
+	serveDone := make(chan error)
+	go func() {
+		serveDone <- grpcServer.Serve(grpcListener)
+	}()
+
+	webrtcServer := newWebRTCServer(logger)
+	webrtcServer.RegisterService(&echopb.EchoService_ServiceDesc, &echoserver.Server{})
+
+	answerer := newWebRTCSignalingAnswerer(
+		grpcListener.Addr().String(),
+		[]string{"yeehaw"},
+		webrtcServer,
+		[]DialOption{WithInsecure()},
+		webrtc.Configuration{},
+		offerDeadline,
+		logger,
+	)
+
+	// the host only shows up after the default deadline has passed
+	answererDelay := getDefaultOfferDeadline() + time.Second
+	answererStarted := make(chan struct{})
+	go func() {
+		defer close(answererStarted)
+		time.Sleep(answererDelay)
+		answerer.Start()
+	}()
+
+	start := time.Now()
+	cc, err := DialWebRTC(
+		context.Background(),
+		grpcListener.Addr().String(),
+		"yeehaw",
+		logger,
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingInsecure: true,
+			OfferDeadline:     offerDeadline,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, answererDelay)
+
+	echoClient := echopb.NewEchoServiceClient(cc)
+	resp, err := echoClient.Echo(context.Background(), &echopb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+	test.That(t, cc.Close(), test.ShouldBeNil)
+
+	<-answererStarted
+	webrtcServer.Stop()
+	answerer.Stop()
+	grpcServer.Stop()
+	test.That(t, <-serveDone, test.ShouldBeNil)
+}
+
 func TestWebRTCClientDialCancelWithMemoryQueue(t *testing.T) {
 	testutils.SkipUnlessInternet(t)
 	logger := golog.NewTestLogger(t)
@@ -481,6 +555,7 @@ func testWebRTCClientAnswerConcurrent(t *testing.T, signalingCallQueue WebRTCCal
 		webrtcServer,
 		[]DialOption{WithInsecure()},
 		webrtc.Configuration{},
+		0,
 		logger,
 	)
 	answerer.Start()
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"bytes"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	// registers gzip so that it is always available.
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Messages over WebRTC are compressed the same way they are over direct gRPC. A client
+// asks for a compressor with grpc.UseCompressor (or WithCompressor) and says so in the
+// grpc-encoding request header; every message it sends on the stream is then compressed.
+// A server that has the compressor registered (see encoding.RegisterCompressor) answers
+// with the same grpc-encoding response header and compresses its messages too. Otherwise
+// it fails the call with Unimplemented. gzip is always registered; others like zstd or
+// snappy can be registered by the application on both ends.
+const headerGRPCEncoding = "grpc-encoding"
+
+// compressorForEncoding returns the registered compressor for the given encoding. No
+// encoding means no compression.
+func compressorForEncoding(name string) (encoding.Compressor, bool) {
+	if name == "" || name == "identity" {
+		return nil, true
+	}
+	comp := encoding.GetCompressor(name)
+	return comp, comp != nil
+}
+
+// encodingFromMetadata returns the grpc-encoding in md, if any.
+func encodingFromMetadata(md metadata.MD) string {
+	if values := md.Get(headerGRPCEncoding); len(values) != 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// compressMessage compresses a marshaled message. Empty messages are sent as is.
+func compressMessage(comp encoding.Compressor, data []byte) ([]byte, error) {
+	if comp == nil || len(data) == 0 {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	w, err := comp.Compress(&buf)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "grpc: error while compressing: %v", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, status.Errorf(codes.Internal, "grpc: error while compressing: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "grpc: error while compressing: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressMessage decompresses a received message without letting it grow past maxSize.
+func decompressMessage(comp encoding.Compressor, data []byte, maxSize int) ([]byte, error) {
+	if comp == nil || len(data) == 0 {
+		return data, nil
+	}
+	r, err := comp.Decompress(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "grpc: failed to decompress the received message: %v", err)
+	}
+	decompressed, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "grpc: failed to decompress the received message: %v", err)
+	}
+	if len(decompressed) > maxSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"grpc: received message after decompression larger than max %d", maxSize)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+// countingCompressor is gzip that counts how often it is used.
+type countingCompressor struct {
+	compressed   atomic.Int64
+	decompressed atomic.Int64
+}
+
+func (c *countingCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	c.compressed.Add(1)
+	return encoding.GetCompressor(gzip.Name).Compress(w)
+}
+
+func (c *countingCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	c.decompressed.Add(1)
+	return encoding.GetCompressor(gzip.Name).Decompress(r)
+}
+
+func (c *countingCompressor) Name() string {
+	return "test-counting"
+}
+
+var testCountingCompressor = &countingCompressor{}
+
+func init() {
+	encoding.RegisterCompressor(testCountingCompressor)
+}
+
+func TestWebRTCCompressMessage(t *testing.T) {
+	comp, ok := compressorForEncoding(gzip.Name)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, comp, test.ShouldNotBeNil)
+
+	comp2, ok := compressorForEncoding("")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, comp2, test.ShouldBeNil)
+
+	_, ok = compressorForEncoding("bogus")
+	test.That(t, ok, test.ShouldBeFalse)
+
+	data := []byte(strings.Repeat("hello", 1000))
+	compressed, err := compressMessage(comp, data)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(compressed), test.ShouldBeLessThan, len(data))
+	decompressed, err := decompressMessage(comp, compressed, MaxMessageSize)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, decompressed, test.ShouldResemble, data)
+
+	// empty messages are left alone
+	compressed, err = compressMessage(comp, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, compressed, test.ShouldBeEmpty)
+	decompressed, err = decompressMessage(comp, nil, MaxMessageSize)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, decompressed, test.ShouldBeEmpty)
+
+	_, err = decompressMessage(comp, []byte("not gzip"), MaxMessageSize)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Internal)
+
+	// a message cannot grow past the max once decompressed
+	compressed, err = compressMessage(comp, bytes.Repeat([]byte{0}, MaxMessageSize+1))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(compressed), test.ShouldBeLessThan, MaxMessageSize)
+	_, err = decompressMessage(comp, compressed, MaxMessageSize)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+}
+
+type encodingEchoServer struct {
+	echoserver.Server
+	lastEncoding atomic.Value
+}
+
+func (srv *encodingEchoServer) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	srv.lastEncoding.Store(strings.Join(md.Get(headerGRPCEncoding), ","))
+	return srv.Server.Echo(ctx, req)
+}
+
+func TestWebRTCCompression(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	echoServer := &encodingEchoServer{}
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		echoServer,
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+	bigMessage := strings.Repeat("hello", 100000)
+
+	t.Run("per call", func(t *testing.T) {
+		compressedBefore := testCountingCompressor.compressed.Load()
+		decompressedBefore := testCountingCompressor.decompressed.Load()
+
+		var header metadata.MD
+		resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: bigMessage},
+			grpc.UseCompressor(testCountingCompressor.Name()), grpc.Header(&header))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldEqual, bigMessage)
+
+		// the request and the response are each compressed and decompressed once
+		test.That(t, testCountingCompressor.compressed.Load()-compressedBefore, test.ShouldEqual, 2)
+		test.That(t, testCountingCompressor.decompressed.Load()-decompressedBefore, test.ShouldEqual, 2)
+
+		// the encoding is not visible as metadata
+		test.That(t, echoServer.lastEncoding.Load(), test.ShouldEqual, "")
+		test.That(t, header.Get(headerGRPCEncoding), test.ShouldBeEmpty)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		stream, err := client.EchoBiDi(context.Background(), grpc.UseCompressor(gzip.Name))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream.Send(&pb.EchoBiDiRequest{Message: "hello"}), test.ShouldBeNil)
+		for _, char := range "hello" {
+			resp, err := stream.Recv()
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, resp.Message, test.ShouldEqual, string(char))
+		}
+		test.That(t, stream.CloseSend(), test.ShouldBeNil)
+	})
+
+	t.Run("unknown compressor", func(t *testing.T) {
+		_, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"}, grpc.UseCompressor("bogus"))
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Internal)
+	})
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	// by default for every call
+	conn, err = DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+		WithCompressor(testCountingCompressor.Name()),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	client = pb.NewEchoServiceClient(conn)
+	compressedBefore := testCountingCompressor.compressed.Load()
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: bigMessage})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, bigMessage)
+	test.That(t, testCountingCompressor.compressed.Load()-compressedBefore, test.ShouldEqual, 2)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
@@ -0,0 +1,299 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pion/webrtc/v3"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+)
+
+// DataChannelOptions describe an additional data channel to open on a WebRTC peer
+// connection alongside the default one. Each data channel is its own SCTP stream, so
+// streams on different data channels do not hold each other up.
+type DataChannelOptions struct {
+	// Name is how calls ask for the data channel with UseDataChannel. Unnamed data channels
+	// are shared with the default one by calls that do not ask for any, with each call going
+	// to the one with the fewest active streams.
+	Name string
+
+	// Unreliable makes the data channel unordered and without retransmissions. A call asking
+	// for it still runs over the default data channel, but response messages small enough to
+	// fit in a single packet are sent over this one instead, where they may be lost or
+	// arrive out of order. This suits latency sensitive telemetry where only the latest
+	// message matters. Unreliable data channels must be named.
+	Unreliable bool
+}
+
+// additional data channels are labeled with this prefix so that servers know to service them.
+const dataChannelLabelPrefix = "data:"
+
+// firstAdditionalDataChannelID comes after the default and renegotiation data channels.
+const firstAdditionalDataChannelID = 2
+
+// UseDataChannel returns a CallOption which makes a call over the data channel with the
+// given name from DialWebRTCOptions.DataChannels. It has no effect on calls that are not
+// made over WebRTC.
+func UseDataChannel(name string) grpc.CallOption {
+	return dataChannelCallOption{name: name}
+}
+
+type dataChannelCallOption struct {
+	grpc.EmptyCallOption
+	name string
+}
+
+func dataChannelLabel(index int, opts DataChannelOptions) string {
+	if opts.Name != "" {
+		return dataChannelLabelPrefix + opts.Name
+	}
+	return dataChannelLabelPrefix + strconv.Itoa(index)
+}
+
+// openDataChannels opens the given additional data channels and waits for them to be ready.
+// The server must know about additional data channels; older servers accept them but never
+// answer on them.
+func (ch *webrtcClientChannel) openDataChannels(ctx context.Context, opts []DataChannelOptions) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	ch.lanes = []*webrtcClientChannel{ch}
+	ch.namedLanes = map[string]*webrtcClientChannel{}
+	ch.unreliable = map[string]*webrtc.DataChannel{}
+
+	names := map[string]bool{}
+	var ready []<-chan struct{}
+	for i, dcOpts := range opts {
+		if dcOpts.Name != "" {
+			if names[dcOpts.Name] {
+				return errors.Errorf("data channel %q given more than once", dcOpts.Name)
+			}
+			names[dcOpts.Name] = true
+		} else if dcOpts.Unreliable {
+			return errors.New("unreliable data channels must be named")
+		}
+
+		id := uint16(firstAdditionalDataChannelID + i)
+		init := &webrtc.DataChannelInit{ID: &id}
+		if dcOpts.Unreliable {
+			ordered := false
+			maxRetransmits := uint16(0)
+			init.Ordered = &ordered
+			init.MaxRetransmits = &maxRetransmits
+		}
+		dataChannel, err := ch.peerConn.CreateDataChannel(dataChannelLabel(i, dcOpts), init)
+		if err != nil {
+			return err
+		}
+
+		if dcOpts.Unreliable {
+			opened := make(chan struct{})
+			dataChannel.OnOpen(func() {
+				close(opened)
+			})
+			dataChannel.OnMessage(ch.onUnreliableMessage)
+			ch.unreliable[dcOpts.Name] = dataChannel
+			ready = append(ready, opened)
+			continue
+		}
+
+		lane := newWebRTCClientLane(ch, dataChannel)
+		if dcOpts.Name == "" {
+			ch.lanes = append(ch.lanes, lane)
+		} else {
+			ch.namedLanes[dcOpts.Name] = lane
+		}
+		ready = append(ready, lane.Ready())
+	}
+
+	for _, opened := range ready {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-opened:
+		}
+	}
+	return nil
+}
+
+// newWebRTCClientLane wraps an additional data channel on the peer connection of the given
+// channel to make calls over.
+func newWebRTCClientLane(primary *webrtcClientChannel, dataChannel *webrtc.DataChannel) *webrtcClientChannel {
+	ch := &webrtcClientChannel{
+		webrtcBaseChannel: newSecondaryBaseChannel(primary.webrtcBaseChannel, dataChannel),
+		streams:           map[uint64]activeWebRTCClientStream{},
+	}
+	dataChannel.OnMessage(ch.onChannelMessage)
+	ch.failStreamsOnClose()
+	return ch
+}
+
+// additionalLanes returns the channels of every additional reliable data channel.
+func (ch *webrtcClientChannel) additionalLanes() []*webrtcClientChannel {
+	var lanes []*webrtcClientChannel
+	if len(ch.lanes) > 1 {
+		lanes = append(lanes, ch.lanes[1:]...)
+	}
+	for _, lane := range ch.namedLanes {
+		lanes = append(lanes, lane)
+	}
+	return lanes
+}
+
+// laneFor picks the data channel to make a call over. If the call asked for an unreliable
+// data channel, its label is returned too so the server can be asked to respond over it.
+func (ch *webrtcClientChannel) laneFor(opts []grpc.CallOption) (*webrtcClientChannel, string, error) {
+	var name string
+	var asked bool
+	for _, opt := range opts {
+		if dcOpt, ok := opt.(dataChannelCallOption); ok {
+			name = dcOpt.name
+			asked = true
+		}
+	}
+	if asked {
+		if lane, ok := ch.namedLanes[name]; ok {
+			return lane, "", nil
+		}
+		if dataChannel, ok := ch.unreliable[name]; ok {
+			return ch, dataChannel.Label(), nil
+		}
+		return nil, "", status.Errorf(codes.InvalidArgument, "no data channel named %q", name)
+	}
+	if len(ch.lanes) <= 1 {
+		return ch, "", nil
+	}
+
+	best, bestCount := ch, -1
+	for _, lane := range ch.lanes {
+		if closed, _ := lane.Closed(); closed {
+			continue
+		}
+		lane.mu.Lock()
+		count := len(lane.streams)
+		lane.mu.Unlock()
+		if bestCount == -1 || count < bestCount {
+			best, bestCount = lane, count
+		}
+	}
+	return best, "", nil
+}
+
+// onUnreliableMessage handles a response message sent over an unreliable data channel.
+func (ch *webrtcClientChannel) onUnreliableMessage(msg webrtc.DataChannelMessage) {
+	resp := &webrtcpb.Response{}
+	if err := proto.Unmarshal(msg.Data, resp); err != nil {
+		ch.webrtcBaseChannel.logger.Errorw("error unmarshaling message; discarding", "error", err)
+		return
+	}
+	ch.webrtcBaseChannel.onReceived()
+	respMsg := resp.GetMessage()
+	if resp.Stream == nil || respMsg == nil || !respMsg.GetPacketMessage().GetEom() {
+		ch.webrtcBaseChannel.logger.Debug("expected a whole message over unreliable data channel; discarding")
+		return
+	}
+
+	ch.mu.Lock()
+	activeStream, ok := ch.streams[resp.Stream.Id]
+	ch.mu.Unlock()
+	if !ok {
+		return
+	}
+	activeStream.cs.onUnreliableMessage(respMsg.PacketMessage.Data)
+}
+
+// webrtcUnreliableDataChannels are the unreliable data channels a client opened on a peer
+// connection, by label.
+type webrtcUnreliableDataChannels struct {
+	mu      sync.Mutex
+	byLabel map[string]*webrtc.DataChannel
+}
+
+func newWebRTCUnreliableDataChannels() *webrtcUnreliableDataChannels {
+	return &webrtcUnreliableDataChannels{byLabel: map[string]*webrtc.DataChannel{}}
+}
+
+func (dcs *webrtcUnreliableDataChannels) add(dataChannel *webrtc.DataChannel) {
+	label := dataChannel.Label()
+	dcs.mu.Lock()
+	dcs.byLabel[label] = dataChannel
+	dcs.mu.Unlock()
+	dataChannel.OnClose(func() {
+		dcs.mu.Lock()
+		if dcs.byLabel[label] == dataChannel {
+			delete(dcs.byLabel, label)
+		}
+		dcs.mu.Unlock()
+	})
+}
+
+func (dcs *webrtcUnreliableDataChannels) get(label string) *webrtc.DataChannel {
+	dcs.mu.Lock()
+	defer dcs.mu.Unlock()
+	return dcs.byLabel[label]
+}
+
+// onDataChannel services an additional data channel opened by the client.
+func (ch *webrtcServerChannel) onDataChannel(dataChannel *webrtc.DataChannel) {
+	if !strings.HasPrefix(dataChannel.Label(), dataChannelLabelPrefix) {
+		ch.webrtcBaseChannel.logger.Debugw("ignoring unknown data channel", "label", dataChannel.Label())
+		return
+	}
+	if !dataChannel.Ordered() || dataChannel.MaxRetransmits() != nil {
+		ch.unreliable.add(dataChannel)
+		return
+	}
+	newWebRTCServerLane(ch, dataChannel)
+}
+
+// newWebRTCServerLane wraps an additional data channel on the peer connection of the given
+// channel to service calls over.
+func newWebRTCServerLane(primary *webrtcServerChannel, dataChannel *webrtc.DataChannel) *webrtcServerChannel {
+	ch := &webrtcServerChannel{
+		webrtcBaseChannel: newSecondaryBaseChannel(primary.webrtcBaseChannel, dataChannel),
+		authAudience:      primary.authAudience,
+		server:            primary.server,
+		streams:           make(map[uint64]*webrtcServerStream),
+		unreliable:        primary.unreliable,
+	}
+	dataChannel.OnMessage(ch.onChannelMessage)
+	return ch
+}
+
+// writeUnreliableMessage sends a whole message over an unreliable data channel. Rather than
+// waiting for the data channel to drain, the message is dropped.
+func (ch *webrtcServerChannel) writeUnreliableMessage(
+	dataChannel *webrtc.DataChannel,
+	stream *webrtcpb.Stream,
+	data []byte,
+) error {
+	if dataChannel.BufferedAmount() >= bufferThreshold {
+		return nil
+	}
+	msg, err := proto.Marshal(&webrtcpb.Response{
+		Stream: stream,
+		Type: &webrtcpb.Response_Message{
+			Message: &webrtcpb.ResponseMessage{
+				PacketMessage: &webrtcpb.PacketMessage{
+					Data: data,
+					Eom:  true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := dataChannel.Send(msg); err != nil {
+		ch.webrtcBaseChannel.logger.Debugw("error sending over unreliable data channel", "error", err)
+	}
+	return nil
+}
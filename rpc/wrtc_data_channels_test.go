@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestWebRTCDataChannels(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingInsecure: true,
+			DataChannels: []DataChannelOptions{
+				{},
+				{Name: "bulk"},
+				{Name: "telemetry", Unreliable: true},
+			},
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+	resp, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"}, UseDataChannel("bulk"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+	_, err = client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"}, UseDataChannel("nope"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.InvalidArgument)
+
+	// responses may be lost but never exceed what was sent
+	multiClient, err := client.EchoMultiple(
+		context.Background(), &pb.EchoMultipleRequest{Message: "hello"}, UseDataChannel("telemetry"))
+	test.That(t, err, test.ShouldBeNil)
+	var received int
+	for {
+		resp, err := multiClient.Recv()
+		if err != nil {
+			test.That(t, err, test.ShouldEqual, io.EOF)
+			break
+		}
+		test.That(t, resp.Message, test.ShouldHaveLength, 1)
+		received++
+	}
+	test.That(t, received, test.ShouldBeLessThanOrEqualTo, len("hello"))
+
+	// streams are spread out across the unnamed data channels
+	clientCh, ok := conn.(*webrtcClientChannel)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, clientCh.lanes, test.ShouldHaveLength, 2)
+	streamCtx, streamCancel := context.WithCancel(context.Background())
+	for i := 0; i < 4; i++ {
+		_, err := client.EchoBiDi(streamCtx)
+		test.That(t, err, test.ShouldBeNil)
+	}
+	for _, lane := range clientCh.lanes {
+		lane.mu.Lock()
+		test.That(t, lane.streams, test.ShouldHaveLength, 2)
+		lane.mu.Unlock()
+	}
+	streamCancel()
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+)
+
+// WebRTCStreamWindowSize is how many bytes of messages the other end of a stream may send
+// before they have been received by the application. Windows are per stream so that a slow
+// consumer of one stream only holds up that stream and not every other stream sharing the
+// data channel. Setting it to zero turns off asking for flow control.
+//
+// Flow control is negotiated per stream. A client asks for it by setting window_size in its
+// request headers, which is the window the server has to send responses in. A server that
+// supports it replies with a WindowUpdate granting the client its own window before anything
+// else. From then on, each end sends a WindowUpdate as the application consumes what was
+// received. Either end that never hears about flow control from the other sends without
+// limits, like before, which keeps older clients and servers working.
+var WebRTCStreamWindowSize = uint32(1024 * 1024)
+
+// A webrtcSendWindow tracks how many more bytes of messages a stream may send. Until the
+// other end grants a window, it is assumed to not do flow control and sending is not limited.
+type webrtcSendWindow struct {
+	mu      sync.Mutex
+	enabled bool
+	window  int64
+	changed chan struct{}
+}
+
+func newWebRTCSendWindow() *webrtcSendWindow {
+	return &webrtcSendWindow{changed: make(chan struct{})}
+}
+
+// grant allows increment more bytes to be sent.
+func (w *webrtcSendWindow) grant(increment uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.enabled = true
+	w.window += int64(increment)
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// wait blocks until a message may be sent. A message is allowed to use more than what is
+// left of the window so that messages larger than the whole window can still be sent.
+func (w *webrtcSendWindow) wait(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		if !w.enabled || w.window > 0 {
+			w.mu.Unlock()
+			return nil
+		}
+		changed := w.changed
+		w.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// sent takes n bytes out of the window. Bytes sent before any grant are counted too since
+// the other end counts them when granting more.
+func (w *webrtcSendWindow) sent(n int) {
+	w.mu.Lock()
+	w.window -= int64(n)
+	w.mu.Unlock()
+}
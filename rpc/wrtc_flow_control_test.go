@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestWebRTCSendWindow(t *testing.T) {
+	w := newWebRTCSendWindow()
+
+	// no limit until the other end grants a window
+	w.sent(100)
+	test.That(t, w.wait(context.Background()), test.ShouldBeNil)
+
+	w.grant(150)
+	test.That(t, w.wait(context.Background()), test.ShouldBeNil)
+
+	// a message can overdraw what is left
+	w.sent(100)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	test.That(t, w.wait(ctx), test.ShouldEqual, context.DeadlineExceeded)
+
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- w.wait(context.Background())
+	}()
+	w.grant(50)
+	select {
+	case err := <-waitErr:
+		t.Fatalf("expected to still be waiting but got %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	w.grant(1)
+	test.That(t, <-waitErr, test.ShouldBeNil)
+}
+
+func TestWebRTCBaseStreamRecvWindow(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bs := newWebRTCBaseStream(ctx, cancel, &webrtcpb.Stream{Id: 1}, func(id uint64) {}, logger)
+	bs.recvWindowSize = 10
+	bs.writeWindowUpdate = func(stream *webrtcpb.Stream, update *webrtcpb.WindowUpdate) error {
+		return nil
+	}
+	bs.enableRecvFlowControl()
+
+	data, err := proto.Marshal(&webrtcpb.Stream{Id: 1 << 40})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(data), test.ShouldBeLessThan, 10)
+
+	// the message that fills the window may go past it, but nothing more may come
+	test.That(t, bs.enqueueMessage(data), test.ShouldBeNil)
+	test.That(t, bs.enqueueMessage(data), test.ShouldBeNil)
+	err = bs.enqueueMessage(data)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+
+	// reading makes room again
+	var msg webrtcpb.Stream
+	test.That(t, bs.RecvMsg(&msg), test.ShouldBeNil)
+	test.That(t, msg.Id, test.ShouldEqual, uint64(1<<40))
+	test.That(t, bs.enqueueMessage(data), test.ShouldBeNil)
+}
+
+// floodingEchoServer responds to EchoMultiple with the whole message until told to stop.
+type floodingEchoServer struct {
+	echoserver.Server
+	sent atomic.Int64
+}
+
+func (srv *floodingEchoServer) EchoMultiple(
+	req *pb.EchoMultipleRequest,
+	server pb.EchoService_EchoMultipleServer,
+) error {
+	for {
+		if err := server.Send(&pb.EchoMultipleResponse{Message: req.Message}); err != nil {
+			return err
+		}
+		srv.sent.Add(1)
+	}
+}
+
+func TestWebRTCStreamFlowControl(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	echoServer := &floodingEchoServer{}
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		echoServer,
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+
+	// a stream that is not read from
+	msgSize := 100 * 1024
+	streamCtx, streamCancel := context.WithCancel(context.Background())
+	stream, err := client.EchoMultiple(streamCtx, &pb.EchoMultipleRequest{Message: strings.Repeat("a", msgSize)})
+	test.That(t, err, test.ShouldBeNil)
+
+	// the server can only get a window ahead
+	maxAhead := int64(WebRTCStreamWindowSize)/int64(msgSize) + 2
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, echoServer.sent.Load(), test.ShouldBeGreaterThanOrEqualTo, maxAhead-2)
+	})
+	time.Sleep(100 * time.Millisecond)
+	test.That(t, echoServer.sent.Load(), test.ShouldBeLessThanOrEqualTo, maxAhead)
+
+	// other calls are not held up by it
+	for i := 0; i < 3; i++ {
+		resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldEqual, "hello")
+	}
+
+	// reading lets the server send more
+	for i := int64(0); i < maxAhead*2; i++ {
+		resp, err := stream.Recv()
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Message, test.ShouldHaveLength, msgSize)
+	}
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, echoServer.sent.Load(), test.ShouldBeGreaterThan, maxAhead*2)
+	})
+
+	streamCancel()
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
@@ -0,0 +1,321 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pion/webrtc/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/utils"
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+)
+
+// WebRTCICERestartGracePeriod is how long a server holds on to a peer connection whose ICE
+// connection dropped, giving the client the chance to restart ICE, such as after switching
+// networks, before giving up on it. It only applies to clients that said they restart ICE
+// when connecting; connections from any other client are given up on right away.
+var WebRTCICERestartGracePeriod = 30 * time.Second
+
+const (
+	// webrtcICERestartAttempts is how many times in a row a client tries to restart ICE before
+	// giving up on the connection.
+	webrtcICERestartAttempts = 5
+	// webrtcICERestartInterval is how long a client waits after restarting ICE to see if that
+	// worked before trying again.
+	webrtcICERestartInterval = 5 * time.Second
+)
+
+var errICERestartFailed = errors.New("could not restart ICE")
+
+// A webrtcICERestarter holds what a client needs to restart ICE through the signaling server
+// a peer connection was established through.
+type webrtcICERestarter struct {
+	signalingServer string
+	host            string
+	dOpts           dialOptions
+	callUUID        string
+	logger          golog.Logger
+}
+
+// restartICEWhenDisconnected restarts ICE whenever the ICE connection drops so that the
+// channel and its streams survive network changes. If ICE cannot be restarted, the channel
+// is closed.
+func (ch *webrtcClientChannel) restartICEWhenDisconnected(restarter *webrtcICERestarter) {
+	ch.webrtcBaseChannel.mu.Lock()
+	if ch.webrtcBaseChannel.closed {
+		ch.webrtcBaseChannel.mu.Unlock()
+		return
+	}
+	ch.activeBackgroundWorkers.Add(1)
+	ch.webrtcBaseChannel.mu.Unlock()
+
+	utils.PanicCapturingGo(func() {
+		defer ch.activeBackgroundWorkers.Done()
+		var attempts int
+		for {
+			ch.webrtcBaseChannel.mu.Lock()
+			closed := ch.webrtcBaseChannel.closed
+			iceState := ch.webrtcBaseChannel.iceState
+			stateChanged := ch.webrtcBaseChannel.stateChanged
+			ch.webrtcBaseChannel.mu.Unlock()
+			if closed {
+				return
+			}
+
+			switch iceState {
+			case webrtc.ICEConnectionStateConnected, webrtc.ICEConnectionStateCompleted:
+				attempts = 0
+			case webrtc.ICEConnectionStateDisconnected, webrtc.ICEConnectionStateFailed:
+				if attempts == webrtcICERestartAttempts {
+					if err := ch.closeWithReason(errICERestartFailed); err != nil {
+						restarter.logger.Errorw("error closing channel", "error", err)
+					}
+					return
+				}
+				attempts++
+				restarter.logger.Debugw("restarting ICE", "ice_state", iceState.String(), "attempt", attempts)
+				if err := ch.restartICE(restarter); err != nil {
+					restarter.logger.Debugw("error restarting ICE", "error", err)
+				}
+				if !utils.SelectContextOrWait(ch.webrtcBaseChannel.ctx, webrtcICERestartInterval) {
+					return
+				}
+				continue
+			case webrtc.ICEConnectionStateNew, webrtc.ICEConnectionStateChecking, webrtc.ICEConnectionStateClosed:
+			}
+
+			select {
+			case <-ch.webrtcBaseChannel.ctx.Done():
+				return
+			case <-stateChanged:
+			}
+		}
+	})
+}
+
+// restartICE makes an ICE restart offer through the signaling server and applies the answer.
+func (ch *webrtcClientChannel) restartICE(restarter *webrtcICERestarter) (err error) {
+	ctx, cancel := context.WithTimeout(ch.webrtcBaseChannel.ctx, restarter.dOpts.webrtcOpts.getOfferDeadline())
+	defer cancel()
+
+	conn, _, err := dialDirectGRPC(ctx, restarter.signalingServer, &restarter.dOpts, restarter.logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, conn.Close())
+	}()
+
+	// if this fails part way, the next attempt's offer simply replaces this one
+	pc := ch.peerConn
+	offer, err := pc.CreateOffer(&webrtc.OfferOptions{ICERestart: true})
+	if err != nil {
+		return err
+	}
+	gatherComplete := webrtc.GatheringCompletePromise(pc)
+	if err := pc.SetLocalDescription(offer); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-gatherComplete:
+	}
+	encodedSDP, err := encodeSDP(pc.LocalDescription())
+	if err != nil {
+		return err
+	}
+
+	signalCtx := metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{RPCHostMetadataField: restarter.host}))
+	signalingClient := webrtcpb.NewSignalingServiceClient(conn)
+	callClient, err := signalingClient.Call(signalCtx, &webrtcpb.CallRequest{
+		Sdp:            encodedSDP,
+		DisableTrickle: true,
+		IceRestartUuid: restarter.callUUID,
+	})
+	if err != nil {
+		return err
+	}
+	callResp, err := callClient.Recv()
+	if err != nil {
+		return err
+	}
+	initStage, ok := callResp.Stage.(*webrtcpb.CallResponse_Init)
+	if !ok {
+		return errors.Errorf("expected first stage to be init; got %T", callResp.Stage)
+	}
+
+	applyAnswer := func() error {
+		answer := webrtc.SessionDescription{}
+		if err := decodeSDP(initStage.Init.Sdp, &answer); err != nil {
+			return err
+		}
+		// an answerer that does not know about ICE restarts answers with a new peer connection
+		if remote := pc.RemoteDescription(); remote == nil || sdpFingerprint(answer.SDP) != sdpFingerprint(remote.SDP) {
+			return errors.New("answerer did not restart ICE on the existing peer connection")
+		}
+		return pc.SetRemoteDescription(answer)
+	}
+	if err := applyAnswer(); err != nil {
+		_, updateErr := signalingClient.CallUpdate(signalCtx, &webrtcpb.CallUpdateRequest{
+			Uuid: callResp.Uuid,
+			Update: &webrtcpb.CallUpdateRequest_Error{
+				Error: ErrorToStatus(err).Proto(),
+			},
+		})
+		return multierr.Combine(err, updateErr)
+	}
+
+	_, err = signalingClient.CallUpdate(signalCtx, &webrtcpb.CallUpdateRequest{
+		Uuid: callResp.Uuid,
+		Update: &webrtcpb.CallUpdateRequest_Done{
+			Done: true,
+		},
+	})
+	return err
+}
+
+// sdpFingerprint returns the DTLS fingerprint in the given SDP. It stays the same across ICE
+// restarts of a peer connection.
+func sdpFingerprint(sdp string) string {
+	const prefix = "a=fingerprint:"
+	for _, line := range strings.Split(sdp, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix)
+		}
+	}
+	return ""
+}
+
+// trackPeerConn remembers the peer connection established by the call with the given UUID
+// for as long as it is in use so that the caller can restart ICE on it.
+func (ans *webrtcSignalingAnswerer) trackPeerConn(uuid string, pc *webrtc.PeerConnection, ch *webrtcServerChannel) {
+	ans.peerConnsMu.Lock()
+	ans.peerConns[uuid] = pc
+	ans.peerConnsMu.Unlock()
+
+	ans.activeBackgroundWorkers.Add(1)
+	utils.PanicCapturingGo(func() {
+		defer ans.activeBackgroundWorkers.Done()
+		select {
+		case <-ans.closeCtx.Done():
+		case <-ch.webrtcBaseChannel.ctx.Done():
+		}
+		ans.peerConnsMu.Lock()
+		delete(ans.peerConns, uuid)
+		ans.peerConnsMu.Unlock()
+	})
+}
+
+// answerICERestart applies an ICE restart offer to the peer connection established by an
+// earlier call and responds with an answer containing all ICE candidates.
+func (ans *webrtcSignalingAnswerer) answerICERestart(
+	client webrtcpb.SignalingService_AnswerClient,
+	uuid string,
+	init *webrtcpb.AnswerRequestInitStage,
+) error {
+	sendError := func(err error) error {
+		return client.Send(&webrtcpb.AnswerResponse{
+			Uuid: uuid,
+			Stage: &webrtcpb.AnswerResponse_Error{
+				Error: &webrtcpb.AnswerResponseErrorStage{
+					Status: ErrorToStatus(err).Proto(),
+				},
+			},
+		})
+	}
+
+	ans.peerConnsMu.Lock()
+	pc, ok := ans.peerConns[init.IceRestartUuid]
+	ans.peerConnsMu.Unlock()
+	if !ok {
+		return sendError(status.Errorf(codes.NotFound, "no peer connection to restart ICE on for call %q", init.IceRestartUuid))
+	}
+
+	var exchangeCtx context.Context
+	var exchangeCancel func()
+	if init.Deadline != nil {
+		exchangeCtx, exchangeCancel = context.WithDeadline(ans.closeCtx, init.Deadline.AsTime())
+	} else {
+		exchangeCtx, exchangeCancel = context.WithTimeout(ans.closeCtx, ans.getOfferDeadline())
+	}
+	defer exchangeCancel()
+
+	encodedSDP, err := func() (string, error) {
+		offer := webrtc.SessionDescription{}
+		if err := decodeSDP(init.Sdp, &offer); err != nil {
+			return "", err
+		}
+		if err := pc.SetRemoteDescription(offer); err != nil {
+			return "", err
+		}
+		answer, err := pc.CreateAnswer(nil)
+		if err != nil {
+			return "", err
+		}
+		gatherComplete := webrtc.GatheringCompletePromise(pc)
+		if err := pc.SetLocalDescription(answer); err != nil {
+			return "", err
+		}
+		select {
+		case <-exchangeCtx.Done():
+			return "", exchangeCtx.Err()
+		case <-gatherComplete:
+		}
+		return encodeSDP(pc.LocalDescription())
+	}()
+	if err != nil {
+		return sendError(err)
+	}
+
+	if err := client.Send(&webrtcpb.AnswerResponse{
+		Uuid: uuid,
+		Stage: &webrtcpb.AnswerResponse_Init{
+			Init: &webrtcpb.AnswerResponseInitStage{
+				Sdp: encodedSDP,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	if err := client.Send(&webrtcpb.AnswerResponse{
+		Uuid: uuid,
+		Stage: &webrtcpb.AnswerResponse_Done{
+			Done: &webrtcpb.AnswerResponseDoneStage{},
+		},
+	}); err != nil {
+		return err
+	}
+
+	// ICE restarts do not trickle, so all that is left is to hear how it went
+	for {
+		req, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		switch s := req.Stage.(type) {
+		case *webrtcpb.AnswerRequest_Done:
+			return nil
+		case *webrtcpb.AnswerRequest_Error:
+			return fmt.Errorf("error from requester: %w", status.FromProto(s.Error.Status).Err())
+		case *webrtcpb.AnswerRequest_Update:
+			if err := pc.AddICECandidate(iceCandidateFromProto(s.Update.Candidate)); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("unexpected stage %T", s)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	echoserver "go.viam.com/utils/rpc/examples/echo/server"
+	"go.viam.com/utils/testutils"
+)
+
+func TestWebRTCICERestart(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&echoserver.Server{},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{
+			SignalingInsecure: true,
+			EnableICERestart:  true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	client := pb.NewEchoServiceClient(conn)
+	clientCh, ok := conn.(*webrtcClientChannel)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	var callUUID string
+	for _, ans := range rpcServer.(*simpleServer).webrtcAnswerers {
+		ans.peerConnsMu.Lock()
+		for uuid := range ans.peerConns {
+			callUUID = uuid
+		}
+		ans.peerConnsMu.Unlock()
+	}
+	test.That(t, callUUID, test.ShouldNotBeEmpty)
+
+	restarter := &webrtcICERestarter{
+		signalingServer: httpListener.Addr().String(),
+		host:            "yeehaw",
+		dOpts:           dialOptions{insecure: true},
+		callUUID:        callUUID,
+		logger:          logger,
+	}
+	fingerprint := sdpFingerprint(clientCh.peerConn.RemoteDescription().SDP)
+	test.That(t, clientCh.restartICE(restarter), test.ShouldBeNil)
+	test.That(t, sdpFingerprint(clientCh.peerConn.RemoteDescription().SDP), test.ShouldEqual, fingerprint)
+
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+	// a peer connection the answerer does not know about cannot be restarted
+	restarter.callUUID = "nope"
+	err = clientCh.restartICE(restarter)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no peer connection")
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
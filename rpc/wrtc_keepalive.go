@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+
+	"go.viam.com/utils"
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+)
+
+// Defaults for WebRTCKeepaliveParameters.
+var (
+	DefaultWebRTCKeepaliveTime    = 10 * time.Second
+	DefaultWebRTCKeepaliveTimeout = 10 * time.Second
+)
+
+// WebRTCKeepaliveParameters configure how the end of a WebRTC connection notices that the
+// other end has gone away, much like gRPC's keepalive.ClientParameters and
+// keepalive.ServerParameters. When nothing has been heard from the other end for a while it
+// is pinged over the data channel, and if it does not answer in time the connection is closed
+// and all of its calls fail. Otherwise, a dead peer is only noticed once ICE gives up on it.
+//
+// Peers that do not know about pings are never pinged.
+type WebRTCKeepaliveParameters struct {
+	// Time is how long the connection may go without hearing from the other end before
+	// it is pinged. Zero uses DefaultWebRTCKeepaliveTime and a negative value disables
+	// keepalive.
+	Time time.Duration
+
+	// Timeout is how long to wait for anything from the other end after pinging it before
+	// the connection is closed. Zero uses DefaultWebRTCKeepaliveTimeout.
+	Timeout time.Duration
+
+	// PermitWithoutStream makes clients ping even when no calls are in progress. Servers
+	// always ping.
+	PermitWithoutStream bool
+}
+
+func (params WebRTCKeepaliveParameters) withDefaults() WebRTCKeepaliveParameters {
+	if params.Time == 0 {
+		params.Time = DefaultWebRTCKeepaliveTime
+	}
+	if params.Timeout == 0 {
+		params.Timeout = DefaultWebRTCKeepaliveTimeout
+	}
+	return params
+}
+
+var errWebRTCKeepaliveTimeout = errors.New("keepalive ping not acknowledged in time")
+
+// onReceived notes that something was heard from the other end.
+func (ch *webrtcBaseChannel) onReceived() {
+	ch.lastReceived.Store(time.Now().UnixNano())
+}
+
+// onPing handles a ping from the other end, acknowledging it if it asks for that.
+func (ch *webrtcBaseChannel) onPing(ping *webrtcpb.Ping, writePing func(ping *webrtcpb.Ping) error) {
+	ch.peerAnswersPings.Store(true)
+	if ping.IceRestart {
+		ch.peerRestartsICE.Store(true)
+	}
+	if ping.Ack {
+		return
+	}
+	if err := writePing(&webrtcpb.Ping{Data: ping.Data, Ack: true}); err != nil {
+		ch.logger.Debugw("error acknowledging ping", "error", err)
+	}
+}
+
+// startKeepalive pings the other end, with writePing, once the channel is ready according to
+// the given parameters. If hello is set, it is sent to the other end right away to let it know
+// that pings are answered, along with anything else the ping says about this end. hasStreams
+// reports whether any calls are in progress; if it is nil, pings are sent regardless.
+func (ch *webrtcBaseChannel) startKeepalive(
+	params WebRTCKeepaliveParameters,
+	writePing func(ping *webrtcpb.Ping) error,
+	hasStreams func() bool,
+	hello *webrtcpb.Ping,
+) {
+	params = params.withDefaults()
+	ch.mu.Lock()
+	if ch.closed {
+		ch.mu.Unlock()
+		return
+	}
+	ch.activeBackgroundWorkers.Add(1)
+	ch.mu.Unlock()
+
+	utils.PanicCapturingGo(func() {
+		defer ch.activeBackgroundWorkers.Done()
+		select {
+		case <-ch.ctx.Done():
+			return
+		case <-ch.ready:
+		}
+		ch.onReceived()
+		if hello != nil {
+			if err := writePing(hello); err != nil {
+				ch.logger.Debugw("error sending initial ping", "error", err)
+			}
+		}
+		if params.Time < 0 {
+			return
+		}
+		ch.keepalive(params, writePing, hasStreams)
+	})
+}
+
+func (ch *webrtcBaseChannel) keepalive(
+	params WebRTCKeepaliveParameters,
+	writePing func(ping *webrtcpb.Ping) error,
+	hasStreams func() bool,
+) {
+	timer := time.NewTimer(params.Time)
+	defer timer.Stop()
+
+	var pingData uint64
+	var pingSent time.Time
+	for {
+		select {
+		case <-ch.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		lastReceived := time.Unix(0, ch.lastReceived.Load())
+		if !pingSent.IsZero() {
+			if !lastReceived.After(pingSent) {
+				if wait := params.Timeout - now.Sub(pingSent); wait > 0 {
+					timer.Reset(wait)
+					continue
+				}
+				ch.logger.Debugw("closing channel", "error", errWebRTCKeepaliveTimeout)
+				if err := ch.closeWithReason(errWebRTCKeepaliveTimeout); err != nil {
+					ch.logger.Errorw("error closing channel", "error", err)
+				}
+				return
+			}
+			pingSent = time.Time{}
+		}
+
+		if idle := now.Sub(lastReceived); idle < params.Time {
+			timer.Reset(params.Time - idle)
+			continue
+		}
+		if !ch.peerAnswersPings.Load() || (hasStreams != nil && !hasStreams()) {
+			timer.Reset(params.Time)
+			continue
+		}
+		pingData++
+		if err := writePing(&webrtcpb.Ping{Data: pingData}); err != nil {
+			ch.logger.Debugw("error sending ping", "error", err)
+			timer.Reset(params.Time)
+			continue
+		}
+		pingSent = now
+		timer.Reset(params.Timeout)
+	}
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pion/webrtc/v3"
+	"go.viam.com/test"
+
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+	"go.viam.com/utils/testutils"
+)
+
+func TestWebRTCKeepalive(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	pc1, pc2, dc1, dc2 := setupWebRTCPeers(t)
+
+	clientCh := newWebRTCClientChannel(pc1, dc1, logger, nil, nil)
+	defer func() {
+		test.That(t, clientCh.Close(), test.ShouldBeNil)
+	}()
+	clientCh.startKeepalive(
+		WebRTCKeepaliveParameters{Time: 50 * time.Millisecond, Timeout: 50 * time.Millisecond},
+		clientCh.writePing,
+		clientCh.hasStreams,
+		&webrtcpb.Ping{IceRestart: true},
+	)
+
+	server := newWebRTCServer(logger)
+	server.keepalive = WebRTCKeepaliveParameters{Time: -1}
+	serverCh := server.NewChannel(pc2, dc2, []string{"one"})
+	defer func() {
+		test.That(t, serverCh.Close(), test.ShouldBeNil)
+	}()
+
+	<-clientCh.Ready()
+	<-serverCh.Ready()
+
+	// both ends say hello, and only the client restarts ICE
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, clientCh.peerAnswersPings.Load(), test.ShouldBeTrue)
+		test.That(tb, serverCh.peerAnswersPings.Load(), test.ShouldBeTrue)
+		test.That(tb, serverCh.peerRestartsICE.Load(), test.ShouldBeTrue)
+	})
+	test.That(t, clientCh.peerRestartsICE.Load(), test.ShouldBeFalse)
+
+	// nothing is pinged without calls in progress
+	time.Sleep(200 * time.Millisecond)
+	isClosed, _ := clientCh.Closed()
+	test.That(t, isClosed, test.ShouldBeFalse)
+
+	// the server goes away in the middle of a call
+	dc2.OnMessage(func(msg webrtc.DataChannelMessage) {})
+	start := time.Now()
+	err := clientCh.Invoke(
+		context.Background(),
+		"/proto.rpc.webrtc.v1.SignalingService/Call",
+		&webrtcpb.CallRequest{},
+		&webrtcpb.CallResponse{},
+	)
+	test.That(t, errors.Is(err, errWebRTCKeepaliveTimeout), test.ShouldBeTrue)
+	test.That(t, time.Since(start), test.ShouldBeLessThan, time.Second)
+
+	isClosed, reason := clientCh.Closed()
+	test.That(t, isClosed, test.ShouldBeTrue)
+	test.That(t, reason, test.ShouldEqual, errWebRTCKeepaliveTimeout)
+}
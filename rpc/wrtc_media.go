@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/pion/webrtc/v3"
+
+	"go.viam.com/utils"
+)
+
+// ErrNotOverWebRTC is returned when media tracks are added to or removed from a call that
+// was not made over WebRTC.
+var ErrNotOverWebRTC = errors.New("call was not made over WebRTC")
+
+// AddTrack adds a media track to the WebRTC peer connection that the call of the given
+// context came in over, so that camera streams and the like share one peer with gRPC. It
+// is meant to be called from a handler. The peer connection is renegotiated with the client
+// over its negotiation data channel, after which the client's OnTrack handler gets the
+// track. Media is sent by writing to the track; RTCP from the client is read and handed to
+// interceptors until the track is removed.
+func AddTrack(ctx context.Context, track webrtc.TrackLocal) (*webrtc.RTPSender, error) {
+	pc, ok := ContextPeerConnection(ctx)
+	if !ok {
+		return nil, ErrNotOverWebRTC
+	}
+	sender, err := pc.AddTrack(track)
+	if err != nil {
+		return nil, err
+	}
+	utils.PanicCapturingGo(func() {
+		buf := make([]byte, 1500)
+		for {
+			if _, _, err := sender.Read(buf); err != nil {
+				return
+			}
+		}
+	})
+	return sender, nil
+}
+
+// RemoveTrack stops sending a track added by AddTrack to the peer connection that the call
+// of the given context came in over, renegotiating it with the client.
+func RemoveTrack(ctx context.Context, sender *webrtc.RTPSender) error {
+	pc, ok := ContextPeerConnection(ctx)
+	if !ok {
+		return ErrNotOverWebRTC
+	}
+	return pc.RemoveTrack(sender)
+}
+
+// A TrackHandler is called with each media track that a server adds to a connection. It is
+// called in its own goroutine and may read from the track for as long as it likes.
+type TrackHandler func(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver)
+
+// A ClientConnTrackReceiver is a connection that can receive media tracks from its server,
+// as is the case for connections made over WebRTC.
+type ClientConnTrackReceiver interface {
+	ClientConn
+
+	// OnTrack sets the handler for media tracks the server adds to the connection. Tracks
+	// added before a handler is set are handed to the first one. Connections that are not
+	// over WebRTC never get any tracks.
+	OnTrack(f TrackHandler)
+}
+
+// A webrtcTrackReceiver hands media tracks added to a peer connection to the current
+// TrackHandler, holding on to the ones that come before there is one.
+type webrtcTrackReceiver struct {
+	mu      sync.Mutex
+	handler TrackHandler
+	pending []webrtcRemoteTrack
+}
+
+type webrtcRemoteTrack struct {
+	track    *webrtc.TrackRemote
+	receiver *webrtc.RTPReceiver
+}
+
+// onTrack is the peer connection's OnTrack handler.
+func (r *webrtcTrackReceiver) onTrack(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+	r.mu.Lock()
+	handler := r.handler
+	if handler == nil {
+		r.pending = append(r.pending, webrtcRemoteTrack{track, receiver})
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	// already in a goroutine of its own
+	handler(track, receiver)
+}
+
+func (r *webrtcTrackReceiver) setHandler(f TrackHandler) {
+	r.mu.Lock()
+	r.handler = f
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+	if f == nil {
+		return
+	}
+	for _, remote := range pending {
+		remote := remote
+		utils.PanicCapturingGo(func() {
+			f(remote.track, remote.receiver)
+		})
+	}
+}
+
+// OnTrack sets the handler for media tracks the server adds to the peer connection.
+func (ch *webrtcClientChannel) OnTrack(f TrackHandler) {
+	ch.tracks.setHandler(f)
+}
+
+// OnTrack sets the handler for media tracks the server adds to any of the peer connections.
+func (pool *webrtcClientPool) OnTrack(f TrackHandler) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for _, member := range pool.members {
+		member.ch.OnTrack(f)
+	}
+}
+
+// OnTrack sets the handler for media tracks the server adds to the current connection and
+// to every one made after it.
+func (rc *reconnectingClientConn) OnTrack(f TrackHandler) {
+	rc.mu.Lock()
+	rc.trackHandler = f
+	conn := rc.conn
+	rc.mu.Unlock()
+	if conn != nil {
+		clientConnOnTrack(conn, f)
+	}
+}
+
+// clientConnOnTrack sets the handler for media tracks added to the given connection, if it
+// can receive any.
+func clientConnOnTrack(conn ClientConn, f TrackHandler) {
+	if c, ok := conn.(ClientConnTrackReceiver); ok {
+		c.OnTrack(f)
+	}
+}
+
+// The wrappers below pass through to whatever they wrap.
+
+func (rc *reffedConn) OnTrack(f TrackHandler) {
+	clientConnOnTrack(rc.ClientConn, f)
+}
+
+func (cc *clientConnWithCloseFunc) OnTrack(f TrackHandler) {
+	clientConnOnTrack(cc.ClientConn, f)
+}
+
+func (cc clientConnRPCAuthenticator) OnTrack(f TrackHandler) {
+	clientConnOnTrack(cc.ClientConn, f)
+}
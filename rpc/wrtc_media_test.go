@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media"
+	"go.viam.com/test"
+	"google.golang.org/grpc/status"
+
+	pb "go.viam.com/utils/proto/rpc/examples/echo/v1"
+	"go.viam.com/utils/testutils"
+)
+
+// trackEchoServer adds its track to the peer connection of every Echo call.
+type trackEchoServer struct {
+	pb.UnimplementedEchoServiceServer
+	track webrtc.TrackLocal
+}
+
+func (srv *trackEchoServer) Echo(ctx context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if _, err := AddTrack(ctx, srv.track); err != nil {
+		return nil, err
+	}
+	return &pb.EchoResponse{Message: req.Message}, nil
+}
+
+func TestWebRTCMediaTracks(t *testing.T) {
+	testutils.SkipUnlessInternet(t)
+	logger := golog.NewTestLogger(t)
+	rpcServer, err := NewServer(
+		logger,
+		WithUnauthenticated(),
+		WithInstanceNames("yeehaw"),
+		WithDisableMulticastDNS(),
+		WithWebRTCServerOptions(WebRTCServerOptions{
+			Enable:                  true,
+			EnableInternalSignaling: true,
+		}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	track, err := webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, "video", "camera")
+	test.That(t, err, test.ShouldBeNil)
+	err = rpcServer.RegisterServiceServer(
+		context.Background(),
+		&pb.EchoService_ServiceDesc,
+		&trackEchoServer{track: track},
+		pb.RegisterEchoServiceHandlerFromEndpoint,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	httpListener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	errChan := make(chan error)
+	go func() {
+		errChan <- rpcServer.Serve(httpListener)
+	}()
+
+	conn, err := DialWebRTC(context.Background(), httpListener.Addr().String(), "yeehaw", logger,
+		WithWebRTCOptions(DialWebRTCOptions{SignalingInsecure: true}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	trackReceiver, ok := conn.(ClientConnTrackReceiver)
+	test.That(t, ok, test.ShouldBeTrue)
+	remoteTracks := make(chan *webrtc.TrackRemote, 1)
+	trackReceiver.OnTrack(func(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		remoteTracks <- track
+	})
+
+	resp, err := pb.NewEchoServiceClient(conn).Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Message, test.ShouldEqual, "hello")
+
+	writeCtx, stopWriting := context.WithCancel(context.Background())
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		for writeCtx.Err() == nil {
+			if err := track.WriteSample(media.Sample{Data: []byte{0x1, 0x2, 0x3}, Duration: time.Second / 30}); err != nil {
+				return
+			}
+			time.Sleep(time.Second / 30)
+		}
+	}()
+
+	select {
+	case remoteTrack := <-remoteTracks:
+		test.That(t, remoteTrack.ID(), test.ShouldEqual, "video")
+		test.That(t, remoteTrack.Kind(), test.ShouldEqual, webrtc.RTPCodecTypeVideo)
+		_, _, err := remoteTrack.ReadRTP()
+		test.That(t, err, test.ShouldBeNil)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected track to be added")
+	}
+	stopWriting()
+	<-writerDone
+	test.That(t, conn.Close(), test.ShouldBeNil)
+
+	// direct gRPC calls have no peer connection to add tracks to
+	grpcConn, err := DialDirectGRPC(context.Background(), httpListener.Addr().String(), logger, WithInsecure())
+	test.That(t, err, test.ShouldBeNil)
+	_, err = pb.NewEchoServiceClient(grpcConn).Echo(context.Background(), &pb.EchoRequest{Message: "hello"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, status.Convert(err).Message(), test.ShouldContainSubstring, ErrNotOverWebRTC.Error())
+	test.That(t, grpcConn.Close(), test.ShouldBeNil)
+
+	test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	err = <-errChan
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestWebRTCTrackReceiver(t *testing.T) {
+	var receiver webrtcTrackReceiver
+	// tracks that come before a handler wait for one
+	receiver.onTrack(nil, nil)
+	receiver.onTrack(nil, nil)
+
+	handled := make(chan struct{}, 3)
+	receiver.setHandler(func(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		handled <- struct{}{}
+	})
+	<-handled
+	<-handled
+
+	receiver.onTrack(nil, nil)
+	<-handled
+	test.That(t, receiver.pending, test.ShouldBeEmpty)
+}
@@ -97,6 +97,10 @@ func newPeerConnectionForClient(
 	}
 	dataChannel.OnError(initialDataChannelOnError(pc, logger))
 
+	if err := addNegotiationChannel(pc, true, logger); err != nil {
+		return pc, nil, err
+	}
+
 	if disableTrickle {
 		offer, err := pc.CreateOffer(nil)
 		if err != nil {
@@ -150,41 +154,6 @@ func newPeerConnectionForServer(
 		}
 	}()
 
-	var negOpen bool
-	var negMu sync.Mutex
-	var negotiationChannel *webrtc.DataChannel
-	var makingOffer bool
-	pc.OnNegotiationNeeded(func() {
-		negMu.Lock()
-		if !negOpen {
-			negMu.Unlock()
-			return
-		}
-		negMu.Unlock()
-		makingOffer = true
-		defer func() {
-			makingOffer = false
-		}()
-		offer, err := pc.CreateOffer(nil)
-		if err != nil {
-			logger.Errorw("renegotiation: error creating offer", "error", err)
-			return
-		}
-		if err := pc.SetLocalDescription(offer); err != nil {
-			logger.Errorw("renegotiation: error setting local description", "error", err)
-			return
-		}
-		encodedSDP, err := encodeSDP(pc.LocalDescription())
-		if err != nil {
-			logger.Errorw("renegotiation: error encoding SDP", "error", err)
-			return
-		}
-		if err := negotiationChannel.SendText(encodedSDP); err != nil {
-			logger.Errorw("renegotiation: error sending SDP", "error", err)
-			return
-		}
-	})
-
 	negotiated := true
 	ordered := true
 	dataChannelID := uint16(0)
@@ -198,25 +167,112 @@ func newPeerConnectionForServer(
 	}
 	dataChannel.OnError(initialDataChannelOnError(pc, logger))
 
-	negotiationChannelID := uint16(1)
-	negotiationChannel, err = pc.CreateDataChannel("negotiation", &webrtc.DataChannelInit{
-		ID:         &negotiationChannelID,
+	if err := addNegotiationChannel(pc, false, logger); err != nil {
+		return pc, dataChannel, err
+	}
+
+	offer := webrtc.SessionDescription{}
+	if err := decodeSDP(sdp, &offer); err != nil {
+		return pc, dataChannel, err
+	}
+
+	err = pc.SetRemoteDescription(offer)
+	if err != nil {
+		return pc, dataChannel, err
+	}
+
+	if disableTrickle {
+		answer, err := pc.CreateAnswer(nil)
+		if err != nil {
+			return pc, dataChannel, err
+		}
+
+		err = pc.SetLocalDescription(answer)
+		if err != nil {
+			return pc, dataChannel, err
+		}
+
+		// Create channel that is blocked until ICE Gathering is complete
+		gatherComplete := webrtc.GatheringCompletePromise(pc)
+
+		// Block until ICE Gathering is complete since we signal back one complete SDP
+		// and do not want to wait on trickle ICE.
+		select {
+		case <-ctx.Done():
+			return pc, nil, ctx.Err()
+		case <-gatherComplete:
+		}
+	}
+
+	successful = true
+	return pc, dataChannel, nil
+}
+
+// negotiationChannelID is the ID of the data channel that peers renegotiate their connection
+// over once connected, such as when media tracks are added or removed.
+const negotiationChannelID = uint16(1)
+
+// addNegotiationChannel creates the data channel that pc is renegotiated over once connected
+// and renegotiates whenever needed following the perfect negotiation pattern
+// (https://w3c.github.io/webrtc-pc/#perfect-negotiation-example). Clients are polite and give
+// way to offers from servers when both make one at the same time.
+func addNegotiationChannel(pc *webrtc.PeerConnection, polite bool, logger golog.Logger) error {
+	negotiated := true
+	ordered := true
+	channelID := negotiationChannelID
+	negotiationChannel, err := pc.CreateDataChannel("negotiation", &webrtc.DataChannelInit{
+		ID:         &channelID,
 		Negotiated: &negotiated,
 		Ordered:    &ordered,
 	})
 	if err != nil {
-		return pc, dataChannel, err
+		return err
 	}
 	negotiationChannel.OnError(initialDataChannelOnError(pc, logger))
 
+	// negMu serializes offers and answers so that one is never made while another is
+	// being handled.
+	var negMu sync.Mutex
+	var negOpen bool
+	sendLocalDescription := func() error {
+		encodedSDP, err := encodeSDP(pc.LocalDescription())
+		if err != nil {
+			return err
+		}
+		return negotiationChannel.SendText(encodedSDP)
+	}
+
 	negotiationChannel.OnOpen(func() {
 		negMu.Lock()
 		negOpen = true
 		negMu.Unlock()
 	})
 
-	const polite = false
-	var ignoreOffer bool
+	pc.OnNegotiationNeeded(func() {
+		// not on the peer connection's own goroutine, which may be needed to handle an answer
+		// that is in progress
+		utils.PanicCapturingGo(func() {
+			negMu.Lock()
+			defer negMu.Unlock()
+			// the initial negotiation happens over signaling
+			if !negOpen || pc.ConnectionState() == webrtc.PeerConnectionStateClosed {
+				return
+			}
+			offer, err := pc.CreateOffer(nil)
+			if err != nil {
+				logger.Errorw("renegotiation: error creating offer", "error", err)
+				return
+			}
+			if err := pc.SetLocalDescription(offer); err != nil {
+				logger.Errorw("renegotiation: error setting local description", "error", err)
+				return
+			}
+			if err := sendLocalDescription(); err != nil {
+				logger.Errorw("renegotiation: error sending SDP", "error", err)
+			}
+		})
+	})
+
 	negotiationChannel.OnMessage(func(msg webrtc.DataChannelMessage) {
 		negMu.Lock()
 		defer negMu.Unlock()
@@ -226,11 +282,22 @@ func newPeerConnectionForServer(
 			logger.Errorw("renegotiation: error decoding SDP", "error", err)
 			return
 		}
-		offerCollision := (description.Type == webrtc.SDPTypeOffer) &&
-			(makingOffer || pc.SignalingState() != webrtc.SignalingStateStable)
-		ignoreOffer = !polite && offerCollision
-		if ignoreOffer {
-			logger.Debugw("ignoring offer", "polite", polite, "offer_collision", offerCollision)
+		offerCollision := description.Type == webrtc.SDPTypeOffer && pc.SignalingState() != webrtc.SignalingStateStable
+		if offerCollision {
+			if !polite {
+				logger.Debugw("renegotiation: ignoring colliding offer", "polite", polite)
+				return
+			}
+			// our offer gives way and is made again once this one is done
+			if pending := pc.PendingLocalDescription(); pending != nil {
+				if err := pc.SetLocalDescription(webrtc.SessionDescription{
+					Type: webrtc.SDPTypeRollback,
+					SDP:  pending.SDP,
+				}); err != nil {
+					logger.Errorw("renegotiation: error rolling back local description", "error", err)
+					return
+				}
+			}
 		}
 
 		if err := pc.SetRemoteDescription(description); err != nil {
@@ -248,53 +315,12 @@ func newPeerConnectionForServer(
 				logger.Errorw("renegotiation: error setting local description", "error", err)
 				return
 			}
-			encodedSDP, err := encodeSDP(pc.LocalDescription())
-			if err != nil {
-				logger.Errorw("renegotiation: error encoding SDP", "error", err)
-				return
-			}
-			if err := negotiationChannel.SendText(encodedSDP); err != nil {
+			if err := sendLocalDescription(); err != nil {
 				logger.Errorw("renegotiation: error sending SDP", "error", err)
-				return
 			}
 		}
 	})
-
-	offer := webrtc.SessionDescription{}
-	if err := decodeSDP(sdp, &offer); err != nil {
-		return pc, dataChannel, err
-	}
-
-	err = pc.SetRemoteDescription(offer)
-	if err != nil {
-		return pc, dataChannel, err
-	}
-
-	if disableTrickle {
-		answer, err := pc.CreateAnswer(nil)
-		if err != nil {
-			return pc, dataChannel, err
-		}
-
-		err = pc.SetLocalDescription(answer)
-		if err != nil {
-			return pc, dataChannel, err
-		}
-
-		// Create channel that is blocked until ICE Gathering is complete
-		gatherComplete := webrtc.GatheringCompletePromise(pc)
-
-		// Block until ICE Gathering is complete since we signal back one complete SDP
-		// and do not want to wait on trickle ICE.
-		select {
-		case <-ctx.Done():
-			return pc, nil, ctx.Err()
-		case <-gatherComplete:
-		}
-	}
-
-	successful = true
-	return pc, dataChannel, nil
+	return nil
 }
 
 type webrtcPeerConnectionStats struct {
@@ -10,6 +10,8 @@ import (
 	"github.com/edaniels/golog"
 	"github.com/pion/webrtc/v3"
 	"google.golang.org/grpc"
+
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
 )
 
 // DefaultWebRTCMaxGRPCCalls is the maximum number of concurrent gRPC calls to allow
@@ -35,6 +37,13 @@ type webrtcServer struct {
 
 	onPeerAdded   func(pc *webrtc.PeerConnection)
 	onPeerRemoved func(pc *webrtc.PeerConnection)
+
+	keepalive WebRTCKeepaliveParameters
+
+	// maxRecvMsgSize and maxSendMsgSize are the largest messages calls may receive and send,
+	// if not the defaults.
+	maxRecvMsgSize int
+	maxSendMsgSize int
 }
 
 // from grpc.
@@ -173,6 +182,7 @@ func (srv *webrtcServer) NewChannel(
 	authAudience []string,
 ) *webrtcServerChannel {
 	serverCh := newWebRTCServerChannel(srv, peerConn, dataChannel, authAudience, srv.logger)
+	serverCh.startKeepalive(srv.keepalive, serverCh.writePing, nil, &webrtcpb.Ping{})
 	srv.mu.Lock()
 	srv.peerConns[peerConn] = struct{}{}
 	srv.mu.Unlock()
@@ -25,6 +25,10 @@ type webrtcServerChannel struct {
 	authAudience string
 	server       *webrtcServer
 	streams      map[uint64]*webrtcServerStream
+
+	// unreliable are the unreliable data channels on the peer connection, shared by every
+	// channel on it.
+	unreliable *webrtcUnreliableDataChannels
 }
 
 // newWebRTCServerChannel wraps the given WebRTC data channel to be used as the server end
@@ -48,8 +52,10 @@ func newWebRTCServerChannel(
 		webrtcBaseChannel: base,
 		server:            server,
 		streams:           make(map[uint64]*webrtcServerStream),
+		unreliable:        newWebRTCUnreliableDataChannels(),
 	}
 	dataChannel.OnMessage(ch.onChannelMessage)
+	peerConn.OnDataChannel(ch.onDataChannel)
 	return ch
 }
 
@@ -80,6 +86,23 @@ func (ch *webrtcServerChannel) writeTrailers(stream *webrtcpb.Stream, trailers *
 	})
 }
 
+func (ch *webrtcServerChannel) writeWindowUpdate(stream *webrtcpb.Stream, update *webrtcpb.WindowUpdate) error {
+	return ch.webrtcBaseChannel.write(&webrtcpb.Response{
+		Stream: stream,
+		Type: &webrtcpb.Response_WindowUpdate{
+			WindowUpdate: update,
+		},
+	})
+}
+
+func (ch *webrtcServerChannel) writePing(ping *webrtcpb.Ping) error {
+	return ch.webrtcBaseChannel.write(&webrtcpb.Response{
+		Type: &webrtcpb.Response_Ping{
+			Ping: ping,
+		},
+	})
+}
+
 func (ch *webrtcServerChannel) removeStreamByID(id uint64) {
 	ch.mu.Lock()
 	delete(ch.streams, id)
@@ -93,6 +116,13 @@ func (ch *webrtcServerChannel) onChannelMessage(msg webrtc.DataChannelMessage) {
 		ch.webrtcBaseChannel.logger.Errorw("error unmarshaling message; discarding", "error", err)
 		return
 	}
+	ch.webrtcBaseChannel.onReceived()
+
+	if ping := req.GetPing(); ping != nil {
+		ch.webrtcBaseChannel.onPing(ping, ch.writePing)
+		return
+	}
+
 	stream := req.GetStream()
 	if stream == nil {
 		ch.webrtcBaseChannel.logger.Error("no stream, discard request")
@@ -118,7 +148,10 @@ func (ch *webrtcServerChannel) onChannelMessage(msg webrtc.DataChannelMessage) {
 			return
 		}
 
-		handlerCtx := metadata.NewIncomingContext(ch.ctx, metadataFromProto(headers.Headers.Metadata))
+		// like with direct gRPC, the encoding is for the transport and not the handler
+		handlerMD := metadataFromProto(headers.Headers.Metadata)
+		delete(handlerMD, headerGRPCEncoding)
+		handlerCtx := metadata.NewIncomingContext(ch.ctx, handlerMD)
 		timeout := headers.Headers.Timeout.AsDuration()
 		var cancelCtx func()
 		if timeout == 0 {
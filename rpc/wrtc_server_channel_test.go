@@ -326,7 +326,7 @@ func TestWebRTCServerChannelResetStream(t *testing.T) {
 				"rpc-host": []string{"yeehaw"},
 			}),
 		}), test.ShouldBeNil)
-		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}), test.ShouldBeNil)
+		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}, nil), test.ShouldBeNil)
 		<-messagesRead
 	})
 	t.Run("reset stream in middle of message", func(t *testing.T) {
@@ -372,7 +372,7 @@ func TestWebRTCServerChannelResetStream(t *testing.T) {
 			},
 			Eos: false,
 		}), test.ShouldBeNil)
-		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}), test.ShouldBeNil)
+		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}, nil), test.ShouldBeNil)
 		<-messagesRead
 	})
 	t.Run("reset stream after message", func(t *testing.T) {
@@ -443,7 +443,7 @@ func TestWebRTCServerChannelResetStream(t *testing.T) {
 		test.That(t, err, test.ShouldBeNil)
 		answererSDP := "world"
 		test.That(t, offer.AnswererRespond(context.Background(), WebRTCCallAnswer{InitialSDP: &answererSDP}), test.ShouldBeNil)
-		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}), test.ShouldBeNil)
+		test.That(t, clientCh.writeReset(&webrtcpb.Stream{Id: 1}, nil), test.ShouldBeNil)
 
 		<-messagesRead
 	})
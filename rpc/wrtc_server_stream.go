@@ -8,7 +8,6 @@ import (
 	"sync/atomic"
 
 	"github.com/edaniels/golog"
-	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck
 	"github.com/pion/sctp"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -39,6 +38,13 @@ type webrtcServerStream struct {
 	header          metadata.MD
 	trailer         metadata.MD
 	sendClosed      atomic.Bool
+	// encoding is what responses are compressed with, if anything.
+	encoding string
+	// responseDataChannel is the label of the unreliable data channel the client asked for
+	// responses over, if any.
+	responseDataChannel string
+	// cancellation is why the client cancelled the call, if it did.
+	cancellation *callCancellation
 }
 
 // newWebRTCServerStream creates a gRPC stream from the given server channel with a
@@ -53,11 +59,21 @@ func newWebRTCServerStream(
 	onDone func(id uint64),
 	logger golog.Logger,
 ) *webrtcServerStream {
+	ctx, cancellation := contextWithCallCancellation(ctx)
 	bs := newWebRTCBaseStream(ctx, cancelCtx, stream, onDone, logger)
+	bs.recvWindowSize = WebRTCStreamWindowSize
+	bs.writeWindowUpdate = channel.writeWindowUpdate
+	if channel.server.maxRecvMsgSize > 0 {
+		bs.maxRecvMsgSize = channel.server.maxRecvMsgSize
+	}
+	if channel.server.maxSendMsgSize > 0 {
+		bs.maxSendMsgSize = channel.server.maxSendMsgSize
+	}
 	s := &webrtcServerStream{
 		webrtcBaseStream: bs,
 		ch:               channel,
 		method:           method,
+		cancellation:     cancellation,
 	}
 	return s
 }
@@ -170,6 +186,9 @@ func (s *webrtcServerStream) SendMsg(m interface{}) (err error) {
 	if s.sendClosed.Load() {
 		return io.ErrClosedPipe
 	}
+	if err := s.webrtcBaseStream.waitForSendWindow(); err != nil {
+		return err
+	}
 
 	s.webrtcBaseStream.mu.RLock()
 	defer func() {
@@ -184,13 +203,16 @@ func (s *webrtcServerStream) SendMsg(m interface{}) (err error) {
 	if err := s.writeHeaders(); err != nil {
 		return err
 	}
-	if v1Msg, ok := m.(protov1.Message); ok {
-		m = protov1.MessageV2(v1Msg)
-	}
-	data, err := proto.Marshal(m.(proto.Message))
+	data, err := s.webrtcBaseStream.marshal(m)
 	if err != nil {
 		return err
 	}
+	if s.responseDataChannel != "" && len(data) != 0 && len(data) <= maxResponseMessagePacketDataSize {
+		if dataChannel := s.ch.unreliable.get(s.responseDataChannel); dataChannel != nil {
+			return s.ch.writeUnreliableMessage(dataChannel, s.stream, data)
+		}
+	}
+	s.webrtcBaseStream.sendWindow.sent(len(data))
 
 	if len(data) == 0 {
 		return s.ch.writeMessage(s.stream, &webrtcpb.ResponseMessage{
@@ -239,8 +261,14 @@ func (s *webrtcServerStream) onRequest(request *webrtcpb.Request) {
 			return
 		}
 		s.processMessage(r.Message)
+	case *webrtcpb.Request_WindowUpdate:
+		s.sendWindow.grant(r.WindowUpdate.GetIncrement())
 	case *webrtcpb.Request_RstStream:
-		if err := s.closeWithSendError(status.Error(codes.Canceled, "request cancelled")); err != nil {
+		var reason *status.Status
+		if request.RstStreamStatus != nil {
+			reason = status.FromProto(request.RstStreamStatus)
+		}
+		if err := s.closeWithSendError(s.cancellation.set(reason)); err != nil {
 			s.logger.Errorw("error closing", "error", err)
 		}
 		return
@@ -265,6 +293,21 @@ func isContextCanceled(err error) bool {
 func (s *webrtcServerStream) processHeaders(headers *webrtcpb.RequestHeaders) {
 	s.logger = s.logger.With("method", headers.Method)
 
+	// responses are compressed the same way as requests
+	if reqEncoding := encodingFromMetadata(metadataFromProto(headers.Metadata)); reqEncoding != "" {
+		comp, ok := compressorForEncoding(reqEncoding)
+		if !ok {
+			if err := s.closeWithSendError(status.Errorf(codes.Unimplemented,
+				"grpc: Decompressor is not installed for grpc-encoding %q", reqEncoding)); err != nil {
+				s.logger.Errorw("error closing", "error", err)
+			}
+			return
+		}
+		s.recvCompressor = comp
+		s.sendCompressor = comp
+		s.encoding = reqEncoding
+	}
+
 	handlerFunc, ok := s.ch.server.handler(headers.Method)
 	if !ok {
 		if s.ch.server.unknownStreamDesc != nil {
@@ -292,13 +335,39 @@ func (s *webrtcServerStream) processHeaders(headers *webrtcpb.RequestHeaders) {
 		return
 	}
 
+	if headers.WindowSize != 0 {
+		// the client does flow control; respect its window and let it know about ours
+		// before anything else is sent.
+		s.sendWindow.grant(headers.WindowSize)
+		if s.recvWindowSize != 0 {
+			s.enableRecvFlowControl()
+			if err := s.ch.writeWindowUpdate(s.stream, &webrtcpb.WindowUpdate{
+				Increment: s.recvWindowSize,
+			}); err != nil {
+				if err := s.closeWithSendError(err); err != nil {
+					s.logger.Errorw("error closing", "error", err)
+				}
+				<-s.ch.server.callTickets // return a ticket
+				return
+			}
+		}
+	}
+
+	if maxSize := headers.MaxResponseMessageSize; maxSize != 0 && uint64(maxSize) < uint64(s.maxSendMsgSize) {
+		// never send the client more than it will accept
+		s.maxSendMsgSize = int(maxSize)
+	}
+	s.responseDataChannel = headers.ResponseDataChannel
 	s.headersReceived = true
+	handlerDone := make(chan struct{})
+	s.watchHandler(handlerDone)
 	s.ch.server.activeBackgroundWorkers.Add(1)
 	utils.PanicCapturingGo(func() {
 		defer func() {
 			<-s.ch.server.callTickets // return a ticket
 		}()
 		defer s.ch.server.activeBackgroundWorkers.Done()
+		defer close(handlerDone)
 		if err := handlerFunc(s); err != nil {
 			if errors.Is(err, io.ErrClosedPipe) || isContextCanceled(err) {
 				return
@@ -318,27 +387,22 @@ func (s *webrtcServerStream) processMessage(msg *webrtcpb.RequestMessage) {
 			s.closeWithError(errors.New("expected RequestMessage.PacketMessgae to not be nil but it was"), false)
 			return
 		}
-		data, eop := s.webrtcBaseStream.processMessage(msg.PacketMessage)
-		if !eop {
+		data, eop, err := s.webrtcBaseStream.processMessage(msg.PacketMessage)
+		if err != nil {
+			if err := s.closeWithSendError(err); err != nil {
+				s.logger.Errorw("error closing", "error", err)
+			}
 			return
 		}
-		s.webrtcBaseStream.mu.Lock()
-		if s.recvClosed.Load() {
-			s.webrtcBaseStream.mu.Unlock()
+		if !eop {
 			return
 		}
-		msgCh := s.msgCh
-		s.webrtcBaseStream.activeSenders.Add(1)
-		s.webrtcBaseStream.mu.Unlock()
-
-		func() {
-			defer s.webrtcBaseStream.activeSenders.Done()
-			select {
-			case msgCh <- data:
-			case <-s.ctx.Done():
-				return
+		if err := s.webrtcBaseStream.enqueueMessage(data); err != nil {
+			if err := s.closeWithSendError(err); err != nil {
+				s.logger.Errorw("error closing", "error", err)
 			}
-		}()
+			return
+		}
 	}
 	if msg.Eos {
 		s.CloseRecv()
@@ -390,7 +454,11 @@ func (s *webrtcServerStream) writeHeaders() error {
 	if !s.headersWritten.CompareAndSwap(false, true) {
 		return nil
 	}
-	protoHeaders := metadataToProto(s.header)
+	header := s.header
+	if s.encoding != "" {
+		header = metadata.Join(header, metadata.Pairs(headerGRPCEncoding, s.encoding))
+	}
+	protoHeaders := metadataToProto(header)
 	return s.ch.writeHeaders(s.stream, &webrtcpb.ResponseHeaders{
 		Metadata: protoHeaders,
 	})
@@ -399,6 +467,10 @@ func (s *webrtcServerStream) writeHeaders() error {
 // ErrorToStatus converts an error to a gRPC status. A nil
 // error becomes a successful status.
 func ErrorToStatus(err error) *status.Status {
+	var ctxStatusErr contextStatusError
+	if errors.As(err, &ctxStatusErr) {
+		return ctxStatusErr.GRPCStatus()
+	}
 	respStatus := status.FromContextError(err)
 	if respStatus.Code() == codes.Unknown {
 		respStatus = status.Convert(err)
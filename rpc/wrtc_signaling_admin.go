@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+)
+
+// A WebRTCSignalingAdminServer lets the operators of a signaling service see what its call
+// queue is doing, such as which hosts have answerers connected and which offers are still
+// waiting to be picked up.
+// Note: this service should only be exposed to administrators; authentication and
+// authorization should happen by something wrapping this service server.
+type WebRTCSignalingAdminServer struct {
+	webrtcpb.UnimplementedSignalingAdminServiceServer
+	callQueue WebRTCCallQueue
+}
+
+// NewWebRTCSignalingAdminServer makes a new signaling admin server that reports on the given
+// call queue. The call queue must implement WebRTCCallQueueStats for stats to be returned.
+func NewWebRTCSignalingAdminServer(callQueue WebRTCCallQueue) *WebRTCSignalingAdminServer {
+	return &WebRTCSignalingAdminServer{callQueue: callQueue}
+}
+
+// CallQueueStats returns the hosts, operators and in-flight calls that the call queue knows of.
+func (srv *WebRTCSignalingAdminServer) CallQueueStats(
+	ctx context.Context,
+	req *webrtcpb.CallQueueStatsRequest,
+) (*webrtcpb.CallQueueStatsResponse, error) {
+	statsQueue, ok := srv.callQueue.(WebRTCCallQueueStats)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "call queue does not report stats")
+	}
+	snapshot, err := statsQueue.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var forHosts map[string]struct{}
+	if len(req.Hosts) != 0 {
+		forHosts = make(map[string]struct{}, len(req.Hosts))
+		for _, host := range req.Hosts {
+			forHosts[host] = struct{}{}
+		}
+	}
+	wantHost := func(host string) bool {
+		if forHosts == nil {
+			return true
+		}
+		_, ok := forHosts[host]
+		return ok
+	}
+
+	var resp webrtcpb.CallQueueStatsResponse
+	for _, host := range snapshot.Hosts {
+		if !wantHost(host.Host) {
+			continue
+		}
+		resp.Hosts = append(resp.Hosts, &webrtcpb.CallQueueHostStats{
+			Host:               host.Host,
+			PendingOffers:      host.PendingOffers,
+			ConnectedAnswerers: host.ConnectedAnswerers,
+		})
+	}
+	for _, operator := range snapshot.Operators {
+		operatorStats := &webrtcpb.CallQueueOperatorStats{
+			Id:        operator.ID,
+			ExpiresAt: timestamppb.New(operator.ExpiresAt),
+		}
+		for _, host := range operator.Hosts {
+			if !wantHost(host.Host) {
+				continue
+			}
+			operatorStats.Hosts = append(operatorStats.Hosts, &webrtcpb.CallQueueOperatorHostStats{
+				Host:      host.Host,
+				Callers:   host.Callers,
+				Answerers: host.Answerers,
+			})
+		}
+		resp.Operators = append(resp.Operators, operatorStats)
+	}
+	for _, call := range snapshot.Calls {
+		if !wantHost(call.Host) {
+			continue
+		}
+		resp.Calls = append(resp.Calls, &webrtcpb.CallQueueCallStats{
+			Uuid:               call.UUID,
+			Host:               call.Host,
+			StartedAt:          timestamppb.New(call.StartedAt),
+			Deadline:           timestamppb.New(call.Deadline),
+			Answered:           call.Answered,
+			CallerOperatorId:   call.CallerOperatorID,
+			AnswererOperatorId: call.AnswererOperatorID,
+		})
+	}
+	return &resp, nil
+}
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
+)
+
+func TestWebRTCSignalingAdminServer(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	callQueue := NewMemoryWebRTCCallQueue(logger)
+	defer func() {
+		test.That(t, callQueue.Close(), test.ShouldBeNil)
+	}()
+	adminServer := NewWebRTCSignalingAdminServer(callQueue)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	uuid, _, _, _, err := callQueue.SendOfferInit(ctx, "yeehaw", "somesdp", false, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	t.Run("all hosts", func(t *testing.T) {
+		resp, err := adminServer.CallQueueStats(context.Background(), &webrtcpb.CallQueueStatsRequest{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Hosts, test.ShouldHaveLength, 1)
+		test.That(t, resp.Hosts[0].Host, test.ShouldEqual, "yeehaw")
+		test.That(t, resp.Hosts[0].PendingOffers, test.ShouldEqual, uint64(1))
+		test.That(t, resp.Operators, test.ShouldBeEmpty)
+		test.That(t, resp.Calls, test.ShouldHaveLength, 1)
+		test.That(t, resp.Calls[0].Uuid, test.ShouldEqual, uuid)
+		test.That(t, resp.Calls[0].Answered, test.ShouldBeFalse)
+		test.That(t, resp.Calls[0].Deadline.AsTime().After(resp.Calls[0].StartedAt.AsTime()), test.ShouldBeTrue)
+	})
+
+	t.Run("filtered by host", func(t *testing.T) {
+		resp, err := adminServer.CallQueueStats(context.Background(), &webrtcpb.CallQueueStatsRequest{
+			Hosts: []string{"someotherhost"},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Hosts, test.ShouldBeEmpty)
+		test.That(t, resp.Calls, test.ShouldBeEmpty)
+	})
+
+	t.Run("call queue without stats", func(t *testing.T) {
+		noStatsServer := NewWebRTCSignalingAdminServer(struct{ WebRTCCallQueue }{callQueue})
+		_, err := noStatsServer.CallQueueStats(context.Background(), &webrtcpb.CallQueueStatsRequest{})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unimplemented)
+	})
+}
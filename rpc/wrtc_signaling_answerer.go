@@ -35,18 +35,29 @@ type webrtcSignalingAnswerer struct {
 	cancelBackgroundWorkers func()
 	closeCtx                context.Context
 	logger                  golog.Logger
+
+	// offerDeadline, if set, is how long an offer has to be answered when the signaling
+	// server does not say.
+	offerDeadline time.Duration
+
+	// peerConns are the peer connections answered that are still in use, by call UUID.
+	peerConnsMu sync.Mutex
+	peerConns   map[string]*webrtc.PeerConnection
 }
 
 // newWebRTCSignalingAnswerer makes an answerer that will connect to and listen for calls at the given
 // address. Note that using this assumes that the connection at the given address is secure and
 // assumed that all calls are authenticated. Random ports will be opened on this host to establish
 // connections as a means to service ICE (https://webrtcforthecurious.com/docs/03-connecting/#how-does-it-work).
+// The offer deadline, if non-zero, is how long an offer has to be answered when the signaling
+// server does not send a deadline along with it.
 func newWebRTCSignalingAnswerer(
 	address string,
 	hosts []string,
 	server *webrtcServer,
 	dialOpts []DialOption,
 	webrtcConfig webrtc.Configuration,
+	offerDeadline time.Duration,
 	logger golog.Logger,
 ) *webrtcSignalingAnswerer {
 	dialOptsCopy := make([]DialOption, len(dialOpts))
@@ -59,10 +70,21 @@ func newWebRTCSignalingAnswerer(
 		server:                  server,
 		dialOpts:                dialOptsCopy,
 		webrtcConfig:            webrtcConfig,
+		offerDeadline:           offerDeadline,
 		cancelBackgroundWorkers: cancel,
 		closeCtx:                closeCtx,
 		logger:                  logger,
+		peerConns:               map[string]*webrtc.PeerConnection{},
+	}
+}
+
+// getOfferDeadline returns how long an offer has to be answered when the signaling server
+// does not say, falling back to the package default if unset.
+func (ans *webrtcSignalingAnswerer) getOfferDeadline() time.Duration {
+	if ans.offerDeadline == 0 {
+		return getDefaultOfferDeadline()
 	}
+	return ans.offerDeadline
 }
 
 const (
@@ -235,6 +257,9 @@ func (ans *webrtcSignalingAnswerer) answer(client webrtcpb.SignalingService_Answ
 		})
 	}
 	init := initStage.Init
+	if init.IceRestartUuid != "" {
+		return ans.answerICERestart(client, uuid, init)
+	}
 
 	disableTrickle := false
 	if init.OptionalConfig != nil {
@@ -284,7 +309,7 @@ func (ans *webrtcSignalingAnswerer) answer(client webrtcpb.SignalingService_Answ
 	if initStage.Init.Deadline != nil {
 		exchangeCtx, exchangeCancel = context.WithDeadline(ans.closeCtx, initStage.Init.Deadline.AsTime())
 	} else {
-		exchangeCtx, exchangeCancel = context.WithTimeout(ans.closeCtx, getDefaultOfferDeadline())
+		exchangeCtx, exchangeCancel = context.WithTimeout(ans.closeCtx, ans.getOfferDeadline())
 	}
 
 	errCh := make(chan interface{})
@@ -465,5 +490,6 @@ func (ans *webrtcSignalingAnswerer) answer(client webrtcpb.SignalingService_Answ
 		return err
 	}
 	successful = true
+	ans.trackPeerConn(uuid, pc, serverChannel)
 	return nil
 }
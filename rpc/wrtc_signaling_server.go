@@ -26,7 +26,8 @@ import (
 // and many answerers. The callers provide an SDP to the service which asks a corresponding
 // waiting answerer to provide an SDP in exchange in order to establish a P2P connection between
 // the two parties.
-// Note: authorization should happen by something wrapping this service server.
+// Note: authentication should happen by something wrapping this service server. Which hosts
+// an authenticated entity may call or answer for is decided by a SignalingAuthorizer, if set.
 type WebRTCSignalingServer struct {
 	webrtcpb.UnimplementedSignalingServiceServer
 	mu                   sync.RWMutex
@@ -34,6 +35,8 @@ type WebRTCSignalingServer struct {
 	hostICEServers       map[string]hostICEServers
 	webrtcConfigProvider WebRTCConfigProvider
 	forHosts             map[string]struct{}
+	authorizer           SignalingAuthorizer
+	offerDeadline        time.Duration
 
 	activeBackgroundWorkers sync.WaitGroup
 	cancelCtx               context.Context
@@ -51,8 +54,29 @@ func NewWebRTCSignalingServer(
 	logger golog.Logger,
 	forHosts ...string,
 ) *WebRTCSignalingServer {
-	forHostsSet := make(map[string]struct{}, len(forHosts))
-	for _, host := range forHosts {
+	return NewWebRTCSignalingServerWithOptions(
+		callQueue,
+		webrtcConfigProvider,
+		logger,
+		WithSignalingForHosts(forHosts...),
+	)
+}
+
+// NewWebRTCSignalingServerWithOptions makes a new signaling server that uses the given
+// call queue and changes its behavior with the given options.
+func NewWebRTCSignalingServerWithOptions(
+	callQueue WebRTCCallQueue,
+	webrtcConfigProvider WebRTCConfigProvider,
+	logger golog.Logger,
+	opts ...WebRTCSignalingServerOption,
+) *WebRTCSignalingServer {
+	var sOpts webrtcSignalingServerOptions
+	for _, opt := range opts {
+		opt.apply(&sOpts)
+	}
+
+	forHostsSet := make(map[string]struct{}, len(sOpts.forHosts))
+	for _, host := range sOpts.forHosts {
 		forHostsSet[host] = struct{}{}
 	}
 
@@ -62,12 +86,23 @@ func NewWebRTCSignalingServer(
 		hostICEServers:       map[string]hostICEServers{},
 		webrtcConfigProvider: webrtcConfigProvider,
 		forHosts:             forHostsSet,
+		authorizer:           sOpts.authorizer,
+		offerDeadline:        sOpts.offerDeadline,
 		cancelCtx:            cancelCtx,
 		cancelFunc:           cancelFunc,
 		logger:               logger,
 	}
 }
 
+// getOfferDeadline returns how long a call has to be answered, falling back to the package
+// default if unset.
+func (srv *WebRTCSignalingServer) getOfferDeadline() time.Duration {
+	if srv.offerDeadline == 0 {
+		return getDefaultOfferDeadline()
+	}
+	return srv.offerDeadline
+}
+
 // RPCHostMetadataField is the identifier of a host.
 const RPCHostMetadataField = "rpc-host"
 
@@ -123,10 +158,56 @@ func (srv *WebRTCSignalingServer) validateHosts(hosts ...string) error {
 	return nil
 }
 
+// A SignalingAuthorizer decides which hosts an authenticated entity may signal for, such as
+// which robots a user may reach and which robot may answer for itself. Errors that are not
+// already gRPC statuses are returned to the client as PermissionDenied.
+type SignalingAuthorizer interface {
+	// AuthorizeCall returns an error if the entity may not call the given hosts. It is
+	// consulted for Call, CallUpdate and OptionalWebRTCConfig.
+	AuthorizeCall(ctx context.Context, entity EntityInfo, hosts []string) error
+
+	// AuthorizeAnswer returns an error if the entity may not answer calls for the given
+	// hosts. It is consulted for Answer.
+	AuthorizeAnswer(ctx context.Context, entity EntityInfo, hosts []string) error
+}
+
+func (srv *WebRTCSignalingServer) authorizeCall(ctx context.Context, hosts ...string) error {
+	if srv.authorizer == nil {
+		return nil
+	}
+	return srv.authorize(ctx, hosts, srv.authorizer.AuthorizeCall)
+}
+
+func (srv *WebRTCSignalingServer) authorizeAnswer(ctx context.Context, hosts ...string) error {
+	if srv.authorizer == nil {
+		return nil
+	}
+	return srv.authorize(ctx, hosts, srv.authorizer.AuthorizeAnswer)
+}
+
+func (srv *WebRTCSignalingServer) authorize(
+	ctx context.Context,
+	hosts []string,
+	authorizeFunc func(ctx context.Context, entity EntityInfo, hosts []string) error,
+) error {
+	entity, ok := ContextAuthEntity(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	err := authorizeFunc(ctx, entity, hosts)
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
 // Call is a request/offer to start a caller with the connected answerer.
 func (srv *WebRTCSignalingServer) Call(req *webrtcpb.CallRequest, server webrtcpb.SignalingService_CallServer) (callErr error) {
 	ctx := server.Context()
-	ctx, cancel := context.WithTimeout(ctx, getDefaultOfferDeadline())
+	ctx, cancel := context.WithTimeout(ctx, srv.getOfferDeadline())
 	defer cancel()
 
 	host, err := HostFromCtx(ctx)
@@ -136,8 +217,12 @@ func (srv *WebRTCSignalingServer) Call(req *webrtcpb.CallRequest, server webrtcp
 	if err := srv.validateHosts(host); err != nil {
 		return err
 	}
+	if err := srv.authorizeCall(ctx, host); err != nil {
+		return err
+	}
 
-	uuid, respCh, respDone, sendCancel, err := srv.callQueue.SendOfferInit(ctx, host, req.Sdp, req.DisableTrickle)
+	uuid, respCh, respDone, sendCancel, err := srv.callQueue.SendOfferInit(
+		ctx, host, req.Sdp, req.DisableTrickle, req.IceRestartUuid)
 	if err != nil {
 		return err
 	}
@@ -237,7 +322,7 @@ func (srv *WebRTCSignalingServer) Call(req *webrtcpb.CallRequest, server webrtcp
 // In a world where https://github.com/grpc/grpc-web/issues/24 is fixed,
 // this should be removed in favor of a bidirectional stream on Call.
 func (srv *WebRTCSignalingServer) CallUpdate(ctx context.Context, req *webrtcpb.CallUpdateRequest) (*webrtcpb.CallUpdateResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, getDefaultOfferDeadline())
+	ctx, cancel := context.WithTimeout(ctx, srv.getOfferDeadline())
 	defer cancel()
 	host, err := HostFromCtx(ctx)
 	if err != nil {
@@ -246,6 +331,9 @@ func (srv *WebRTCSignalingServer) CallUpdate(ctx context.Context, req *webrtcpb.
 	if err := srv.validateHosts(host); err != nil {
 		return nil, err
 	}
+	if err := srv.authorizeCall(ctx, host); err != nil {
+		return nil, err
+	}
 	switch u := req.Update.(type) {
 	case *webrtcpb.CallUpdateRequest_Candidate:
 		cand := iceCandidateFromProto(u.Candidate)
@@ -319,6 +407,9 @@ func (srv *WebRTCSignalingServer) Answer(server webrtcpb.SignalingService_Answer
 	if err := srv.validateHosts(hosts...); err != nil {
 		return err
 	}
+	if err := srv.authorizeAnswer(ctx, hosts...); err != nil {
+		return err
+	}
 	defer srv.clearAdditionalICEServers(hosts)
 
 	offer, err := srv.callQueue.RecvOffer(ctx, hosts)
@@ -342,7 +433,8 @@ func (srv *WebRTCSignalingServer) Answer(server webrtcpb.SignalingService_Answer
 					AdditionalIceServers: iceServers,
 					DisableTrickle:       offer.DisableTrickleICE(),
 				},
-				Deadline: timestamppb.New(offer.Deadline()),
+				Deadline:       timestamppb.New(offer.Deadline()),
+				IceRestartUuid: offer.ICERestartUUID(),
 			},
 		},
 	}); err != nil {
@@ -488,7 +580,7 @@ func (srv *WebRTCSignalingServer) OptionalWebRTCConfig(
 	ctx context.Context,
 	req *webrtcpb.OptionalWebRTCConfigRequest,
 ) (*webrtcpb.OptionalWebRTCConfigResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, getDefaultOfferDeadline())
+	ctx, cancel := context.WithTimeout(ctx, srv.getOfferDeadline())
 	defer cancel()
 	hosts, err := HostsFromCtx(ctx)
 	if err != nil {
@@ -497,6 +589,9 @@ func (srv *WebRTCSignalingServer) OptionalWebRTCConfig(
 	if err := srv.validateHosts(hosts...); err != nil {
 		return nil, err
 	}
+	if err := srv.authorizeCall(ctx, hosts...); err != nil {
+		return nil, err
+	}
 	iceServers, err := srv.additionalICEServers(ctx, hosts, false)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,62 @@
+package rpc
+
+import "time"
+
+// webrtcSignalingServerOptions change the runtime behavior of a WebRTCSignalingServer.
+type webrtcSignalingServerOptions struct {
+	// forHosts, if non-empty, are the only hosts that may be called and answered for.
+	forHosts []string
+
+	// authorizer, if set, decides which hosts an authenticated entity may signal for.
+	authorizer SignalingAuthorizer
+
+	// offerDeadline, if set, is how long a call has to be answered.
+	offerDeadline time.Duration
+}
+
+// A WebRTCSignalingServerOption changes the runtime behavior of a WebRTCSignalingServer.
+type WebRTCSignalingServerOption interface {
+	apply(*webrtcSignalingServerOptions)
+}
+
+// funcWebRTCSignalingServerOption wraps a function that modifies webrtcSignalingServerOptions
+// into an implementation of the WebRTCSignalingServerOption interface.
+type funcWebRTCSignalingServerOption struct {
+	f func(*webrtcSignalingServerOptions)
+}
+
+func (fo *funcWebRTCSignalingServerOption) apply(o *webrtcSignalingServerOptions) {
+	fo.f(o)
+}
+
+func newFuncWebRTCSignalingServerOption(f func(*webrtcSignalingServerOptions)) *funcWebRTCSignalingServerOption {
+	return &funcWebRTCSignalingServerOption{
+		f: f,
+	}
+}
+
+// WithSignalingForHosts returns a WebRTCSignalingServerOption which makes the server only
+// accept the given hosts and reject all others.
+func WithSignalingForHosts(hosts ...string) WebRTCSignalingServerOption {
+	return newFuncWebRTCSignalingServerOption(func(o *webrtcSignalingServerOptions) {
+		o.forHosts = append(o.forHosts, hosts...)
+	})
+}
+
+// WithSignalingAuthorizer returns a WebRTCSignalingServerOption which has the server consult
+// the given authorizer before signaling for any host. Requests that were not authenticated
+// are rejected once an authorizer is set.
+func WithSignalingAuthorizer(authorizer SignalingAuthorizer) WebRTCSignalingServerOption {
+	return newFuncWebRTCSignalingServerOption(func(o *webrtcSignalingServerOptions) {
+		o.authorizer = authorizer
+	})
+}
+
+// WithSignalingOfferDeadline returns a WebRTCSignalingServerOption which sets how long a call
+// has to be answered. It should match the deadline of the call queue, if the queue takes one
+// (see WithCallQueueOfferDeadline); the shorter of the two wins.
+func WithSignalingOfferDeadline(deadline time.Duration) WebRTCSignalingServerOption {
+	return newFuncWebRTCSignalingServerOption(func(o *webrtcSignalingServerOptions) {
+		o.offerDeadline = deadline
+	})
+}
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/google/uuid"
 	"github.com/pion/webrtc/v3"
 	"go.viam.com/test"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	echopb "go.viam.com/utils/proto/rpc/examples/echo/v1"
 	webrtcpb "go.viam.com/utils/proto/rpc/webrtc/v1"
@@ -70,6 +73,7 @@ func testWebRTCSignaling(t *testing.T, signalingCallQueue WebRTCCallQueue, logge
 				webrtcServer,
 				[]DialOption{WithInsecure()},
 				webrtc.Configuration{},
+				0,
 				logger,
 			)
 			answerer.Start()
@@ -177,6 +181,7 @@ func TestWebRTCAnswererImmediateStop(t *testing.T) {
 		webrtcServer,
 		[]DialOption{WithInsecure()},
 		webrtc.Configuration{},
+		0,
 		logger,
 	)
 
@@ -185,3 +190,121 @@ func TestWebRTCAnswererImmediateStop(t *testing.T) {
 	go answerer.Start()
 	go answerer.Stop()
 }
+
+// hostSignalingAuthorizer lets users call the robots they own and robots answer for themselves.
+type hostSignalingAuthorizer struct {
+	robotsByUser map[string][]string
+}
+
+func (a *hostSignalingAuthorizer) AuthorizeCall(ctx context.Context, entity EntityInfo, hosts []string) error {
+	for _, host := range hosts {
+		var owned bool
+		for _, robot := range a.robotsByUser[entity.Entity] {
+			if robot == host {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			return fmt.Errorf("%q may not call %q", entity.Entity, host)
+		}
+	}
+	return nil
+}
+
+func (a *hostSignalingAuthorizer) AuthorizeAnswer(ctx context.Context, entity EntityInfo, hosts []string) error {
+	for _, host := range hosts {
+		if host != entity.Entity {
+			return status.Errorf(codes.PermissionDenied, "%q may not answer for %q", entity.Entity, host)
+		}
+	}
+	return nil
+}
+
+// contextAnswerServer is an answer stream that only has a context.
+type contextAnswerServer struct {
+	webrtcpb.SignalingService_AnswerServer
+	ctx context.Context
+}
+
+func (s *contextAnswerServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestWebRTCSignalingAuthorizer(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	signalingCallQueue := NewMemoryWebRTCCallQueue(logger)
+	defer func() {
+		test.That(t, signalingCallQueue.Close(), test.ShouldBeNil)
+	}()
+
+	signalingServer := NewWebRTCSignalingServerWithOptions(signalingCallQueue, nil, logger,
+		WithSignalingAuthorizer(&hostSignalingAuthorizer{
+			robotsByUser: map[string][]string{"alice": {"robot1"}},
+		}),
+	)
+	defer signalingServer.Close()
+
+	ctxFor := func(entity string, hosts ...string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{RPCHostMetadataField: hosts})
+		if entity == "" {
+			return ctx
+		}
+		return ContextWithAuthEntity(ctx, EntityInfo{Entity: entity})
+	}
+
+	t.Run("call", func(t *testing.T) {
+		_, err := signalingServer.OptionalWebRTCConfig(ctxFor("alice", "robot1"), &webrtcpb.OptionalWebRTCConfigRequest{})
+		test.That(t, err, test.ShouldBeNil)
+
+		_, err = signalingServer.OptionalWebRTCConfig(ctxFor("alice", "robot2"), &webrtcpb.OptionalWebRTCConfigRequest{})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+		test.That(t, status.Convert(err).Message(), test.ShouldContainSubstring, `"alice" may not call "robot2"`)
+
+		_, err = signalingServer.CallUpdate(ctxFor("bob", "robot1"), &webrtcpb.CallUpdateRequest{
+			Uuid:   uuid.NewString(),
+			Update: &webrtcpb.CallUpdateRequest_Done{Done: true},
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+
+		_, err = signalingServer.OptionalWebRTCConfig(ctxFor("", "robot1"), &webrtcpb.OptionalWebRTCConfigRequest{})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unauthenticated)
+	})
+
+	t.Run("answer", func(t *testing.T) {
+		err := signalingServer.Answer(&contextAnswerServer{ctx: ctxFor("robot1", "robot1", "robot2")})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+		test.That(t, status.Convert(err).Message(), test.ShouldContainSubstring, `"robot1" may not answer for "robot2"`)
+
+		err = signalingServer.Answer(&contextAnswerServer{ctx: ctxFor("", "robot1")})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unauthenticated)
+
+		// authorized answerers go on to wait for a call
+		ctx, cancel := context.WithTimeout(ctxFor("robot1", "robot1"), 100*time.Millisecond)
+		defer cancel()
+		err = signalingServer.Answer(&contextAnswerServer{ctx: ctx})
+		test.That(t, err, test.ShouldBeError, context.DeadlineExceeded)
+	})
+
+	t.Run("internal signaling", func(t *testing.T) {
+		authorizer := &hostSignalingAuthorizer{}
+		rpcServer, err := NewServer(
+			logger,
+			WithUnauthenticated(),
+			WithDisableMulticastDNS(),
+			WithWebRTCServerOptions(WebRTCServerOptions{
+				Enable:                      true,
+				EnableInternalSignaling:     true,
+				InternalSignalingAuthorizer: authorizer,
+			}),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, rpcServer.(*simpleServer).signalingServer.authorizer, test.ShouldEqual, authorizer)
+		test.That(t, rpcServer.Stop(), test.ShouldBeNil)
+	})
+}
@@ -102,3 +102,19 @@ func BackingMongoDBURI(tb testing.TB) string {
 	}
 	return mongoURI
 }
+
+func backingRedisURI() (string, error) {
+	redisURI, ok := os.LookupEnv("TEST_REDIS_URI")
+	if !ok || redisURI == "" {
+		return "", errors.New("no Redis URI found")
+	}
+	return redisURI, nil
+}
+
+func backingPostgresURI() (string, error) {
+	postgresURI, ok := os.LookupEnv("TEST_POSTGRES_URI")
+	if !ok || postgresURI == "" {
+		return "", errors.New("no PostgreSQL URI found")
+	}
+	return postgresURI, nil
+}
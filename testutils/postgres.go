@@ -0,0 +1,55 @@
+package testutils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	cachedBackingPostgresPool    *pgxpool.Pool
+	errCachedBackingPostgresPool error
+)
+
+func backingPostgresPool() (*pgxpool.Pool, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cachedBackingPostgresPool != nil {
+		return cachedBackingPostgresPool, nil
+	}
+	if errCachedBackingPostgresPool != nil {
+		return nil, errCachedBackingPostgresPool
+	}
+	postgresURI, err := backingPostgresURI()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool, err := pgxpool.New(ctx, postgresURI)
+	if err != nil {
+		errCachedBackingPostgresPool = err
+		return nil, errCachedBackingPostgresPool
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		errCachedBackingPostgresPool = err
+		return nil, errCachedBackingPostgresPool
+	}
+	cachedBackingPostgresPool = pool
+	return pool, nil
+}
+
+// BackingPostgresPool returns a backing PostgreSQL connection pool to use. It is taken from the
+// TEST_POSTGRES_URI environment variable (e.g. postgres://localhost:5432/test).
+func BackingPostgresPool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+	pool, err := backingPostgresPool()
+	if err != nil {
+		skipWithError(tb, err)
+		return nil
+	}
+	return pool
+}
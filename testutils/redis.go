@@ -0,0 +1,56 @@
+package testutils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/multierr"
+)
+
+var (
+	cachedBackingRedisClient    *redis.Client
+	errCachedBackingRedisClient error
+)
+
+func backingRedisClient() (*redis.Client, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cachedBackingRedisClient != nil {
+		return cachedBackingRedisClient, nil
+	}
+	if errCachedBackingRedisClient != nil {
+		return nil, errCachedBackingRedisClient
+	}
+	redisURI, err := backingRedisURI()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := redis.ParseURL(redisURI)
+	if err != nil {
+		errCachedBackingRedisClient = err
+		return nil, errCachedBackingRedisClient
+	}
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		errCachedBackingRedisClient = multierr.Combine(err, client.Close())
+		return nil, errCachedBackingRedisClient
+	}
+	cachedBackingRedisClient = client
+	return client, nil
+}
+
+// BackingRedisClient returns a backing Redis client to use. It is taken from the TEST_REDIS_URI
+// environment variable (e.g. redis://localhost:6379/0).
+func BackingRedisClient(tb testing.TB) *redis.Client {
+	tb.Helper()
+	client, err := backingRedisClient()
+	if err != nil {
+		skipWithError(tb, err)
+		return nil
+	}
+	return client
+}